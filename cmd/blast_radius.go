@@ -0,0 +1,71 @@
+// Package cmd provides the CLI commands for the docker-network-viz tool.
+// This file contains the blast-radius command which shows lateral movement paths.
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/output"
+)
+
+// blastRadiusCmd represents the blast-radius command.
+var blastRadiusCmd = &cobra.Command{
+	Use:   "blast-radius CONTAINER",
+	Short: "Show every container reachable from a compromised container",
+	Long: `Assume the given container is compromised and show every container an
+attacker could move to from it, hop by hop over shared networks.
+
+Each reached container is in turn treated as compromised, so containers
+attached to several networks become pivots into their other networks. Every
+reachable container is listed once, under the container it is first reached
+from, with the networks that carry the hop. Networks that disable
+inter-container communication carry no hops, and stopped containers are
+skipped.
+
+Examples:
+  # Show the blast radius of the web container
+  docker-network-viz blast-radius web
+
+  # Review a Compose project before deploying it
+  docker-network-viz blast-radius shop-web-1 -f docker-compose.yml`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBlastRadius,
+}
+
+func init() {
+	// Add blast-radius command to root
+	rootCmd.AddCommand(blastRadiusCmd)
+}
+
+// runBlastRadius executes the blast-radius command logic.
+func runBlastRadius(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	// Initialize Docker client
+	client, err := newDockerClient()
+	if err != nil {
+		return fmt.Errorf("failed to create Docker client: %w", err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	topo, err := fetchTopology(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := topo.containerMap[args[0]]; !ok {
+		return fmt.Errorf("container %s not found", args[0])
+	}
+
+	radius := output.BlastRadiusOf(args[0], topo.networkToContainers, output.NetworksByName(topo.networkInfos()))
+
+	fmt.Fprintln(cmd.OutOrStdout(), "=== Blast Radius ===")
+	output.PrintBlastRadius(cmd.OutOrStdout(), radius)
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// TestBlastRadiusCommandExists verifies that the blast-radius command is properly defined.
+func TestBlastRadiusCommandExists(t *testing.T) {
+	if blastRadiusCmd == nil {
+		t.Fatal("blast-radius command should not be nil")
+	}
+
+	if blastRadiusCmd.Use != "blast-radius CONTAINER" {
+		t.Errorf("unexpected Use %q", blastRadiusCmd.Use)
+	}
+
+	if err := blastRadiusCmd.Args(blastRadiusCmd, nil); err == nil {
+		t.Error("blast-radius command should require a container")
+	}
+}
+
+// writeBlastRadiusCompose writes a Compose file where the api service pivots
+// from the front network into the back network.
+func writeBlastRadiusCompose(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "docker-compose.yml")
+	content := `
+services:
+  web:
+    image: nginx
+    networks: [front]
+  api:
+    image: shop/api
+    networks: [front, back]
+  db:
+    image: postgres
+    networks: [back]
+networks:
+  front:
+  back:
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// TestRunBlastRadiusWithComposeFile verifies the blast radius built from a Compose file.
+func TestRunBlastRadiusWithComposeFile(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("compose-file", []string{writeBlastRadiusCompose(t)})
+	viper.Set("project-name", "shop")
+
+	buf := new(bytes.Buffer)
+	blastRadiusCmd.SetOut(buf)
+	defer blastRadiusCmd.SetOut(nil)
+
+	if err := runBlastRadius(blastRadiusCmd, []string{"shop-web-1"}); err != nil {
+		t.Fatalf("runBlastRadius returned error: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"=== Blast Radius ===",
+		"Compromised: shop-web-1",
+		"└── shop-api-1 via shop_front [pivot]",
+		"    └── shop-db-1 via shop_back",
+		"Reachable: 2 container(s) on 2 network(s), up to 2 hop(s)",
+		"Pivots: shop-api-1",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+// TestRunBlastRadiusUnknownContainer verifies that unknown containers are rejected.
+func TestRunBlastRadiusUnknownContainer(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("compose-file", []string{writeBlastRadiusCompose(t)})
+	viper.Set("project-name", "shop")
+
+	err := runBlastRadius(blastRadiusCmd, []string{"missing"})
+	if err == nil || !strings.Contains(err.Error(), "container missing not found") {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
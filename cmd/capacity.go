@@ -0,0 +1,133 @@
+// Package cmd provides the CLI commands for the docker-network-viz tool.
+// This file contains the capacity command which reports address pool utilization.
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/ipam"
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+	"git.o.ocom.com.au/go/docker-network-viz/internal/output"
+)
+
+var (
+	// capacityWarnThreshold is the utilization at which a pool is flagged.
+	capacityWarnThreshold float64
+
+	// capacityFailOnWarning makes the command fail when any pool is flagged.
+	capacityFailOnWarning bool
+
+	// capacityCmd represents the capacity command.
+	capacityCmd = &cobra.Command{
+		Use:   "capacity [NETWORK...]",
+		Short: "Report network address pool utilization",
+		Long: `Report how many addresses in each network's IPAM subnets and IP ranges are
+allocated to container endpoints and how many remain available.
+
+Pools at or above the warning threshold are flagged as nearly exhausted, so
+small networks can be resized before Docker reports "no available IPv4
+addresses".
+
+Examples:
+  # Report all networks
+  docker-network-viz capacity
+
+  # Report specific networks
+  docker-network-viz capacity frontend_net backend_net
+
+  # Warn at 90% and exit with an error when any pool is flagged
+  docker-network-viz capacity --warn-threshold 0.9 --fail-on-warning`,
+		RunE: runCapacity,
+	}
+)
+
+func init() {
+	// Add capacity command to root
+	rootCmd.AddCommand(capacityCmd)
+
+	// Local flags for capacity command
+	capacityCmd.Flags().Float64Var(&capacityWarnThreshold, "warn-threshold", ipam.DefaultWarnThreshold,
+		"utilization (0-1) at which a pool is flagged as nearly exhausted")
+	capacityCmd.Flags().BoolVar(&capacityFailOnWarning, "fail-on-warning", false,
+		"exit with an error when any pool is nearly exhausted")
+
+	// Bind flags to viper
+	_ = viper.BindPFlag("capacity.warn-threshold", capacityCmd.Flags().Lookup("warn-threshold"))
+	_ = viper.BindPFlag("capacity.fail-on-warning", capacityCmd.Flags().Lookup("fail-on-warning"))
+}
+
+// runCapacity executes the capacity command logic.
+func runCapacity(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	// Initialize Docker client
+	client, err := newDockerClient()
+	if err != nil {
+		return fmt.Errorf("failed to create Docker client: %w", err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	topo, err := fetchTopology(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	threshold := viper.GetFloat64("capacity.warn-threshold")
+	warnings, err := printCapacity(cmd.OutOrStdout(), topo.networkInfos(), args, threshold)
+	if err != nil {
+		return err
+	}
+
+	if warnings > 0 && viper.GetBool("capacity.fail-on-warning") {
+		return fmt.Errorf("%d address pool(s) at or above %.0f%% utilization", warnings, threshold*100)
+	}
+
+	return nil
+}
+
+// printCapacity prints the capacity report for the given networks, restricted
+// to the named networks when names is not empty. It returns the number of
+// pools at or above the warning threshold.
+func printCapacity(w io.Writer, networks []*models.NetworkInfo, names []string, threshold float64) (int, error) {
+	if threshold <= 0 || threshold > 1 {
+		return 0, fmt.Errorf("invalid warning threshold %v: must be between 0 and 1", threshold)
+	}
+
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[name] = true
+	}
+
+	fmt.Fprintln(w, "=== Address Pools ===")
+
+	warnings := 0
+	found := 0
+	for _, net := range networks {
+		if len(wanted) > 0 && !wanted[net.Name] {
+			continue
+		}
+		found++
+
+		output.PrintNetworkCapacity(w, *net, threshold)
+		fmt.Fprintln(w)
+
+		for _, pool := range ipam.NetworkUsage(*net, net.Addresses) {
+			if pool.NearlyExhausted(threshold) {
+				warnings++
+			}
+		}
+	}
+
+	if len(wanted) > 0 && found < len(wanted) {
+		return warnings, fmt.Errorf("%d of the requested networks were not found", len(wanted)-found)
+	}
+
+	return warnings, nil
+}
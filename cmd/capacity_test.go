@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// capacityTestNetworks returns networks with small, nearly full and pool-less networks.
+func capacityTestNetworks() []*models.NetworkInfo {
+	return []*models.NetworkInfo{
+		{
+			Name:      "full_net",
+			Driver:    "bridge",
+			Subnets:   []models.SubnetInfo{{Subnet: "172.20.0.0/29", Gateway: "172.20.0.1"}},
+			Addresses: []string{"172.20.0.2", "172.20.0.3", "172.20.0.4", "172.20.0.5"},
+		},
+		{
+			Name:    "roomy_net",
+			Driver:  "bridge",
+			Subnets: []models.SubnetInfo{{Subnet: "10.10.0.0/24", Gateway: "10.10.0.1"}},
+		},
+		{Name: "host", Driver: "host"},
+	}
+}
+
+// TestCapacityCommandExists verifies that the capacity command is properly defined.
+func TestCapacityCommandExists(t *testing.T) {
+	if capacityCmd == nil {
+		t.Fatal("capacity command should not be nil")
+	}
+
+	if !strings.HasPrefix(capacityCmd.Use, "capacity") {
+		t.Errorf("capacity command Use should start with 'capacity', got %q", capacityCmd.Use)
+	}
+
+	for _, name := range []string{"warn-threshold", "fail-on-warning"} {
+		if capacityCmd.Flags().Lookup(name) == nil {
+			t.Errorf("capacity command should have a %s flag", name)
+		}
+	}
+}
+
+// TestPrintCapacity verifies the report output and warning count.
+func TestPrintCapacity(t *testing.T) {
+	buf := new(bytes.Buffer)
+
+	warnings, err := printCapacity(buf, capacityTestNetworks(), nil, 0.8)
+	if err != nil {
+		t.Fatalf("printCapacity should not return error: %v", err)
+	}
+
+	if warnings != 1 {
+		t.Errorf("expected 1 warning, got %d", warnings)
+	}
+
+	out := buf.String()
+	expected := []string{
+		"=== Address Pools ===",
+		"Network: full_net (bridge)",
+		"172.20.0.0/29: 4 used, 1 available of 5 (80%) WARNING: nearly exhausted",
+		"10.10.0.0/24: 0 used, 253 available of 253 (0%)",
+		"Network: host (host)",
+		"(no address pools)",
+	}
+	for _, want := range expected {
+		if !strings.Contains(out, want) {
+			t.Errorf("output should contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+// TestPrintCapacityFiltersNetworks verifies filtering by network name.
+func TestPrintCapacityFiltersNetworks(t *testing.T) {
+	buf := new(bytes.Buffer)
+
+	warnings, err := printCapacity(buf, capacityTestNetworks(), []string{"roomy_net"}, 0.8)
+	if err != nil {
+		t.Fatalf("printCapacity should not return error: %v", err)
+	}
+
+	if warnings != 0 {
+		t.Errorf("expected no warnings, got %d", warnings)
+	}
+	if strings.Contains(buf.String(), "full_net") {
+		t.Error("output should not contain filtered-out networks")
+	}
+
+	_, err = printCapacity(new(bytes.Buffer), capacityTestNetworks(), []string{"missing_net"}, 0.8)
+	if err == nil {
+		t.Error("expected error for unknown network")
+	}
+}
+
+// TestPrintCapacityInvalidThreshold verifies threshold validation.
+func TestPrintCapacityInvalidThreshold(t *testing.T) {
+	for _, threshold := range []float64{0, -1, 1.5} {
+		if _, err := printCapacity(new(bytes.Buffer), nil, nil, threshold); err == nil {
+			t.Errorf("expected error for threshold %v", threshold)
+		}
+	}
+}
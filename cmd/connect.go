@@ -0,0 +1,222 @@
+// Package cmd provides the CLI commands for the docker-network-viz tool.
+// This file contains the connect and disconnect commands which preview the
+// reachability change of a network attachment before applying it.
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/docker"
+	"git.o.ocom.com.au/go/docker-network-viz/internal/output"
+	"git.o.ocom.com.au/go/docker-network-viz/internal/simulate"
+)
+
+var (
+	// connectAliases are the network-scoped aliases for the new endpoint.
+	connectAliases []string
+
+	// connectIP is a static IPv4 address for the new endpoint.
+	connectIP string
+
+	// connectIP6 is a static IPv6 address for the new endpoint.
+	connectIP6 string
+
+	// disconnectForce disconnects the container even if it is not running.
+	disconnectForce bool
+
+	// attachDryRun prints the preview without changing anything.
+	attachDryRun bool
+
+	// attachYes skips the confirmation prompt.
+	attachYes bool
+
+	// connectCmd represents the connect command.
+	connectCmd = &cobra.Command{
+		Use:   "connect CONTAINER NETWORK",
+		Short: "Connect a container to a network after previewing the reachability change",
+		Long: `Connect a container to a network, like "docker network connect", but first
+show which containers become reachable from it. The change is applied after
+confirmation; use --dry-run to only print the preview, or --yes to skip the
+prompt.
+
+The topology is always read from the Docker daemon; --compose-file is ignored.
+
+Examples:
+  # Preview and confirm
+  docker-network-viz connect api backend
+
+  # Only preview
+  docker-network-viz connect api backend --dry-run
+
+  # Connect with an alias and a static address, without prompting
+  docker-network-viz connect api backend --alias api.internal --ip 172.20.0.10 --yes`,
+		Args: cobra.ExactArgs(2),
+		RunE: runConnect,
+	}
+
+	// disconnectCmd represents the disconnect command.
+	disconnectCmd = &cobra.Command{
+		Use:   "disconnect CONTAINER NETWORK",
+		Short: "Disconnect a container from a network after previewing the reachability change",
+		Long: `Disconnect a container from a network, like "docker network disconnect", but
+first show which containers are no longer reachable from it. Containers that
+share another network with it stay reachable and are not listed. The change is
+applied after confirmation; use --dry-run to only print the preview, or --yes
+to skip the prompt.
+
+The topology is always read from the Docker daemon; --compose-file is ignored.
+
+Examples:
+  # Preview and confirm
+  docker-network-viz disconnect api frontend
+
+  # Disconnect a stopped container without prompting
+  docker-network-viz disconnect api frontend --force --yes`,
+		Args: cobra.ExactArgs(2),
+		RunE: runDisconnect,
+	}
+)
+
+func init() {
+	// Add connect and disconnect commands to root
+	rootCmd.AddCommand(connectCmd)
+	rootCmd.AddCommand(disconnectCmd)
+
+	// Local flags. They are deliberately not bound to viper, so a
+	// configuration file cannot skip the confirmation prompt.
+	connectCmd.Flags().StringSliceVar(&connectAliases, "alias", nil,
+		"network-scoped alias for the container (repeatable)")
+	connectCmd.Flags().StringVar(&connectIP, "ip", "",
+		"static IPv4 address for the container on the network")
+	connectCmd.Flags().StringVar(&connectIP6, "ip6", "",
+		"static IPv6 address for the container on the network")
+	disconnectCmd.Flags().BoolVar(&disconnectForce, "force", false,
+		"disconnect the container even if it is not running")
+
+	for _, c := range []*cobra.Command{connectCmd, disconnectCmd} {
+		c.Flags().BoolVar(&attachDryRun, "dry-run", false,
+			"only print the reachability preview")
+		c.Flags().BoolVarP(&attachYes, "yes", "y", false,
+			"do not ask for confirmation")
+	}
+}
+
+// runConnect executes the connect command logic.
+func runConnect(cmd *cobra.Command, args []string) error {
+	containerName, networkName := args[0], args[1]
+	change := simulate.Change{
+		Action:    simulate.ActionConnect,
+		Container: containerName,
+		Network:   networkName,
+		Aliases:   connectAliases,
+	}
+
+	return runAttachmentChange(cmd, change,
+		func(ctx context.Context, client *docker.Client) error {
+			return client.ConnectNetwork(ctx, networkName, containerName, &docker.ConnectOptions{
+				Aliases:     connectAliases,
+				IPv4Address: connectIP,
+				IPv6Address: connectIP6,
+			})
+		})
+}
+
+// runDisconnect executes the disconnect command logic.
+func runDisconnect(cmd *cobra.Command, args []string) error {
+	containerName, networkName := args[0], args[1]
+	change := simulate.Change{
+		Action:    simulate.ActionDisconnect,
+		Container: containerName,
+		Network:   networkName,
+	}
+
+	return runAttachmentChange(cmd, change,
+		func(ctx context.Context, client *docker.Client) error {
+			return client.DisconnectNetwork(ctx, networkName, containerName, disconnectForce)
+		})
+}
+
+// runAttachmentChange previews connecting or disconnecting a container and,
+// unless --dry-run is given or the user declines, applies the change.
+func runAttachmentChange(
+	cmd *cobra.Command,
+	change simulate.Change,
+	apply func(ctx context.Context, client *docker.Client) error,
+) error {
+	ctx := context.Background()
+	w := cmd.OutOrStdout()
+
+	// Initialize Docker client
+	client, err := newDockerClient()
+	if err != nil {
+		return fmt.Errorf("failed to create Docker client: %w", err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	// The change is applied to the daemon, so it is previewed against it too.
+	topo, err := fetchLiveTopology(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	if err := printAttachmentPreview(w, topo, change); err != nil {
+		return err
+	}
+
+	if attachDryRun {
+		return nil
+	}
+
+	verb, preposition := attachmentVerb(change.Action)
+	question := fmt.Sprintf("%s %s %s %s?", capitalize(verb), change.Container, preposition, change.Network)
+	if !attachYes && !confirm(cmd.InOrStdin(), w, question) {
+		fmt.Fprintln(w, "Aborted; nothing was changed")
+		return nil
+	}
+
+	if err := apply(ctx, client); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "%sed %s %s %s\n", capitalize(verb), change.Container, preposition, change.Network)
+	return nil
+}
+
+// printAttachmentPreview simulates the change on the topology and prints
+// the container's reachability change. It fails if the change cannot be
+// applied, such as connecting a container to a network it is already on.
+func printAttachmentPreview(w io.Writer, topo *topology, change simulate.Change) error {
+	networks := topo.networkInfos()
+	state := simulate.NewState(networks, topo.containerMap)
+	if err := state.Apply([]simulate.Change{change}); err != nil {
+		return fmt.Errorf("failed to preview change: %w", err)
+	}
+
+	fmt.Fprintf(w, "=== Reachability Preview: %s ===\n", change)
+	output.PrintReachabilityChange(w, output.ContainerReachabilityChange(change.Container,
+		topo.networkToContainers, state.NetworkToContainers(), output.NetworksByName(networks)))
+
+	return nil
+}
+
+// attachmentVerb returns the verb and preposition describing the change.
+func attachmentVerb(action simulate.Action) (string, string) {
+	if action == simulate.ActionConnect {
+		return "connect", "to"
+	}
+	return "disconnect", "from"
+}
+
+// capitalize upper-cases the first letter of an ASCII word.
+func capitalize(word string) string {
+	if word == "" || word[0] < 'a' || word[0] > 'z' {
+		return word
+	}
+	return string(word[0]-'a'+'A') + word[1:]
+}
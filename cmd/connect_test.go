@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/network"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+	"git.o.ocom.com.au/go/docker-network-viz/internal/simulate"
+)
+
+// attachmentTopology returns a topology with api on frontend and postgres on backend.
+func attachmentTopology() *topology {
+	api := models.NewContainerInfo("api")
+	api.AddNetwork("frontend")
+	nginx := models.NewContainerInfo("nginx")
+	nginx.AddNetwork("frontend")
+	postgres := models.NewContainerInfo("postgres")
+	postgres.AddNetwork("backend")
+
+	return &topology{
+		networks: []network.Summary{{Name: "backend"}, {Name: "frontend"}},
+		containerMap: map[string]*models.ContainerInfo{
+			"api": api, "nginx": nginx, "postgres": postgres,
+		},
+		networkToContainers: map[string][]models.ContainerInfo{
+			"frontend": {*api, *nginx},
+			"backend":  {*postgres},
+		},
+	}
+}
+
+// TestConnectCommandsExist verifies that the connect and disconnect commands are properly defined.
+func TestConnectCommandsExist(t *testing.T) {
+	if connectCmd.Use != "connect CONTAINER NETWORK" {
+		t.Errorf("unexpected connect Use: %q", connectCmd.Use)
+	}
+	if disconnectCmd.Use != "disconnect CONTAINER NETWORK" {
+		t.Errorf("unexpected disconnect Use: %q", disconnectCmd.Use)
+	}
+
+	for _, name := range []string{"alias", "ip", "ip6", "dry-run", "yes"} {
+		if connectCmd.Flags().Lookup(name) == nil {
+			t.Errorf("connect command should have a %s flag", name)
+		}
+	}
+	for _, name := range []string{"force", "dry-run", "yes"} {
+		if disconnectCmd.Flags().Lookup(name) == nil {
+			t.Errorf("disconnect command should have a %s flag", name)
+		}
+	}
+}
+
+// TestPrintAttachmentPreviewConnect verifies the reachability preview of a connect.
+func TestPrintAttachmentPreviewConnect(t *testing.T) {
+	topo := attachmentTopology()
+
+	var buf bytes.Buffer
+	change := simulate.Change{Action: simulate.ActionConnect, Container: "api", Network: "backend"}
+	if err := printAttachmentPreview(&buf, topo, change); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"=== Reachability Preview: connect api to backend ===",
+		"newly reachable:",
+		"postgres (via backend)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "no longer reachable:") {
+		t.Errorf("expected no lost peers, got:\n%s", out)
+	}
+
+	// The topology itself is not modified.
+	if len(topo.networkToContainers["backend"]) != 1 || topo.containerMap["api"].HasNetwork("backend") {
+		t.Error("preview should not modify the topology")
+	}
+}
+
+// TestPrintAttachmentPreviewDisconnect verifies the reachability preview of a disconnect.
+func TestPrintAttachmentPreviewDisconnect(t *testing.T) {
+	topo := attachmentTopology()
+
+	var buf bytes.Buffer
+	change := simulate.Change{Action: simulate.ActionDisconnect, Container: "api", Network: "frontend"}
+	if err := printAttachmentPreview(&buf, topo, change); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "no longer reachable:") || !strings.Contains(out, "nginx (via frontend)") {
+		t.Errorf("expected nginx to be lost, got:\n%s", out)
+	}
+	if strings.Contains(out, "newly reachable:") {
+		t.Errorf("expected no gained peers, got:\n%s", out)
+	}
+}
+
+// TestPrintAttachmentPreviewErrors verifies validation of the requested change.
+func TestPrintAttachmentPreviewErrors(t *testing.T) {
+	topo := attachmentTopology()
+
+	tests := []struct {
+		name      string
+		container string
+		network   string
+		action    simulate.Action
+	}{
+		{"unknown container", "missing", "backend", simulate.ActionConnect},
+		{"unknown network", "api", "missing", simulate.ActionConnect},
+		{"already connected", "api", "frontend", simulate.ActionConnect},
+		{"not connected", "api", "backend", simulate.ActionDisconnect},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			change := simulate.Change{Action: tt.action, Container: tt.container, Network: tt.network}
+			if err := printAttachmentPreview(new(bytes.Buffer), topo, change); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+// TestCapitalize verifies capitalization of command verbs.
+func TestCapitalize(t *testing.T) {
+	for in, want := range map[string]string{"connect": "Connect", "Disconnect": "Disconnect", "": ""} {
+		if got := capitalize(in); got != want {
+			t.Errorf("capitalize(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
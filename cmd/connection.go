@@ -0,0 +1,100 @@
+// Package cmd provides the CLI commands for the docker-network-viz tool.
+// This file contains the daemon connection flags shared by all commands.
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/docker"
+)
+
+// pluginEnvVar is set by the Docker CLI when it runs a CLI plugin.
+const pluginEnvVar = "DOCKER_CLI_PLUGIN_ORIGINAL_CLI_COMMAND"
+
+var (
+	// dockerHost is the daemon address, overriding DOCKER_HOST.
+	dockerHost string
+
+	// dockerContext is the Docker CLI context to connect to.
+	dockerContext string
+
+	// tlsVerify enables TLS and verifies the daemon's certificate.
+	tlsVerify bool
+
+	// tlsCACert is the CA certificate that signs the daemon's certificate.
+	tlsCACert string
+
+	// tlsCert is the client certificate.
+	tlsCert string
+
+	// tlsKey is the client key.
+	tlsKey string
+)
+
+// addConnectionFlags registers the daemon connection flags and binds them
+// to viper, so they can also be set in the config file and with DNV_HOST,
+// DNV_CONTEXT, DNV_TLSVERIFY, DNV_TLSCACERT, DNV_TLSCERT and DNV_TLSKEY.
+func addConnectionFlags(flags *pflag.FlagSet) {
+	flags.StringVarP(&dockerHost, "host", "H", "",
+		"daemon socket to connect to, such as unix:///var/run/docker.sock, tcp://host:2376 or ssh://user@host")
+	flags.StringVarP(&dockerContext, "context", "c", "",
+		"Docker CLI context to connect to (default: the current context when run as a Docker CLI plugin)")
+	flags.BoolVar(&tlsVerify, "tlsverify", false,
+		"use TLS and verify the daemon's certificate")
+	flags.StringVar(&tlsCACert, "tlscacert", "",
+		"trust certificates signed by this CA (default $DOCKER_CERT_PATH/ca.pem or ~/.docker/ca.pem)")
+	flags.StringVar(&tlsCert, "tlscert", "",
+		"path to the TLS client certificate (default $DOCKER_CERT_PATH/cert.pem or ~/.docker/cert.pem)")
+	flags.StringVar(&tlsKey, "tlskey", "",
+		"path to the TLS client key (default $DOCKER_CERT_PATH/key.pem or ~/.docker/key.pem)")
+
+	_ = viper.BindPFlag("host", flags.Lookup("host"))
+	_ = viper.BindPFlag("context", flags.Lookup("context"))
+	_ = viper.BindPFlag("tlsverify", flags.Lookup("tlsverify"))
+	_ = viper.BindPFlag("tlscacert", flags.Lookup("tlscacert"))
+	_ = viper.BindPFlag("tlscert", flags.Lookup("tlscert"))
+	_ = viper.BindPFlag("tlskey", flags.Lookup("tlskey"))
+}
+
+// connectionConfig builds the daemon connection settings from the flags,
+// the config file and the environment. When running as a Docker CLI plugin
+// without --context, the current Docker context is used unless a host is
+// given.
+func connectionConfig() (docker.ConnectionConfig, error) {
+	cfg := docker.ConnectionConfig{
+		Host:      viper.GetString("host"),
+		TLSVerify: viper.GetBool("tlsverify"),
+		TLSCACert: viper.GetString("tlscacert"),
+		TLSCert:   viper.GetString("tlscert"),
+		TLSKey:    viper.GetString("tlskey"),
+		Context:   viper.GetString("context"),
+	}
+
+	if cfg.Host == "" && cfg.Context == "" && isCLIPlugin() {
+		name, err := docker.CurrentContext(docker.ConfigDir())
+		if err != nil {
+			return cfg, fmt.Errorf("failed to determine Docker context: %w", err)
+		}
+		cfg.Context = name
+	}
+
+	return cfg, nil
+}
+
+// isCLIPlugin reports whether the binary was started by the Docker CLI as a plugin.
+func isCLIPlugin() bool {
+	return os.Getenv(pluginEnvVar) != ""
+}
+
+// newDockerClient creates a Docker client using the connection settings.
+func newDockerClient() (*docker.Client, error) {
+	cfg, err := connectionConfig()
+	if err != nil {
+		return nil, err
+	}
+	return docker.NewClient(docker.WithConnection(cfg))
+}
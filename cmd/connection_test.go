@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/docker"
+)
+
+// TestConnectionFlags verifies that the connection flags are registered on the root command.
+func TestConnectionFlags(t *testing.T) {
+	cmd := GetRootCmd()
+
+	for _, name := range []string{"host", "context", "tlsverify", "tlscacert", "tlscert", "tlskey"} {
+		if cmd.PersistentFlags().Lookup(name) == nil {
+			t.Errorf("root command should have a %s flag", name)
+		}
+	}
+
+	if flag := cmd.PersistentFlags().ShorthandLookup("H"); flag == nil || flag.Name != "host" {
+		t.Error("expected -H to be the shorthand of --host")
+	}
+}
+
+// TestConnectionConfig verifies that the connection settings are read from viper.
+func TestConnectionConfig(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+	t.Setenv(pluginEnvVar, "")
+
+	viper.Set("host", "ssh://deploy@build-1")
+	viper.Set("tlsverify", true)
+	viper.Set("tlscacert", "/certs/ca.pem")
+
+	cfg, err := connectionConfig()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if cfg.Host != "ssh://deploy@build-1" || !cfg.TLSVerify || cfg.TLSCACert != "/certs/ca.pem" {
+		t.Errorf("unexpected configuration: %+v", cfg)
+	}
+	if cfg.Context != "" {
+		t.Errorf("expected no context outside plugin mode, got %q", cfg.Context)
+	}
+}
+
+// TestConnectionConfig_Plugin verifies that the current Docker context is
+// used when running as a Docker CLI plugin.
+func TestConnectionConfig_Plugin(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "config.json"), []byte(`{"currentContext":"remote"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("DOCKER_CONFIG", dir)
+	t.Setenv("DOCKER_CONTEXT", "")
+	t.Setenv("DOCKER_HOST", "")
+	t.Setenv(pluginEnvVar, "docker network-viz")
+
+	cfg, err := connectionConfig()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cfg.Context != "remote" {
+		t.Errorf("expected context remote, got %q", cfg.Context)
+	}
+}
+
+// TestNewDockerClient_UnknownContext verifies that an unknown Docker context is reported.
+func TestNewDockerClient_UnknownContext(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	t.Setenv("DOCKER_CONFIG", t.TempDir())
+	t.Setenv("DOCKER_CONTEXT", "missing")
+	t.Setenv("DOCKER_HOST", "")
+	t.Setenv(pluginEnvVar, "docker network-viz")
+
+	if _, err := newDockerClient(); err == nil {
+		t.Error("expected error for unknown Docker context")
+	}
+}
+
+// TestConnectionConfig_ContextFlag verifies that --context overrides the
+// current Docker context.
+func TestConnectionConfig_ContextFlag(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	t.Setenv("DOCKER_CONTEXT", "remote")
+	t.Setenv(pluginEnvVar, "docker network-viz")
+	viper.Set("context", "staging")
+
+	cfg, err := connectionConfig()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cfg.Context != "staging" {
+		t.Errorf("expected context staging, got %q", cfg.Context)
+	}
+}
+
+// TestConnectionConfig_PluginHost verifies that, as a CLI plugin, --host
+// is not overridden by the current Docker context.
+func TestConnectionConfig_PluginHost(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	t.Setenv("DOCKER_CONTEXT", "remote")
+	t.Setenv(pluginEnvVar, "docker network-viz")
+	viper.Set("host", "tcp://build-1:2376")
+
+	cfg, err := connectionConfig()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cfg.Host != "tcp://build-1:2376" || cfg.Context != "" {
+		t.Errorf("expected --host without a context, got %+v", cfg)
+	}
+}
+
+// TestConnectionConfig_PluginDockerHost verifies that, as a CLI plugin,
+// DOCKER_HOST is used over config.json's current context but --context
+// still wins over DOCKER_HOST.
+func TestConnectionConfig_PluginDockerHost(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "config.json"), []byte(`{"currentContext":"remote"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("DOCKER_CONFIG", dir)
+	t.Setenv("DOCKER_CONTEXT", "")
+	t.Setenv("DOCKER_HOST", "tcp://env:2375")
+	t.Setenv(pluginEnvVar, "docker network-viz")
+
+	cfg, err := connectionConfig()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cfg.Context != docker.DefaultContext {
+		t.Errorf("expected the default context with DOCKER_HOST, got %q", cfg.Context)
+	}
+
+	viper.Set("context", "staging")
+	cfg, err = connectionConfig()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cfg.Context != "staging" {
+		t.Errorf("expected context staging over DOCKER_HOST, got %q", cfg.Context)
+	}
+}
@@ -0,0 +1,111 @@
+// Package cmd provides the CLI commands for the docker-network-viz tool.
+// This file contains the deps command which validates service dependencies.
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/deps"
+	"git.o.ocom.com.au/go/docker-network-viz/internal/output"
+)
+
+// depsCmd represents the deps command.
+var depsCmd = &cobra.Command{
+	Use:   "deps",
+	Short: "Check that the services each container depends on resolve from its networks",
+	Long: `Find the services each container depends on and check that every one of
+them resolves from a network the container is attached to.
+
+Dependencies are read from:
+  - Environment variables that name a host, such as DB_HOST, REDIS_URL or
+    DATABASE_URL; URLs, host:port pairs, lists and DSNs are understood
+  - The com.docker.compose.depends_on label of Compose containers
+
+Each name is resolved like Docker's embedded DNS server would resolve it for
+the container: container names, Compose service names and network-scoped
+aliases of running containers on its user-defined networks, and its legacy
+links. The network tree is printed with the dependencies that resolve on each
+network, followed by those that do not and why, such as the target being on
+a network the container is not attached to. Names containing a dot that no
+container answers to are taken to be external hosts.
+
+Live containers are inspected for their environment. The command fails when
+any dependency does not resolve.
+
+Examples:
+  # Check the dependencies of every container
+  docker-network-viz deps
+
+  # Check a Compose project before deploying it
+  docker-network-viz deps -f docker-compose.yml`,
+	Args: cobra.NoArgs,
+	RunE: runDeps,
+}
+
+func init() {
+	// Add deps command to root
+	rootCmd.AddCommand(depsCmd)
+}
+
+// runDeps executes the deps command logic.
+func runDeps(cmd *cobra.Command, _ []string) error {
+	ctx := context.Background()
+
+	// Initialize Docker client
+	client, err := newDockerClient()
+	if err != nil {
+		return fmt.Errorf("failed to create Docker client: %w", err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	topo, err := fetchTopology(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	// Most dependencies are declared in the environment.
+	if err := topo.loadEnv(ctx, client); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(topo.containerMap))
+	for name := range topo.containerMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	dependencies := make(map[string][]deps.Dependency, len(names))
+	unresolved := 0
+	for _, name := range names {
+		dns, err := client.BuildContainerDNS(topo.containers, name)
+		if err != nil {
+			return fmt.Errorf("failed to build DNS view: %w", err)
+		}
+		dependencies[name] = deps.Check(topo.containerMap[name], dns, topo.containerMap)
+		for _, dep := range dependencies[name] {
+			if !dep.Resolved() {
+				unresolved++
+			}
+		}
+	}
+
+	w := cmd.OutOrStdout()
+	fmt.Fprintln(w, "=== Dependencies ===")
+	for _, netInfo := range topo.networkInfos() {
+		output.PrintDependencyTree(w, *netInfo, topo.networkToContainers[netInfo.Name], dependencies)
+		fmt.Fprintln(w)
+	}
+	output.PrintUnresolvedDependencies(w, dependencies)
+
+	if unresolved > 0 {
+		return fmt.Errorf("%d dependency(s) do not resolve", unresolved)
+	}
+
+	return nil
+}
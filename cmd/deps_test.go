@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// TestDepsCommandExists verifies that the deps command is properly defined.
+func TestDepsCommandExists(t *testing.T) {
+	if depsCmd == nil {
+		t.Fatal("deps command should not be nil")
+	}
+
+	if depsCmd.Use != "deps" {
+		t.Errorf("deps command Use should be 'deps', got %q", depsCmd.Use)
+	}
+
+	if err := depsCmd.Args(depsCmd, []string{"extra"}); err == nil {
+		t.Error("deps command should not accept arguments")
+	}
+}
+
+// TestRunDepsWithComposeFile verifies dependencies checked from a Compose file.
+func TestRunDepsWithComposeFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "docker-compose.yml")
+	content := `
+services:
+  api:
+    image: shop/api
+    environment:
+      DATABASE_URL: postgres://app@db:5432/shop
+      SMTP_HOST: mail
+      PAYMENTS_URL: https://payments.example.com
+    depends_on: [db]
+    networks: [back]
+  db:
+    image: postgres
+    networks:
+      back:
+        aliases: [database]
+  mail:
+    image: mailhog/mailhog
+    networks: [mail]
+networks:
+  back:
+  mail:
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("compose-file", []string{path})
+	viper.Set("project-name", "shop")
+
+	buf := new(bytes.Buffer)
+	depsCmd.SetOut(buf)
+	defer depsCmd.SetOut(nil)
+
+	err := runDeps(depsCmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "1 dependency(s) do not resolve") {
+		t.Errorf("expected an unresolved dependency error, got %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"=== Dependencies ===",
+		"Network: shop_back (bridge)",
+		"depends on: db -> shop-db-1 (DATABASE_URL)",
+		"depends on: db -> shop-db-1 (depends_on)",
+		"Unresolved dependencies:",
+		"mail (SMTP_HOST) ERROR: resolves only on shop_mail, which shop-api-1 is not attached to",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "payments.example.com") {
+		t.Errorf("expected external host to be left out, got:\n%s", out)
+	}
+}
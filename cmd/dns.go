@@ -0,0 +1,69 @@
+// Package cmd provides the CLI commands for the docker-network-viz tool.
+// This file contains the dns command which shows the names a container can resolve.
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/output"
+)
+
+// dnsCmd represents the dns command.
+var dnsCmd = &cobra.Command{
+	Use:   "dns CONTAINER",
+	Short: "Show the names a container can resolve on each of its networks",
+	Long: `Show every name the given container can resolve through Docker's embedded
+DNS server on each of its networks, and the containers and addresses each
+name resolves to.
+
+Container names, network aliases, Compose service names and legacy --link
+names are listed. Names that resolve to several containers are flagged as
+ROUND-ROBIN, and names that resolve to different containers on different
+networks are listed as conflicts. The default bridge network has no embedded
+DNS; only links resolve there.
+
+Examples:
+  # Show what the api container can resolve
+  docker-network-viz dns api
+
+  # Review a Compose project before deploying it
+  docker-network-viz dns shop-api-1 -f docker-compose.yml`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDNS,
+}
+
+func init() {
+	// Add dns command to root
+	rootCmd.AddCommand(dnsCmd)
+}
+
+// runDNS executes the dns command logic.
+func runDNS(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	// Initialize Docker client
+	client, err := newDockerClient()
+	if err != nil {
+		return fmt.Errorf("failed to create Docker client: %w", err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	topo, err := fetchTopology(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	dns, err := client.BuildContainerDNS(topo.containers, args[0])
+	if err != nil {
+		return fmt.Errorf("failed to build DNS view: %w", err)
+	}
+
+	output.PrintContainerDNS(cmd.OutOrStdout(), dns)
+
+	return nil
+}
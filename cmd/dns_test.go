@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// TestDNSCommandExists verifies that the dns command is properly defined.
+func TestDNSCommandExists(t *testing.T) {
+	if dnsCmd == nil {
+		t.Fatal("dns command should not be nil")
+	}
+
+	if !strings.HasPrefix(dnsCmd.Use, "dns") {
+		t.Errorf("dns command Use should start with 'dns', got %q", dnsCmd.Use)
+	}
+
+	if err := dnsCmd.Args(dnsCmd, nil); err == nil {
+		t.Error("dns command should require a container argument")
+	}
+}
+
+// TestRunDNSWithComposeFile verifies the dns view built from a Compose file.
+func TestRunDNSWithComposeFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "docker-compose.yml")
+	content := `
+services:
+  api:
+    networks:
+      back:
+        aliases: [backend]
+  db:
+    networks: [back]
+networks:
+  back:
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("compose-file", []string{path})
+	viper.Set("project-name", "shop")
+
+	buf := new(bytes.Buffer)
+	dnsCmd.SetOut(buf)
+	defer dnsCmd.SetOut(nil)
+
+	if err := runDNS(dnsCmd, []string{"shop-api-1"}); err != nil {
+		t.Fatalf("runDNS returned error: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"Container: shop-api-1",
+		"Network: shop_back",
+		"db -> shop-db-1 [service]",
+		"backend -> shop-api-1 [alias]",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+
+	if err := runDNS(dnsCmd, []string{"missing"}); err == nil {
+		t.Error("expected error for unknown container")
+	}
+}
@@ -0,0 +1,122 @@
+// Package cmd provides the CLI commands for the docker-network-viz tool.
+// This file contains the export command which converts live topology to other formats.
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/compose"
+)
+
+var (
+	// exportOutput is the file to write exported content to; stdout when empty.
+	exportOutput string
+
+	// exportProject restricts the compose export to a single Compose project.
+	exportProject string
+
+	// exportExternalNetworks declares exported networks as external.
+	exportExternalNetworks bool
+
+	// exportCmd represents the export command.
+	exportCmd = &cobra.Command{
+		Use:   "export",
+		Short: "Export the live network topology to other formats",
+		Long: `Export the live Docker network topology to other formats so it can be
+captured as code.`,
+	}
+
+	// exportComposeCmd represents the export compose command.
+	exportComposeCmd = &cobra.Command{
+		Use:   "compose",
+		Short: "Generate a docker-compose networks section from live topology",
+		Long: `Generate a docker-compose.yml fragment from the live Docker topology.
+
+Each network becomes a top-level network entry with its driver, IPAM
+configuration and internal flag. Each container becomes a service listing
+its network attachments with aliases and static IPs. Aliases that Docker
+generates automatically (the container name and short container ID) are
+omitted. Without --project, services of several Compose projects are keyed
+by project and service, such as shop_db, so same-named services stay apart.
+
+Examples:
+  # Print the fragment for everything on the host
+  docker-network-viz export compose
+
+  # Capture a single Compose project into a file
+  docker-network-viz export compose --project shop -o docker-compose.networks.yml
+
+  # Reference the existing networks instead of recreating them
+  docker-network-viz export compose --external-networks`,
+		Args: cobra.NoArgs,
+		RunE: runExportCompose,
+	}
+)
+
+func init() {
+	// Add export commands to root
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.AddCommand(exportComposeCmd)
+
+	// Local flags for export compose command
+	exportComposeCmd.Flags().StringVarP(&exportOutput, "output", "o", "",
+		"write the compose file to this path instead of stdout")
+	exportComposeCmd.Flags().StringVar(&exportProject, "project", "",
+		"only export containers and networks of this Compose project")
+	exportComposeCmd.Flags().BoolVar(&exportExternalNetworks, "external-networks", false,
+		"declare networks as external instead of letting Compose create them")
+
+	// Bind flags to viper
+	_ = viper.BindPFlag("export.project", exportComposeCmd.Flags().Lookup("project"))
+	_ = viper.BindPFlag("export.external-networks", exportComposeCmd.Flags().Lookup("external-networks"))
+}
+
+// runExportCompose executes the export compose command logic.
+func runExportCompose(cmd *cobra.Command, _ []string) error {
+	ctx := context.Background()
+
+	// Initialize Docker client
+	client, err := newDockerClient()
+	if err != nil {
+		return fmt.Errorf("failed to create Docker client: %w", err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	topo, err := fetchTopology(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	file := compose.Export(topo.networks, topo.containers, compose.ExportOptions{
+		Project:          viper.GetString("export.project"),
+		ExternalNetworks: viper.GetBool("export.external-networks"),
+	})
+
+	if exportOutput == "" {
+		return file.Write(cmd.OutOrStdout())
+	}
+
+	return writeComposeFile(exportOutput, file)
+}
+
+// writeComposeFile writes a compose file to the given path.
+func writeComposeFile(path string, file *compose.File) (err error) {
+	f, err := os.Create(path) // #nosec G304 -- path is supplied by the user
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer func() {
+		if cerr := f.Close(); cerr != nil && err == nil {
+			err = fmt.Errorf("failed to close %s: %w", path, cerr)
+		}
+	}()
+
+	return file.Write(f)
+}
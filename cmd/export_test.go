@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/compose"
+)
+
+// TestExportCommandExists verifies that the export compose command is properly defined.
+func TestExportCommandExists(t *testing.T) {
+	if exportCmd.Use != "export" {
+		t.Errorf("export command Use should be 'export', got %q", exportCmd.Use)
+	}
+
+	var found bool
+	for _, sub := range exportCmd.Commands() {
+		if sub == exportComposeCmd {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Error("export command should have a compose subcommand")
+	}
+
+	for _, name := range []string{"output", "project", "external-networks"} {
+		if exportComposeCmd.Flags().Lookup(name) == nil {
+			t.Errorf("export compose command should have a %s flag", name)
+		}
+	}
+}
+
+// TestWriteComposeFile verifies that a compose file is written to disk.
+func TestWriteComposeFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "docker-compose.yml")
+	file := &compose.File{
+		Services: map[string]*compose.Service{"web": {Image: "nginx"}},
+		Networks: map[string]*compose.Network{"frontend": {Driver: "bridge"}},
+	}
+
+	if err := writeComposeFile(path, file); err != nil {
+		t.Fatalf("writeComposeFile should not return error: %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read written file: %v", err)
+	}
+
+	if !strings.Contains(string(content), "frontend:") {
+		t.Errorf("written file should contain the network, got:\n%s", content)
+	}
+}
+
+// TestWriteComposeFileInvalidPath verifies that write errors are reported.
+func TestWriteComposeFileInvalidPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "docker-compose.yml")
+
+	if err := writeComposeFile(path, &compose.File{}); err == nil {
+		t.Error("expected error for unwritable path")
+	}
+}
@@ -0,0 +1,248 @@
+// Package cmd provides the CLI commands for the docker-network-viz tool.
+// This file contains the exporter command which serves Prometheus metrics.
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/docker"
+	"git.o.ocom.com.au/go/docker-network-viz/internal/metrics"
+)
+
+const (
+	// defaultExporterListen is the default address the exporter listens on.
+	defaultExporterListen = ":9780"
+
+	// defaultExporterInterval is the default time between periodic refreshes.
+	defaultExporterInterval = 30 * time.Second
+
+	// eventDebounce is how long the exporter waits after a Docker event before
+	// refreshing, so that bursts of events trigger a single refresh.
+	eventDebounce = 2 * time.Second
+)
+
+var (
+	// exporterListen is the address the metrics server listens on.
+	exporterListen string
+
+	// exporterInterval is the time between periodic refreshes.
+	exporterInterval time.Duration
+
+	// exporterWatchEvents enables refreshing when Docker reports topology events.
+	exporterWatchEvents bool
+
+	// exporterCmd represents the exporter command.
+	exporterCmd = &cobra.Command{
+		Use:   "exporter",
+		Short: "Serve network topology metrics for Prometheus",
+		Long: `Serve Docker network topology metrics in the Prometheus text format.
+
+The exporter refreshes the topology periodically and, unless disabled, whenever
+Docker reports container or network events. Metrics are served on /metrics.
+
+Exported metrics include:
+  docker_network_containers{network,driver}         containers per network
+  docker_container_networks{container}              networks per container
+  docker_container_reachable_peers{container}       reachable peers per container
+  docker_containers_isolated                        containers with no networks
+  docker_containers_without_peers                   attached containers with no peers
+  docker_network_ip_pool_size{network,pool}         usable addresses per IPAM pool
+  docker_network_ip_pool_used{network,pool}         allocated addresses per IPAM pool
+  docker_network_ip_pool_utilization_ratio{network,pool}
+
+Examples:
+  # Serve metrics on the default address (:9780)
+  docker-network-viz exporter
+
+  # Listen on a specific address and refresh every minute
+  docker-network-viz exporter --listen 127.0.0.1:9100 --interval 1m
+
+  # Only refresh on the interval, ignoring Docker events
+  docker-network-viz exporter --watch-events=false`,
+		RunE: runExporter,
+	}
+)
+
+func init() {
+	// Add exporter command to root
+	rootCmd.AddCommand(exporterCmd)
+
+	// Local flags for exporter command
+	exporterCmd.Flags().StringVar(&exporterListen, "listen", defaultExporterListen,
+		"address to serve metrics on")
+	exporterCmd.Flags().DurationVar(&exporterInterval, "interval", defaultExporterInterval,
+		"time between periodic topology refreshes")
+	exporterCmd.Flags().BoolVar(&exporterWatchEvents, "watch-events", true,
+		"refresh when Docker reports container or network events")
+
+	// Bind flags to viper
+	_ = viper.BindPFlag("exporter.listen", exporterCmd.Flags().Lookup("listen"))
+	_ = viper.BindPFlag("exporter.interval", exporterCmd.Flags().Lookup("interval"))
+	_ = viper.BindPFlag("exporter.watch-events", exporterCmd.Flags().Lookup("watch-events"))
+}
+
+// runExporter executes the exporter command logic.
+// It serves metrics until interrupted, refreshing the topology on an interval
+// and on Docker events.
+func runExporter(cmd *cobra.Command, _ []string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	interval := viper.GetDuration("exporter.interval")
+	if interval <= 0 {
+		return fmt.Errorf("invalid refresh interval %s: must be positive", interval)
+	}
+
+	// Initialize Docker client
+	client, err := newDockerClient()
+	if err != nil {
+		return fmt.Errorf("failed to create Docker client: %w", err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	exporter := metrics.NewExporter()
+	refreshExporter(ctx, client, exporter, cmd.ErrOrStderr())
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", exporter)
+	server := &http.Server{
+		Addr:              viper.GetString("exporter.listen"),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- server.ListenAndServe()
+	}()
+	fmt.Fprintf(cmd.OutOrStdout(), "Serving metrics on %s/metrics\n", server.Addr)
+
+	// Compose files produce no Docker events, so only the interval applies.
+	watchEvents := viper.GetBool("exporter.watch-events") && !usingComposeFiles()
+
+	err = watchTopology(ctx, client, interval, watchEvents, serverErr, func() {
+		refreshExporter(ctx, client, exporter, cmd.ErrOrStderr())
+	}, cmd.ErrOrStderr())
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_ = server.Shutdown(shutdownCtx)
+
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("metrics server failed: %w", err)
+	}
+
+	return nil
+}
+
+// watchTopology calls refresh on every interval tick and, when watchEvents is
+// set, shortly after Docker reports topology events. It returns when the
+// context is cancelled or an error is received on stopErr.
+func watchTopology(
+	ctx context.Context,
+	client *docker.Client,
+	interval time.Duration,
+	watchEvents bool,
+	stopErr <-chan error,
+	refresh func(),
+	errOut io.Writer,
+) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	debounce := time.NewTimer(eventDebounce)
+	debounce.Stop()
+	defer debounce.Stop()
+
+	// Each subscription has its own context: when the stream fails, the SDK
+	// reports the error but never closes the message channel, so the
+	// forwarding goroutine and the stream are only released by cancelling it.
+	subscribe := func() (<-chan struct{}, <-chan error, context.CancelFunc) {
+		if !watchEvents {
+			return nil, nil, func() {}
+		}
+		subCtx, cancel := context.WithCancel(ctx)
+		messages, errs := client.WatchEvents(subCtx)
+		notify := make(chan struct{})
+		go func() {
+			for {
+				select {
+				case <-subCtx.Done():
+					return
+				case _, ok := <-messages:
+					if !ok {
+						return
+					}
+					select {
+					case notify <- struct{}{}:
+					case <-subCtx.Done():
+						return
+					}
+				}
+			}
+		}()
+		return notify, errs, cancel
+	}
+
+	events, eventErrs, cancelEvents := subscribe()
+	defer func() {
+		cancelEvents()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-stopErr:
+			return err
+		case <-ticker.C:
+			refresh()
+			// Re-subscribe if the event stream was lost.
+			if watchEvents && events == nil {
+				events, eventErrs, cancelEvents = subscribe()
+			}
+		case <-events:
+			debounce.Reset(eventDebounce)
+		case <-debounce.C:
+			refresh()
+		case err := <-eventErrs:
+			if ctx.Err() != nil {
+				return nil
+			}
+			fmt.Fprintf(errOut, "Warning: Docker event stream ended: %v\n", err)
+			cancelEvents()
+			events, eventErrs = nil, nil
+		}
+	}
+}
+
+// refreshExporter fetches the topology and updates the exporter's snapshot.
+// Failures are reported to errOut and counted, and the previous snapshot is kept.
+func refreshExporter(ctx context.Context, client *docker.Client, exporter *metrics.Exporter, errOut io.Writer) {
+	topo, err := fetchTopology(ctx, client)
+	if err != nil {
+		exporter.RecordError()
+		fmt.Fprintf(errOut, "Warning: failed to refresh topology: %v\n", err)
+		return
+	}
+
+	exporter.Update(collectMetrics(topo))
+}
+
+// collectMetrics builds a metrics snapshot from a fetched topology.
+func collectMetrics(topo *topology) *metrics.Snapshot {
+	return metrics.Collect(topo.networkInfos(), topo.containerMap, topo.networkToContainers, topo.addresses)
+}
@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+	"github.com/spf13/viper"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/docker"
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// TestExporterCommandExists verifies that the exporter command is properly defined.
+func TestExporterCommandExists(t *testing.T) {
+	if exporterCmd == nil {
+		t.Fatal("exporter command should not be nil")
+	}
+
+	if exporterCmd.Use != "exporter" {
+		t.Errorf("exporter command Use should be 'exporter', got %q", exporterCmd.Use)
+	}
+
+	var found bool
+	for _, sub := range GetRootCmd().Commands() {
+		if sub == exporterCmd {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Error("root command should have an exporter subcommand")
+	}
+}
+
+// TestExporterCommandHasFlags verifies the exporter flags and their defaults.
+func TestExporterCommandHasFlags(t *testing.T) {
+	tests := []struct {
+		name     string
+		defValue string
+	}{
+		{name: "listen", defValue: defaultExporterListen},
+		{name: "interval", defValue: defaultExporterInterval.String()},
+		{name: "watch-events", defValue: "true"},
+	}
+
+	for _, tt := range tests {
+		flag := exporterCmd.Flags().Lookup(tt.name)
+		if flag == nil {
+			t.Errorf("exporter command should have a %s flag", tt.name)
+			continue
+		}
+		if flag.DefValue != tt.defValue {
+			t.Errorf("%s default = %q, want %q", tt.name, flag.DefValue, tt.defValue)
+		}
+	}
+}
+
+// TestCollectMetrics verifies that a topology is converted to a metrics snapshot.
+func TestCollectMetrics(t *testing.T) {
+	web := models.ContainerInfo{Name: "web", Networks: []string{"small_net"}}
+	db := models.ContainerInfo{Name: "db", Networks: []string{"small_net"}}
+
+	topo := &topology{
+		networks: []network.Summary{
+			{
+				Name:   "small_net",
+				Driver: "bridge",
+				IPAM: network.IPAM{Config: []network.IPAMConfig{
+					{Subnet: "172.30.0.0/28", Gateway: "172.30.0.1"},
+				}},
+			},
+		},
+		containerMap: map[string]*models.ContainerInfo{"web": &web, "db": &db},
+		networkToContainers: map[string][]models.ContainerInfo{
+			"small_net": {db, web},
+		},
+		addresses: map[string][]string{"small_net": {"172.30.0.2", "172.30.0.3"}},
+	}
+
+	snapshot := collectMetrics(topo)
+
+	buf := new(bytes.Buffer)
+	snapshot.WriteText(buf)
+	out := buf.String()
+
+	expected := []string{
+		`docker_network_containers{network="small_net",driver="bridge"} 2`,
+		`docker_container_networks{container="web"} 1`,
+		`docker_network_ip_pool_used{network="small_net",pool="172.30.0.0/28"} 2`,
+		`docker_network_ip_pool_size{network="small_net",pool="172.30.0.0/28"} 13`,
+	}
+	for _, want := range expected {
+		if !strings.Contains(out, want) {
+			t.Errorf("metrics should contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+// TestRunExporterRejectsInvalidInterval verifies that a non-positive interval is rejected.
+func TestRunExporterRejectsInvalidInterval(t *testing.T) {
+	// Reset viper for this test
+	viper.Reset()
+	viper.Set("exporter.interval", time.Duration(0))
+
+	err := runExporter(exporterCmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "invalid refresh interval") {
+		t.Errorf("expected invalid interval error, got %v", err)
+	}
+}
+
+// failingEventsClient is a Docker API client whose event streams fail right
+// away the way the SDK does: the error is sent, but the message channel is
+// never closed.
+type failingEventsClient struct {
+	client.APIClient
+
+	mu   sync.Mutex
+	ctxs []context.Context
+}
+
+// Events implements the Events method of the Docker API client.
+func (f *failingEventsClient) Events(ctx context.Context, _ events.ListOptions) (<-chan events.Message, <-chan error) {
+	f.mu.Lock()
+	f.ctxs = append(f.ctxs, ctx)
+	f.mu.Unlock()
+
+	errs := make(chan error, 1)
+	errs <- errors.New("stream failed")
+	return make(chan events.Message), errs
+}
+
+// subscriptions returns the contexts of the event streams opened so far.
+func (f *failingEventsClient) subscriptions() []context.Context {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]context.Context(nil), f.ctxs...)
+}
+
+// TestWatchTopologyReleasesFailedEventStreams verifies that every failed
+// event stream is cancelled before re-subscribing, so neither the stream nor
+// its forwarding goroutine outlives it.
+func TestWatchTopologyReleasesFailedEventStreams(t *testing.T) {
+	fake := &failingEventsClient{}
+	c, err := docker.NewClient(docker.WithDockerClient(fake))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	before := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const failures = 5
+	stop := make(chan error)
+	done := make(chan error, 1)
+	go func() {
+		done <- watchTopology(ctx, c, time.Millisecond, true, stop, func() {}, io.Discard)
+	}()
+
+	deadline := time.After(5 * time.Second)
+	for len(fake.subscriptions()) <= failures {
+		select {
+		case <-deadline:
+			t.Fatalf("expected more than %d subscriptions, got %d", failures, len(fake.subscriptions()))
+		case <-time.After(time.Millisecond):
+		}
+	}
+
+	// Every stream but the newest has failed, and must have been cancelled
+	// while the watch is still running.
+	subs := fake.subscriptions()
+	for i, sub := range subs[:failures] {
+		if sub.Err() == nil {
+			t.Errorf("failed event stream %d was not cancelled", i+1)
+		}
+	}
+
+	stop <- nil
+	if err := <-done; err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for runtime.NumGoroutine() > before {
+		select {
+		case <-deadline:
+			t.Fatalf("goroutines leaked: %d before, %d after", before, runtime.NumGoroutine())
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
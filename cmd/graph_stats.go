@@ -0,0 +1,98 @@
+// Package cmd provides the CLI commands for the docker-network-viz tool.
+// This file contains the graph-stats command which analyzes the topology graph.
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/graph"
+	"git.o.ocom.com.au/go/docker-network-viz/internal/output"
+)
+
+var (
+	// graphStatsFormat is the output format for graph statistics.
+	graphStatsFormat string
+
+	// graphStatsCmd represents the graph-stats command.
+	graphStatsCmd = &cobra.Command{
+		Use:   "graph-stats",
+		Short: "Find network islands and the containers that bridge them",
+		Long: `Analyze the topology as a graph linking each container to the networks it
+is attached to, and report:
+
+  - Islands: groups of containers and networks connected to each other and
+    to nothing else
+  - Articulation points: multi-homed containers whose removal would split
+    their island. They are the links between segments, so they are both
+    segmentation risks and single points of failure
+  - Degree: the number of networks each container is attached to and the
+    number of containers on each network
+
+The graph is structural: networks that disable inter-container
+communication still link their containers, and the "none" network links
+nothing.
+
+Examples:
+  # Show graph statistics for the local host
+  docker-network-viz graph-stats
+
+  # Emit JSON for further processing
+  docker-network-viz graph-stats --format json
+
+  # Analyze a Compose project before deploying it
+  docker-network-viz graph-stats -f docker-compose.yml`,
+		Args: cobra.NoArgs,
+		RunE: runGraphStats,
+	}
+)
+
+func init() {
+	// Add graph-stats command to root
+	rootCmd.AddCommand(graphStatsCmd)
+
+	// Local flags for graph-stats command
+	graphStatsCmd.Flags().StringVar(&graphStatsFormat, "format", graph.FormatText,
+		"output format: text or json")
+
+	// Bind flags to viper
+	_ = viper.BindPFlag("graph-stats.format", graphStatsCmd.Flags().Lookup("format"))
+}
+
+// runGraphStats executes the graph-stats command logic.
+func runGraphStats(cmd *cobra.Command, _ []string) error {
+	ctx := context.Background()
+
+	format := viper.GetString("graph-stats.format")
+	if err := graph.ValidateFormat(format); err != nil {
+		return err
+	}
+
+	// Initialize Docker client
+	client, err := newDockerClient()
+	if err != nil {
+		return fmt.Errorf("failed to create Docker client: %w", err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	topo, err := fetchTopology(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	stats := graph.Analyze(topo.containerMap)
+
+	if format == graph.FormatJSON {
+		return graph.WriteJSON(cmd.OutOrStdout(), stats)
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), "=== Graph Statistics ===")
+	output.PrintGraphStats(cmd.OutOrStdout(), stats)
+
+	return nil
+}
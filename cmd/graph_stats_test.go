@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/graph"
+)
+
+// TestGraphStatsCommandExists verifies that the graph-stats command is properly defined.
+func TestGraphStatsCommandExists(t *testing.T) {
+	if graphStatsCmd == nil {
+		t.Fatal("graph-stats command should not be nil")
+	}
+
+	if graphStatsCmd.Use != "graph-stats" {
+		t.Errorf("graph-stats command Use should be 'graph-stats', got %q", graphStatsCmd.Use)
+	}
+
+	if graphStatsCmd.Flags().Lookup("format") == nil {
+		t.Error("graph-stats command should have a --format flag")
+	}
+}
+
+// setGraphStatsCompose points the configuration at a Compose file with two
+// islands, one held together by the api service.
+func setGraphStatsCompose(t *testing.T, format string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "docker-compose.yml")
+	content := `
+services:
+  web:
+    image: nginx
+    networks: [front]
+  api:
+    image: shop/api
+    networks: [front, back]
+  db:
+    image: postgres
+    networks: [back]
+  prometheus:
+    image: prom/prometheus
+    networks: [monitoring]
+networks:
+  front:
+  back:
+  monitoring:
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	viper.Reset()
+	viper.Set("compose-file", []string{path})
+	viper.Set("project-name", "shop")
+	viper.Set("graph-stats.format", format)
+}
+
+// TestRunGraphStatsText verifies the text report built from a Compose file.
+func TestRunGraphStatsText(t *testing.T) {
+	setGraphStatsCompose(t, graph.FormatText)
+	defer viper.Reset()
+
+	buf := new(bytes.Buffer)
+	graphStatsCmd.SetOut(buf)
+	defer graphStatsCmd.SetOut(nil)
+
+	if err := runGraphStats(graphStatsCmd, nil); err != nil {
+		t.Fatalf("runGraphStats returned error: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"=== Graph Statistics ===",
+		"Islands: 2",
+		"containers: shop-api-1, shop-db-1, shop-web-1",
+		"Articulation points: 1",
+		"shop-api-1: splits its island into 2 (networks: shop_back, shop_front)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+// TestRunGraphStatsJSON verifies the JSON report built from a Compose file.
+func TestRunGraphStatsJSON(t *testing.T) {
+	setGraphStatsCompose(t, graph.FormatJSON)
+	defer viper.Reset()
+
+	buf := new(bytes.Buffer)
+	graphStatsCmd.SetOut(buf)
+	defer graphStatsCmd.SetOut(nil)
+
+	if err := runGraphStats(graphStatsCmd, nil); err != nil {
+		t.Fatalf("runGraphStats returned error: %v", err)
+	}
+
+	var stats graph.Stats
+	if err := json.Unmarshal(buf.Bytes(), &stats); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if len(stats.Components) != 2 || len(stats.ArticulationPoints) != 1 {
+		t.Errorf("unexpected statistics: %+v", stats)
+	}
+}
+
+// TestRunGraphStatsInvalidFormat verifies that unknown formats are rejected.
+func TestRunGraphStatsInvalidFormat(t *testing.T) {
+	setGraphStatsCompose(t, "xml")
+	defer viper.Reset()
+
+	if err := runGraphStats(graphStatsCmd, nil); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
@@ -0,0 +1,77 @@
+// Package cmd provides the CLI commands for the docker-network-viz tool.
+// This file contains the ingress command which shows the inbound traffic chain.
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/ingress"
+	"git.o.ocom.com.au/go/docker-network-viz/internal/output"
+)
+
+// ingressCmd represents the ingress command.
+var ingressCmd = &cobra.Command{
+	Use:   "ingress",
+	Short: "Show the inbound chain from host ports through reverse proxies to backends",
+	Long: `Show how inbound traffic reaches containers: the host ports each reverse
+proxy publishes, the routes it serves, and the backend container and network
+each route reaches.
+
+Routes are read from the backends' configuration:
+  - Traefik: traefik.http.routers.* and traefik.tcp.routers.* labels
+  - nginx-proxy: VIRTUAL_HOST and VIRTUAL_PORT environment variables
+  - caddy-docker-proxy: caddy and caddy_N labels with {{upstreams PORT}}
+
+Proxies are recognized by their image. Routes whose backend does not share a
+network with the proxy, is stopped, or is not on the network the route names
+are flagged with ERROR; these are a common cause of 502 responses. Containers
+publishing ports without a proxy are listed separately.
+
+Examples:
+  # Show the inbound chain of the local host
+  docker-network-viz ingress
+
+  # Review the routing of a Compose project before deploying it
+  docker-network-viz ingress -f docker-compose.yml`,
+	Args: cobra.NoArgs,
+	RunE: runIngress,
+}
+
+func init() {
+	// Add ingress command to root
+	rootCmd.AddCommand(ingressCmd)
+}
+
+// runIngress executes the ingress command logic.
+func runIngress(cmd *cobra.Command, _ []string) error {
+	ctx := context.Background()
+
+	// Initialize Docker client
+	client, err := newDockerClient()
+	if err != nil {
+		return fmt.Errorf("failed to create Docker client: %w", err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	topo, err := fetchTopology(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	// nginx-proxy routes are declared in the environment.
+	if err := topo.loadEnv(ctx, client); err != nil {
+		return err
+	}
+
+	report := ingress.Build(topo.containerMap, output.NetworksByName(topo.networkInfos()))
+
+	fmt.Fprintln(cmd.OutOrStdout(), "=== Ingress ===")
+	output.PrintIngress(cmd.OutOrStdout(), report)
+
+	return nil
+}
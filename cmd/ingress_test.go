@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// TestIngressCommandExists verifies that the ingress command is properly defined.
+func TestIngressCommandExists(t *testing.T) {
+	if ingressCmd == nil {
+		t.Fatal("ingress command should not be nil")
+	}
+
+	if ingressCmd.Use != "ingress" {
+		t.Errorf("ingress command Use should be 'ingress', got %q", ingressCmd.Use)
+	}
+
+	if err := ingressCmd.Args(ingressCmd, []string{"extra"}); err == nil {
+		t.Error("ingress command should not accept arguments")
+	}
+}
+
+// TestRunIngressWithComposeFile verifies the inbound chain built from a Compose file.
+func TestRunIngressWithComposeFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "docker-compose.yml")
+	content := `
+services:
+  proxy:
+    image: traefik:v3.0
+    ports: ["80:80", "443:443"]
+    networks: [front]
+  shop:
+    image: shop/web
+    labels:
+      traefik.http.routers.shop.rule: Host(` + "`shop.example.com`" + `)
+      traefik.http.services.shop.loadbalancer.server.port: "8080"
+    networks: [front]
+  api:
+    image: shop/api
+    labels:
+      - traefik.http.routers.api.rule=Host(` + "`api.example.com`" + `)
+    networks: [back]
+  db:
+    image: postgres
+    ports: ["127.0.0.1:5432:5432"]
+    networks: [back]
+networks:
+  front:
+  back:
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("compose-file", []string{path})
+	viper.Set("project-name", "shop")
+
+	buf := new(bytes.Buffer)
+	ingressCmd.SetOut(buf)
+	defer ingressCmd.SetOut(nil)
+
+	if err := runIngress(ingressCmd, nil); err != nil {
+		t.Fatalf("runIngress returned error: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"=== Ingress ===",
+		"Proxy: shop-proxy-1 (traefik)",
+		"listens on: 0.0.0.0:80->80/tcp, 0.0.0.0:443->443/tcp",
+		"Host(`shop.example.com`) -> shop-shop-1:8080 (via shop_front)",
+		"Host(`api.example.com`) -> shop-api-1 ERROR: no network shared with shop-proxy-1",
+		"shop-db-1: 127.0.0.1:5432->5432/tcp",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,271 @@
+// Package cmd provides the CLI commands for the docker-network-viz tool.
+// This file contains the lint command which checks the topology for misconfigurations.
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/lint"
+)
+
+var (
+	// lintFormat is the output format for lint findings.
+	lintFormat string
+
+	// lintFailOn is the minimum severity that makes the command fail.
+	lintFailOn string
+
+	// lintDisable lists rule IDs to skip.
+	lintDisable []string
+
+	// lintListRules prints the available rules instead of running them.
+	lintListRules bool
+
+	// lintCmd represents the lint command.
+	lintCmd = &cobra.Command{
+		Use:   "lint",
+		Short: "Check the network topology for misconfigurations",
+		Long: `Run a set of rules over the network topology and report misconfigurations,
+such as alias collisions, containers on the default bridge network, unused
+networks, containers bridging internal and non-internal networks, publicly
+published database ports, containers without networks and host ports
+published by several containers. Stopped containers are inspected for the
+host ports they will claim when they start.
+
+Each rule has an ID and a severity. Rules can be disabled with --disable, or
+disabled and re-rated in the configuration file:
+
+  lint:
+    disable: [network-unused]
+    rules:
+      default-bridge:
+        severity: error
+      host-network:
+        enabled: false
+
+Findings can be written as text, JSON, SARIF 2.1.0 for code-scanning tools,
+or JUnit XML for CI test reports. The command exits with an error when any
+finding is at least as severe as --fail-on.
+
+Examples:
+  # Run all rules
+  docker-network-viz lint
+
+  # List the available rules
+  docker-network-viz lint --list-rules
+
+  # JSON for further processing, failing on warnings too
+  docker-network-viz lint --format json --fail-on warning
+
+  # SARIF and JUnit reports for CI; code scanning needs the Compose file
+  docker-network-viz lint -f docker-compose.yml --format sarif > lint.sarif
+  docker-network-viz lint --format junit > lint-junit.xml
+
+  # Skip some rules
+  docker-network-viz lint --disable default-bridge,network-unused`,
+		Args: cobra.NoArgs,
+		RunE: runLint,
+	}
+)
+
+// lintRuleSettings is the configuration file form of lint.RuleConfig.
+type lintRuleSettings struct {
+	Enabled  *bool  `mapstructure:"enabled"`
+	Severity string `mapstructure:"severity"`
+}
+
+func init() {
+	// Add lint command to root
+	rootCmd.AddCommand(lintCmd)
+
+	// Local flags for lint command
+	lintCmd.Flags().StringVar(&lintFormat, "format", lint.FormatText,
+		"output format: text, json, sarif or junit")
+	lintCmd.Flags().StringVar(&lintFailOn, "fail-on", string(lint.SeverityError),
+		"exit with an error when a finding is at least this severe: error, warning or info")
+	lintCmd.Flags().StringSliceVar(&lintDisable, "disable", nil,
+		"rule IDs to skip (repeatable)")
+	lintCmd.Flags().BoolVar(&lintListRules, "list-rules", false,
+		"list the available rules and exit")
+
+	// Bind flags to viper
+	_ = viper.BindPFlag("lint.format", lintCmd.Flags().Lookup("format"))
+	_ = viper.BindPFlag("lint.fail-on", lintCmd.Flags().Lookup("fail-on"))
+	_ = viper.BindPFlag("lint.disable", lintCmd.Flags().Lookup("disable"))
+}
+
+// runLint executes the lint command logic.
+func runLint(cmd *cobra.Command, _ []string) error {
+	ctx := context.Background()
+	registry := lint.DefaultRegistry()
+
+	cfg, err := lintConfig()
+	if err != nil {
+		return err
+	}
+
+	if lintListRules {
+		printLintRules(cmd.OutOrStdout(), registry, cfg)
+		return nil
+	}
+
+	failOn, err := lint.ParseSeverity(viper.GetString("lint.fail-on"))
+	if err != nil {
+		return err
+	}
+
+	format := viper.GetString("lint.format")
+	if err := lint.ValidateFormat(format); err != nil {
+		return err
+	}
+
+	rules, err := registry.Enabled(cfg)
+	if err != nil {
+		return err
+	}
+
+	// Initialize Docker client
+	client, err := newDockerClient()
+	if err != nil {
+		return fmt.Errorf("failed to create Docker client: %w", err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	topo, err := fetchTopology(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	// Stopped containers claim their host ports only when they start.
+	if err := topo.loadPortBindings(ctx, client); err != nil {
+		return err
+	}
+
+	findings := lint.RunRules(rules, topo.lintTopology())
+
+	opts, err := lintReportOptions()
+	if err != nil {
+		return err
+	}
+	opts.FailOn = failOn
+
+	if err := lint.Write(cmd.OutOrStdout(), format, rules, findings, opts); err != nil {
+		return err
+	}
+
+	if n := lint.CountAtLeast(findings, failOn); n > 0 {
+		return fmt.Errorf("%d finding(s) at or above %s severity", n, failOn)
+	}
+
+	return nil
+}
+
+// lintReportOptions returns where the linted topology came from: the first
+// Compose file, relative to its source root, or nothing for a live daemon.
+func lintReportOptions() (lint.ReportOptions, error) {
+	files := viper.GetStringSlice("compose-file")
+	if len(files) == 0 {
+		return lint.ReportOptions{}, nil
+	}
+
+	path, err := filepath.Abs(files[0])
+	if err != nil {
+		return lint.ReportOptions{}, fmt.Errorf("failed to resolve Compose file path: %w", err)
+	}
+
+	root, err := sourceRoot(path)
+	if err != nil {
+		return lint.ReportOptions{}, err
+	}
+
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return lint.ReportOptions{}, fmt.Errorf("failed to resolve Compose file path: %w", err)
+	}
+
+	return lint.ReportOptions{Source: filepath.ToSlash(rel), SourceRoot: root}, nil
+}
+
+// sourceRoot returns the directory that code scanning tools resolve the
+// path of a file against: the root of the Git repository the file is in,
+// else the working directory if the file is below it, else the file's
+// directory.
+func sourceRoot(path string) (string, error) {
+	for dir := filepath.Dir(path); ; {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to get working directory: %w", err)
+	}
+	if rel, err := filepath.Rel(wd, path); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return wd, nil
+	}
+
+	return filepath.Dir(path), nil
+}
+
+// lintConfig builds the rule configuration from the lint.disable and
+// lint.rules configuration keys.
+func lintConfig() (lint.Config, error) {
+	settings := map[string]lintRuleSettings{}
+	if err := viper.UnmarshalKey("lint.rules", &settings); err != nil {
+		return lint.Config{}, fmt.Errorf("failed to read lint rule configuration: %w", err)
+	}
+
+	cfg := lint.Config{
+		Rules:   make(map[string]lint.RuleConfig, len(settings)),
+		Disable: viper.GetStringSlice("lint.disable"),
+	}
+	for id, s := range settings {
+		cfg.Rules[id] = lint.RuleConfig{
+			Enabled:  s.Enabled,
+			Severity: lint.Severity(s.Severity),
+		}
+	}
+
+	return cfg, nil
+}
+
+// printLintRules prints the registered rules with their effective severity
+// and whether they are enabled.
+func printLintRules(w io.Writer, registry *lint.Registry, cfg lint.Config) {
+	disabled := make(map[string]bool, len(cfg.Disable))
+	for _, id := range cfg.Disable {
+		disabled[id] = true
+	}
+
+	for _, rule := range registry.Rules() {
+		override := cfg.Rules[rule.ID]
+
+		severity := rule.Severity
+		if override.Severity != "" {
+			severity = override.Severity
+		}
+
+		state := "enabled"
+		if disabled[rule.ID] || (override.Enabled != nil && !*override.Enabled) {
+			state = "disabled"
+		}
+
+		fmt.Fprintf(w, "%-24s %-8s %-9s %s\n", rule.ID, severity, state, rule.Description)
+	}
+}
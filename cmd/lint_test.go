@@ -0,0 +1,342 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/lint"
+)
+
+// TestLintCommandExists verifies that the lint command is properly defined.
+func TestLintCommandExists(t *testing.T) {
+	if lintCmd == nil {
+		t.Fatal("lint command should not be nil")
+	}
+
+	if lintCmd.Use != "lint" {
+		t.Errorf("lint command Use should be 'lint', got %q", lintCmd.Use)
+	}
+
+	for _, name := range []string{"format", "fail-on", "disable", "list-rules"} {
+		if lintCmd.Flags().Lookup(name) == nil {
+			t.Errorf("lint command should have a %s flag", name)
+		}
+	}
+}
+
+// TestRunLintWithComposeFile verifies lint findings and the exit status.
+func TestRunLintWithComposeFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "docker-compose.yml")
+	content := `
+services:
+  postgres:
+    networks:
+      back:
+        aliases: [db]
+  mysql:
+    networks:
+      back:
+        aliases: [db]
+networks:
+  back:
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("compose-file", []string{path})
+	viper.Set("project-name", "shop")
+	viper.Set("lint.format", "text")
+	viper.Set("lint.fail-on", "error")
+
+	buf := new(bytes.Buffer)
+	lintCmd.SetOut(buf)
+	defer lintCmd.SetOut(nil)
+
+	err := runLint(lintCmd, nil)
+	if err == nil {
+		t.Error("expected error for error findings")
+	}
+
+	if !strings.Contains(buf.String(), `alias-duplicate: alias "db" on network shop_back`) {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+}
+
+// TestRunLintInvalidOptions verifies flag validation.
+func TestRunLintInvalidOptions(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	viper.Set("lint.format", "text")
+	viper.Set("lint.fail-on", "fatal")
+	if err := runLint(lintCmd, nil); err == nil {
+		t.Error("expected error for invalid severity")
+	}
+
+	viper.Set("lint.format", "xml")
+	viper.Set("lint.fail-on", "error")
+	if err := runLint(lintCmd, nil); err == nil {
+		t.Error("expected error for invalid format")
+	}
+}
+
+// TestRunLintDisabledRules verifies that disabled rules do not fail the command.
+func TestRunLintDisabledRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "docker-compose.yml")
+	content := `
+services:
+  postgres:
+    networks:
+      back:
+        aliases: [db]
+  mysql:
+    networks:
+      back:
+        aliases: [db]
+networks:
+  back:
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("compose-file", []string{path})
+	viper.Set("project-name", "shop")
+	viper.Set("lint.format", "text")
+	viper.Set("lint.fail-on", "error")
+	viper.Set("lint.disable", []string{lint.RuleAliasDuplicate})
+
+	buf := new(bytes.Buffer)
+	lintCmd.SetOut(buf)
+	defer lintCmd.SetOut(nil)
+
+	if err := runLint(lintCmd, nil); err != nil {
+		t.Errorf("unexpected error: %v\n%s", err, buf.String())
+	}
+
+	if strings.Contains(buf.String(), lint.RuleAliasDuplicate) {
+		t.Errorf("disabled rule should not report findings:\n%s", buf.String())
+	}
+}
+
+// TestLintConfig verifies reading rule overrides from the configuration.
+func TestLintConfig(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	viper.Set("lint.disable", []string{"network-unused"})
+	viper.Set("lint.rules", map[string]any{
+		"default-bridge": map[string]any{"severity": "error"},
+		"host-network":   map[string]any{"enabled": false},
+	})
+
+	cfg, err := lintConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(cfg.Disable) != 1 || cfg.Disable[0] != "network-unused" {
+		t.Errorf("unexpected disabled rules: %v", cfg.Disable)
+	}
+	if cfg.Rules["default-bridge"].Severity != lint.SeverityError {
+		t.Errorf("expected severity override, got %+v", cfg.Rules["default-bridge"])
+	}
+	if enabled := cfg.Rules["host-network"].Enabled; enabled == nil || *enabled {
+		t.Errorf("expected host-network to be disabled, got %+v", cfg.Rules["host-network"])
+	}
+}
+
+// TestPrintLintRules verifies the rule listing.
+func TestPrintLintRules(t *testing.T) {
+	disabled := false
+	cfg := lint.Config{
+		Rules: map[string]lint.RuleConfig{
+			"default-bridge": {Severity: lint.SeverityError},
+			"host-network":   {Enabled: &disabled},
+		},
+		Disable: []string{"network-unused"},
+	}
+
+	buf := new(bytes.Buffer)
+	printLintRules(buf, lint.DefaultRegistry(), cfg)
+
+	lines := map[string]string{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		lines[strings.Fields(line)[0]] = line
+	}
+
+	if len(lines) != len(lint.DefaultRegistry().Rules()) {
+		t.Errorf("expected one line per rule, got:\n%s", buf.String())
+	}
+	if f := strings.Fields(lines["default-bridge"]); f[1] != "error" || f[2] != "enabled" {
+		t.Errorf("unexpected default-bridge line: %q", lines["default-bridge"])
+	}
+	if f := strings.Fields(lines["host-network"]); f[2] != "disabled" {
+		t.Errorf("unexpected host-network line: %q", lines["host-network"])
+	}
+	if f := strings.Fields(lines["network-unused"]); f[2] != "disabled" {
+		t.Errorf("unexpected network-unused line: %q", lines["network-unused"])
+	}
+}
+
+// TestRunLintJUnit verifies the JUnit report of the lint command.
+func TestRunLintJUnit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "docker-compose.yml")
+	content := `
+services:
+  web:
+    networks: [front]
+networks:
+  front:
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("compose-file", []string{path})
+	viper.Set("project-name", "shop")
+	viper.Set("lint.format", lint.FormatJUnit)
+	viper.Set("lint.fail-on", "error")
+
+	buf := new(bytes.Buffer)
+	lintCmd.SetOut(buf)
+	defer lintCmd.SetOut(nil)
+
+	if err := runLint(lintCmd, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := buf.String()
+	want := fmt.Sprintf(`<testsuite name="%s" tests="1" failures="0">`, lint.RuleAliasDuplicate)
+	if !strings.Contains(out, want) {
+		t.Errorf("expected a passing suite per rule, got:\n%s", out)
+	}
+}
+
+// TestRunLintJUnitFailOn verifies that JUnit reports fail only the findings
+// at or above --fail-on, matching the command's exit status.
+func TestRunLintJUnitFailOn(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "docker-compose.yml")
+	content := `
+services:
+  web:
+    network_mode: host
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("compose-file", []string{path})
+	viper.Set("project-name", "shop")
+	viper.Set("lint.format", lint.FormatJUnit)
+
+	for _, tt := range []struct {
+		failOn  string
+		wantErr bool
+		want    string
+	}{
+		{"warning", false, `<testsuite name="host-network" tests="1" failures="0">`},
+		{"info", true, `<testsuite name="host-network" tests="1" failures="1">`},
+	} {
+		viper.Set("lint.fail-on", tt.failOn)
+
+		buf := new(bytes.Buffer)
+		lintCmd.SetOut(buf)
+
+		err := runLint(lintCmd, nil)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("--fail-on %s: unexpected error: %v", tt.failOn, err)
+		}
+		if !strings.Contains(buf.String(), tt.want) {
+			t.Errorf("--fail-on %s: expected %s, got:\n%s", tt.failOn, tt.want, buf.String())
+		}
+		if !tt.wantErr && !strings.Contains(buf.String(), "<system-out>info host-network: ") {
+			t.Errorf("--fail-on %s: expected the finding as system output, got:\n%s", tt.failOn, buf.String())
+		}
+	}
+	lintCmd.SetOut(nil)
+}
+
+// TestRunLintSARIF verifies that SARIF results are placed in the Compose
+// file, relative to the root of its repository.
+func TestRunLintSARIF(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".git"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, "deploy"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(root, "deploy", "docker-compose.yml")
+	content := `
+services:
+  web:
+    network_mode: host
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("compose-file", []string{path})
+	viper.Set("project-name", "shop")
+	viper.Set("lint.format", lint.FormatSARIF)
+	viper.Set("lint.fail-on", "error")
+
+	buf := new(bytes.Buffer)
+	lintCmd.SetOut(buf)
+	defer lintCmd.SetOut(nil)
+
+	if err := runLint(lintCmd, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, want := range []string{
+		`"uri": "deploy/docker-compose.yml"`,
+		`"uriBaseId": "%SRCROOT%"`,
+		fmt.Sprintf(`"uri": "file://%s/"`, filepath.ToSlash(root)),
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("expected %s, got:\n%s", want, buf.String())
+		}
+	}
+}
+
+// TestSourceRoot verifies the directory Compose file paths are relative to.
+func TestSourceRoot(t *testing.T) {
+	repo := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := sourceRoot(filepath.Join(repo, "deploy", "docker-compose.yml")); err != nil || got != repo {
+		t.Errorf("expected the repository root %s, got %q (%v)", repo, got, err)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	outside := filepath.Join(t.TempDir(), "docker-compose.yml")
+	if rel, err := filepath.Rel(wd, outside); err == nil && !strings.HasPrefix(rel, "..") {
+		t.Skip("temporary directory is below the working directory")
+	}
+	if got, err := sourceRoot(outside); err != nil || got != filepath.Dir(outside) {
+		t.Errorf("expected the file's directory %s, got %q (%v)", filepath.Dir(outside), got, err)
+	}
+}
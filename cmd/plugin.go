@@ -0,0 +1,158 @@
+// Package cmd provides the CLI commands for the docker-network-viz tool.
+// This file implements the Docker CLI plugin protocol.
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	// pluginName is the name of the plugin as a docker subcommand.
+	pluginName = "network-viz"
+
+	// pluginMetadataCommand is the subcommand the Docker CLI runs to discover a plugin.
+	pluginMetadataCommand = "docker-cli-plugin-metadata"
+
+	// pluginSchemaVersion is the version of the plugin metadata schema.
+	pluginSchemaVersion = "0.1.0"
+
+	// pluginVendor is the vendor shown by "docker info" and "docker --help".
+	pluginVendor = "Ocom"
+
+	// pluginURL is the project home page.
+	pluginURL = "https://git.o.ocom.com.au/go/docker-network-viz"
+)
+
+// Version is the version of the binary, set at build time with
+// -ldflags "-X git.o.ocom.com.au/go/docker-network-viz/cmd.Version=...".
+var Version = "dev"
+
+// pluginMetadata is the document printed by the metadata subcommand.
+type pluginMetadata struct {
+	SchemaVersion    string `json:"SchemaVersion"`
+	Vendor           string `json:"Vendor"`
+	Version          string `json:"Version"`
+	ShortDescription string `json:"ShortDescription"`
+	URL              string `json:"URL"`
+}
+
+// pluginMetadataCmd prints the plugin metadata the Docker CLI uses to
+// recognize the binary as a plugin.
+var pluginMetadataCmd = &cobra.Command{
+	Use:    pluginMetadataCommand,
+	Short:  "Print the Docker CLI plugin metadata",
+	Hidden: true,
+	Args:   cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(pluginMetadata{
+			SchemaVersion:    pluginSchemaVersion,
+			Vendor:           pluginVendor,
+			Version:          Version,
+			ShortDescription: AppDescription,
+			URL:              pluginURL,
+		}); err != nil {
+			return fmt.Errorf("failed to write plugin metadata: %w", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(pluginMetadataCmd)
+}
+
+// dockerValueFlags maps the Docker CLI global flags that take a value to the
+// flag they are passed on as; an empty target drops the flag.
+var dockerValueFlags = map[string]string{
+	"-c":          "--context",
+	"--context":   "--context",
+	"-H":          "--host",
+	"--host":      "--host",
+	"--tlscacert": "--tlscacert",
+	"--tlscert":   "--tlscert",
+	"--tlskey":    "--tlskey",
+	"--config":    "",
+	"-l":          "",
+	"--log-level": "",
+}
+
+// dockerBoolFlags maps the Docker CLI global flags without a value to the
+// flag they are passed on as; an empty target drops the flag.
+var dockerBoolFlags = map[string]string{
+	"--tlsverify": "--tlsverify",
+	"--tls":       "",
+	"-D":          "",
+	"--debug":     "",
+}
+
+// pluginInvocation is a command line received from the Docker CLI.
+type pluginInvocation struct {
+	// args are the arguments for the root command.
+	args []string
+
+	// configDir is the Docker CLI configuration directory given with the
+	// global --config flag, if any.
+	configDir string
+}
+
+// parsePluginArgs translates the arguments the Docker CLI runs a plugin with,
+// "[global flags] network-viz [args]", into arguments for the root command.
+// The global --context, --host and TLS flags are passed on as the matching
+// flags of this tool; the other global flags are dropped. Only the first
+// argument after the global flags is taken as the plugin name, so the name
+// may still be used as an argument of the command. Arguments that start with
+// neither global flags nor the plugin name are returned unchanged.
+func parsePluginArgs(args []string) (pluginInvocation, error) {
+	var inv pluginInvocation
+	for i := 0; i < len(args); i++ {
+		if !strings.HasPrefix(args[i], "-") {
+			if args[i] != pluginName {
+				if i > 0 {
+					return inv, fmt.Errorf("expected %s after the Docker CLI flags, got %s", pluginName, args[i])
+				}
+				return pluginInvocation{args: args}, nil
+			}
+			inv.args = append(inv.args, args[i+1:]...)
+			return inv, nil
+		}
+
+		name, value, hasValue := strings.Cut(args[i], "=")
+
+		if target, ok := dockerBoolFlags[name]; ok {
+			if target != "" {
+				inv.args = append(inv.args, args[i])
+			}
+			continue
+		}
+
+		target, ok := dockerValueFlags[name]
+		if !ok {
+			return inv, fmt.Errorf("unsupported Docker CLI flag %s", name)
+		}
+		if !hasValue {
+			if i+1 >= len(args) {
+				return inv, fmt.Errorf("flag %s needs an argument", name)
+			}
+			i++
+			value = args[i]
+		}
+
+		switch {
+		case name == "--config":
+			inv.configDir = value
+		case target != "":
+			inv.args = append(inv.args, target, value)
+		}
+	}
+
+	if len(args) > 0 {
+		return inv, fmt.Errorf("expected %s after the Docker CLI flags", pluginName)
+	}
+	return inv, nil
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// TestPluginMetadata verifies the metadata printed for the Docker CLI.
+func TestPluginMetadata(t *testing.T) {
+	var buf bytes.Buffer
+	pluginMetadataCmd.SetOut(&buf)
+	defer pluginMetadataCmd.SetOut(nil)
+
+	if err := pluginMetadataCmd.RunE(pluginMetadataCmd, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var meta pluginMetadata
+	if err := json.Unmarshal(buf.Bytes(), &meta); err != nil {
+		t.Fatalf("metadata is not valid JSON: %v\n%s", err, buf.String())
+	}
+	if meta.SchemaVersion != "0.1.0" || meta.Vendor == "" || meta.ShortDescription != AppDescription {
+		t.Errorf("unexpected metadata: %+v", meta)
+	}
+}
+
+// TestPluginMetadataCommandRegistered verifies the metadata subcommand is
+// registered and hidden from help.
+func TestPluginMetadataCommandRegistered(t *testing.T) {
+	cmd, _, err := GetRootCmd().Find([]string{pluginMetadataCommand})
+	if err != nil || cmd != pluginMetadataCmd {
+		t.Fatalf("expected %s to be registered, got %v", pluginMetadataCommand, err)
+	}
+	if !cmd.Hidden {
+		t.Error("metadata subcommand should be hidden")
+	}
+}
+
+// TestParsePluginArgs verifies the translation of Docker CLI plugin invocations.
+func TestParsePluginArgs(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		want      []string
+		configDir string
+	}{
+		{
+			name: "standalone",
+			args: []string{"lint", "--format", "json"},
+			want: []string{"lint", "--format", "json"},
+		},
+		{
+			name: "plugin without global flags",
+			args: []string{"network-viz", "--container", "api"},
+			want: []string{"--container", "api"},
+		},
+		{
+			name: "context and host",
+			args: []string{"--context", "remote", "-H=ssh://build-1", "network-viz", "lint"},
+			want: []string{"--context", "remote", "--host", "ssh://build-1", "lint"},
+		},
+		{
+			name: "tls",
+			args: []string{"--tlsverify", "--tlscacert", "/ca.pem", "--tls", "network-viz"},
+			want: []string{"--tlsverify", "--tlscacert", "/ca.pem"},
+		},
+		{
+			name:      "dropped flags",
+			args:      []string{"-D", "-l", "debug", "--config", "/etc/docker-cli", "network-viz", "dns", "api"},
+			want:      []string{"dns", "api"},
+			configDir: "/etc/docker-cli",
+		},
+		{
+			name: "plugin name as argument",
+			args: []string{"network-viz", "dns", "network-viz"},
+			want: []string{"dns", "network-viz"},
+		},
+		{
+			name: "plugin name as argument of a standalone command",
+			args: []string{"dns", "network-viz"},
+			want: []string{"dns", "network-viz"},
+		},
+		{
+			name: "plugin name as flag value",
+			args: []string{"--context", "network-viz", "network-viz", "lint"},
+			want: []string{"--context", "network-viz", "lint"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inv, err := parsePluginArgs(tt.args)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(inv.args, tt.want) {
+				t.Errorf("args = %q, want %q", inv.args, tt.want)
+			}
+			if inv.configDir != tt.configDir {
+				t.Errorf("configDir = %q, want %q", inv.configDir, tt.configDir)
+			}
+		})
+	}
+}
+
+// TestParsePluginArgs_Invalid verifies errors for unsupported global flags.
+func TestParsePluginArgs_Invalid(t *testing.T) {
+	for _, args := range [][]string{
+		{"--unknown", "network-viz"},
+		{"--context", "network-viz"},
+		{"--context", "remote", "lint", "network-viz"},
+	} {
+		if _, err := parsePluginArgs(args); err == nil {
+			t.Errorf("parsePluginArgs(%q): expected error", args)
+		}
+	}
+}
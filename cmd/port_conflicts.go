@@ -0,0 +1,80 @@
+// Package cmd provides the CLI commands for the docker-network-viz tool.
+// This file contains the port-conflicts command which finds host port collisions.
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+	"git.o.ocom.com.au/go/docker-network-viz/internal/output"
+)
+
+// portConflictsCmd represents the port-conflicts command.
+var portConflictsCmd = &cobra.Command{
+	Use:   "port-conflicts",
+	Short: "Find host ports published by several containers",
+	Long: `Find host ports that several containers publish with the same protocol on
+overlapping host addresses. Addresses overlap when they are equal or one is
+the wildcard address of the other's family, so 0.0.0.0:80 conflicts with
+127.0.0.1:80 but not with [::1]:80.
+
+The container list reports published ports only while a container runs, so
+stopped containers are inspected for the port bindings they will claim when
+they start. A stopped container whose port is held by a running container is
+flagged as failing to start, which is a common surprise after a reboot when
+another service grabbed the port first.
+
+The same check runs as the host-port-conflict lint rule. The command fails
+when any conflict is found.
+
+Examples:
+  # Find host port conflicts on the local host
+  docker-network-viz port-conflicts
+
+  # Check a Compose project before deploying it
+  docker-network-viz port-conflicts -f docker-compose.yml`,
+	Args: cobra.NoArgs,
+	RunE: runPortConflicts,
+}
+
+func init() {
+	// Add port-conflicts command to root
+	rootCmd.AddCommand(portConflictsCmd)
+}
+
+// runPortConflicts executes the port-conflicts command logic.
+func runPortConflicts(cmd *cobra.Command, _ []string) error {
+	ctx := context.Background()
+
+	// Initialize Docker client
+	client, err := newDockerClient()
+	if err != nil {
+		return fmt.Errorf("failed to create Docker client: %w", err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	topo, err := fetchTopology(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	if err := topo.loadPortBindings(ctx, client); err != nil {
+		return err
+	}
+
+	conflicts := models.FindPortConflicts(topo.containerMap)
+
+	fmt.Fprintln(cmd.OutOrStdout(), "=== Host Port Conflicts ===")
+	output.PrintPortConflicts(cmd.OutOrStdout(), conflicts)
+
+	if len(conflicts) > 0 {
+		return fmt.Errorf("%d host port conflict(s)", len(conflicts))
+	}
+
+	return nil
+}
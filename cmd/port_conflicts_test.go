@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// TestPortConflictsCommandExists verifies that the port-conflicts command is properly defined.
+func TestPortConflictsCommandExists(t *testing.T) {
+	if portConflictsCmd == nil {
+		t.Fatal("port-conflicts command should not be nil")
+	}
+
+	if portConflictsCmd.Use != "port-conflicts" {
+		t.Errorf("port-conflicts command Use should be 'port-conflicts', got %q", portConflictsCmd.Use)
+	}
+}
+
+// setPortConflictsCompose points the configuration at a Compose file.
+func setPortConflictsCompose(t *testing.T, content string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "docker-compose.yml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	viper.Reset()
+	viper.Set("compose-file", []string{path})
+	viper.Set("project-name", "shop")
+}
+
+// TestRunPortConflicts verifies that services publishing the same host port
+// are reported and fail the command.
+func TestRunPortConflicts(t *testing.T) {
+	setPortConflictsCompose(t, `
+services:
+  web:
+    image: nginx
+    ports: ["80:80"]
+  admin:
+    image: shop/admin
+    ports: ["127.0.0.1:80:8080", "127.0.0.1:8443:443"]
+  api:
+    image: shop/api
+    ports: ["8443:443"]
+    networks: [back]
+networks:
+  back:
+`)
+	defer viper.Reset()
+
+	buf := new(bytes.Buffer)
+	portConflictsCmd.SetOut(buf)
+	defer portConflictsCmd.SetOut(nil)
+
+	err := runPortConflicts(portConflictsCmd, nil)
+	if err == nil || err.Error() != "2 host port conflict(s)" {
+		t.Errorf("expected 2 conflicts error, got %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"=== Host Port Conflicts ===",
+		"Host port 80/tcp",
+		"shop-admin-1: 127.0.0.1:80 (running)",
+		"shop-web-1: 0.0.0.0:80 (running)",
+		"Host port 8443/tcp",
+		"only the first to start will get the port",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+// TestRunPortConflictsNone verifies the output without conflicts.
+func TestRunPortConflictsNone(t *testing.T) {
+	setPortConflictsCompose(t, `
+services:
+  web:
+    image: nginx
+    ports: ["80:80"]
+  api:
+    image: shop/api
+    ports: ["8080:80"]
+`)
+	defer viper.Reset()
+
+	buf := new(bytes.Buffer)
+	portConflictsCmd.SetOut(buf)
+	defer portConflictsCmd.SetOut(nil)
+
+	if err := runPortConflicts(portConflictsCmd, nil); err != nil {
+		t.Fatalf("runPortConflicts returned error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "No host port conflicts.") {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,173 @@
+// Package cmd provides the CLI commands for the docker-network-viz tool.
+// This file contains the probe command which tests connectivity from inside containers.
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/docker"
+	"git.o.ocom.com.au/go/docker-network-viz/internal/output"
+	"git.o.ocom.com.au/go/docker-network-viz/internal/probe"
+)
+
+var (
+	// probeConcurrency is the number of probe commands run at once.
+	probeConcurrency int
+
+	// probeTimeout is the time a single probe command may take.
+	probeTimeout time.Duration
+
+	// probeCmd represents the probe command.
+	probeCmd = &cobra.Command{
+		Use:   "probe [CONTAINER...]",
+		Short: "Test from inside containers that expected connections actually work",
+		Long: `Test the connections the reachability model expects, from inside the
+containers, and report expected against actual results.
+
+For every running container and every running peer it shares a network
+with, two commands are run in the container through the Docker exec API:
+  - A DNS lookup of the name the peer answers to on the shared network,
+    preferring its Compose service name or an alias, with getent or nslookup
+  - A TCP connect to the peer's lowest exposed TCP port, with nc, or bash
+    under timeout
+
+Containers without a shell or without any of these tools are skipped, and
+a check whose tool is missing is reported as skipped. On networks without
+embedded DNS, such as the default bridge, the peer is connected to by
+address. Networks with inter-container communication disabled are not
+expected to connect and are not probed.
+
+Probing runs commands inside containers, so it only happens when this
+command is run, and only against live containers. At most --concurrency
+commands run at once, each bounded by --timeout. Give container names to
+probe only from them. The command fails when any check fails.
+
+Examples:
+  # Probe every expected connection
+  docker-network-viz probe
+
+  # Probe from the api container only, with a longer timeout
+  docker-network-viz probe shop-api-1 --timeout 5s
+
+  # Probe a large host gently
+  docker-network-viz probe --concurrency 2`,
+		Args: cobra.ArbitraryArgs,
+		RunE: runProbe,
+	}
+)
+
+func init() {
+	// Add probe command to root
+	rootCmd.AddCommand(probeCmd)
+
+	// Local flags for probe command
+	probeCmd.Flags().IntVar(&probeConcurrency, "concurrency", probe.DefaultConcurrency,
+		"number of probe commands run at once")
+	probeCmd.Flags().DurationVar(&probeTimeout, "timeout", probe.DefaultTimeout,
+		"time a single probe command may take")
+
+	// Bind flags to viper
+	_ = viper.BindPFlag("probe.concurrency", probeCmd.Flags().Lookup("concurrency"))
+	_ = viper.BindPFlag("probe.timeout", probeCmd.Flags().Lookup("timeout"))
+}
+
+// runProbe executes the probe command logic.
+func runProbe(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	if usingComposeFiles() {
+		return errors.New("probe runs commands in live containers and cannot be used with --compose-file")
+	}
+
+	opts := probe.Options{
+		Concurrency: viper.GetInt("probe.concurrency"),
+		Timeout:     viper.GetDuration("probe.timeout"),
+	}
+	if opts.Concurrency < 1 {
+		return fmt.Errorf("invalid concurrency %d: must be at least 1", opts.Concurrency)
+	}
+	if opts.Timeout <= 0 {
+		return fmt.Errorf("invalid timeout %s: must be positive", opts.Timeout)
+	}
+
+	// Initialize Docker client
+	client, err := newDockerClient()
+	if err != nil {
+		return fmt.Errorf("failed to create Docker client: %w", err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	topo, err := fetchTopology(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	names := args
+	if len(names) == 0 {
+		for name := range topo.containerMap {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+
+	networks := output.NetworksByName(topo.networkInfos())
+	var sources []probe.Source
+	for _, name := range names {
+		c, ok := topo.containerMap[name]
+		if !ok {
+			return fmt.Errorf("container %s not found", name)
+		}
+		// Pod members share the namespace owner's endpoints; probing from
+		// the owner covers them.
+		if !c.IsActive() || c.NetworkNamespace != "" {
+			continue
+		}
+
+		dns, err := client.BuildContainerDNS(topo.containers, name)
+		if err != nil {
+			return fmt.Errorf("failed to build DNS view: %w", err)
+		}
+		peers := output.ReachablePeers(name, topo.networkToContainers, networks)
+		targets := probe.Plan(name, peers, dns, topo.containerMap)
+		for i, t := range targets {
+			// Networks without embedded DNS have no records to take it from.
+			if t.IP == "" {
+				targets[i].IP = docker.EndpointAddress(topo.containers, t.To, t.Network)
+			}
+		}
+		if len(targets) > 0 {
+			sources = append(sources, probe.Source{Name: name, ID: c.ID, Targets: targets})
+		}
+	}
+
+	w := cmd.OutOrStdout()
+	fmt.Fprintln(w, "=== Connectivity Probes ===")
+	if len(sources) == 0 {
+		fmt.Fprintln(w, "No running containers with running peers to probe")
+		return nil
+	}
+
+	report := probe.Run(ctx, client, sources, opts)
+	output.PrintProbeReport(w, report)
+
+	failed := 0
+	for _, r := range report.Results {
+		if r.Failed() {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d probe(s) failed", failed)
+	}
+
+	return nil
+}
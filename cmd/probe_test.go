@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/probe"
+)
+
+// TestProbeCommandExists verifies that the probe command is properly defined.
+func TestProbeCommandExists(t *testing.T) {
+	if probeCmd == nil {
+		t.Fatal("probe command should not be nil")
+	}
+
+	if probeCmd.Use != "probe [CONTAINER...]" {
+		t.Errorf("probe command Use should be 'probe [CONTAINER...]', got %q", probeCmd.Use)
+	}
+}
+
+// TestProbeCommandFlags verifies the probe flags and their defaults.
+func TestProbeCommandFlags(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"concurrency", "4"},
+		{"timeout", probe.DefaultTimeout.String()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flag := probeCmd.Flags().Lookup(tt.name)
+			if flag == nil {
+				t.Fatalf("expected flag --%s", tt.name)
+			}
+			if flag.DefValue != tt.expected {
+				t.Errorf("expected default %q, got %q", tt.expected, flag.DefValue)
+			}
+		})
+	}
+}
+
+// TestRunProbeErrors verifies the errors reported before any container is
+// contacted.
+func TestRunProbeErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings map[string]any
+		expected string
+	}{
+		{
+			name:     "compose file",
+			settings: map[string]any{"compose-file": []string{"docker-compose.yml"}},
+			expected: "cannot be used with --compose-file",
+		},
+		{
+			name:     "invalid concurrency",
+			settings: map[string]any{"probe.concurrency": 0, "probe.timeout": time.Second},
+			expected: "invalid concurrency 0: must be at least 1",
+		},
+		{
+			name:     "invalid timeout",
+			settings: map[string]any{"probe.concurrency": 1, "probe.timeout": -time.Second},
+			expected: "invalid timeout -1s: must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			defer viper.Reset()
+			for k, v := range tt.settings {
+				viper.Set(k, v)
+			}
+
+			err := runProbe(probeCmd, nil)
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("expected error containing %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+// Package cmd provides the CLI commands for the docker-network-viz tool.
+// This file contains the prune-plan command which proposes unused networks for removal.
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/output"
+	"git.o.ocom.com.au/go/docker-network-viz/internal/prune"
+)
+
+var (
+	// pruneApply removes the planned networks instead of only printing the plan.
+	pruneApply bool
+
+	// pruneYes skips the confirmation prompt of --apply.
+	pruneYes bool
+
+	// pruneCmd represents the prune-plan command.
+	pruneCmd = &cobra.Command{
+		Use:   "prune-plan",
+		Short: "List unused networks and the commands to remove them",
+		Long: `List the user-defined networks that look unused, explain why, and print
+the "docker network rm" command for each one. A network is proposed when:
+
+- it belongs to a Compose project that has no containers left, and no
+  running container uses it (orphaned-project)
+- it has no containers attached (no-containers)
+- all its containers are stopped (exited-only); those containers will fail
+  to start once the network is removed
+
+Networks managed by Docker (bridge, host, none, docker_gwbridge, ingress) are
+never proposed. Unlike "docker network prune", nothing is removed unless
+--apply is given, and --apply asks for confirmation unless --yes is given.
+
+The topology is always read from the Docker daemon; --compose-file is ignored.
+
+Examples:
+  # Review the plan
+  docker-network-viz prune-plan
+
+  # Remove the planned networks after confirmation
+  docker-network-viz prune-plan --apply`,
+		Args: cobra.NoArgs,
+		RunE: runPrunePlan,
+	}
+)
+
+// networkRemover removes Docker networks; it is implemented by docker.Client.
+type networkRemover interface {
+	RemoveNetwork(ctx context.Context, networkID string) error
+}
+
+func init() {
+	// Add prune-plan command to root
+	rootCmd.AddCommand(pruneCmd)
+
+	// Local flags for prune-plan command. They are deliberately not bound to
+	// viper, so a configuration file cannot turn on removal.
+	pruneCmd.Flags().BoolVar(&pruneApply, "apply", false,
+		"remove the planned networks")
+	pruneCmd.Flags().BoolVarP(&pruneYes, "yes", "y", false,
+		"do not ask for confirmation with --apply")
+}
+
+// runPrunePlan executes the prune-plan command logic.
+func runPrunePlan(cmd *cobra.Command, _ []string) error {
+	ctx := context.Background()
+
+	// Initialize Docker client
+	client, err := newDockerClient()
+	if err != nil {
+		return fmt.Errorf("failed to create Docker client: %w", err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	// Stopped containers and project labels only exist on the daemon.
+	topo, err := fetchLiveTopology(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	candidates := prune.Plan(topo.networkInfos(), topo.containerMap, topo.networkToContainers)
+	printPrunePlan(cmd.OutOrStdout(), candidates)
+
+	if !pruneApply || len(candidates) == 0 {
+		return nil
+	}
+
+	if !pruneYes && !confirm(cmd.InOrStdin(), cmd.OutOrStdout(),
+		fmt.Sprintf("Remove %d network(s)?", len(candidates))) {
+		fmt.Fprintln(cmd.OutOrStdout(), "Aborted; no networks were removed")
+		return nil
+	}
+
+	return applyPrunePlan(ctx, cmd.OutOrStdout(), client, candidates)
+}
+
+// printPrunePlan prints each candidate with the reason it is considered
+// unused, followed by the commands that remove them.
+func printPrunePlan(w io.Writer, candidates []prune.Candidate) {
+	cw := output.NewColorWriter(w)
+
+	fmt.Fprintln(w, "=== Network Prune Plan ===")
+
+	if len(candidates) == 0 {
+		fmt.Fprintln(w, "No unused networks found")
+		return
+	}
+
+	for _, c := range candidates {
+		fmt.Fprintf(w, "%s %s\n", cw.Warning(fmt.Sprintf("%-16s", c.Reason)), c)
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, c := range candidates {
+		fmt.Fprintf(w, "  %s\n", c.Command())
+	}
+}
+
+// applyPrunePlan removes the candidate networks, continuing past failures
+// such as networks that gained containers since the plan was made.
+func applyPrunePlan(ctx context.Context, w io.Writer, remover networkRemover, candidates []prune.Candidate) error {
+	failed := 0
+	for _, c := range candidates {
+		id := c.ID
+		if id == "" {
+			id = c.Network
+		}
+
+		if err := remover.RemoveNetwork(ctx, id); err != nil {
+			fmt.Fprintf(w, "Failed to remove %s: %v\n", c.Network, err)
+			failed++
+			continue
+		}
+		fmt.Fprintf(w, "Removed %s\n", c.Network)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to remove %d of %d network(s)", failed, len(candidates))
+	}
+
+	return nil
+}
+
+// confirm asks a yes/no question and reports whether the answer was yes.
+func confirm(r io.Reader, w io.Writer, question string) bool {
+	fmt.Fprintf(w, "%s [y/N] ", question)
+
+	answer, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && answer == "" {
+		return false
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
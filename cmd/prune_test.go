@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/prune"
+)
+
+// fakeRemover records removed networks and fails for the listed IDs.
+type fakeRemover struct {
+	removed []string
+	fail    map[string]bool
+}
+
+// RemoveNetwork implements networkRemover.
+func (f *fakeRemover) RemoveNetwork(_ context.Context, networkID string) error {
+	if f.fail[networkID] {
+		return errors.New("network has active endpoints")
+	}
+	f.removed = append(f.removed, networkID)
+	return nil
+}
+
+// pruneCandidates returns a plan with two networks.
+func pruneCandidates() []prune.Candidate {
+	return []prune.Candidate{
+		{Network: "empty", ID: "n1", Reason: prune.ReasonNoContainers},
+		{Network: "stopped", ID: "n2", Reason: prune.ReasonExitedOnly, Containers: []string{"job"}},
+	}
+}
+
+// TestPruneCommandExists verifies that the prune-plan command is properly defined.
+func TestPruneCommandExists(t *testing.T) {
+	if pruneCmd == nil {
+		t.Fatal("prune-plan command should not be nil")
+	}
+
+	if pruneCmd.Use != "prune-plan" {
+		t.Errorf("prune-plan command Use should be 'prune-plan', got %q", pruneCmd.Use)
+	}
+
+	for _, name := range []string{"apply", "yes"} {
+		if pruneCmd.Flags().Lookup(name) == nil {
+			t.Errorf("prune-plan command should have a %s flag", name)
+		}
+	}
+}
+
+// TestPrintPrunePlan verifies the plan output.
+func TestPrintPrunePlan(t *testing.T) {
+	buf := new(bytes.Buffer)
+	printPrunePlan(buf, pruneCandidates())
+
+	out := buf.String()
+	for _, want := range []string{
+		"=== Network Prune Plan ===",
+		"no-containers",
+		"network stopped is only used by stopped containers: job",
+		"Commands:",
+		"  docker network rm empty\n",
+		"  docker network rm stopped\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	printPrunePlan(buf, nil)
+	if !strings.Contains(buf.String(), "No unused networks found") {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+}
+
+// TestApplyPrunePlan verifies removal by ID and error reporting.
+func TestApplyPrunePlan(t *testing.T) {
+	remover := &fakeRemover{fail: map[string]bool{"n2": true}}
+	buf := new(bytes.Buffer)
+
+	err := applyPrunePlan(context.Background(), buf, remover, pruneCandidates())
+	if err == nil {
+		t.Error("expected error for failed removal")
+	}
+
+	if len(remover.removed) != 1 || remover.removed[0] != "n1" {
+		t.Errorf("expected n1 to be removed, got %v", remover.removed)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "Removed empty") || !strings.Contains(out, "Failed to remove stopped") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+}
+
+// TestConfirm verifies the confirmation prompt answers.
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"y\n", true},
+		{"YES\n", true},
+		{"yes", true},
+		{"n\n", false},
+		{"\n", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		buf := new(bytes.Buffer)
+		if got := confirm(strings.NewReader(tt.input), buf, "Remove?"); got != tt.want {
+			t.Errorf("confirm(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+		if buf.String() != "Remove? [y/N] " {
+			t.Errorf("unexpected prompt: %q", buf.String())
+		}
+	}
+}
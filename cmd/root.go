@@ -26,6 +26,12 @@ var (
 	// noColor disables colored output.
 	noColor bool
 
+	// composeFiles are Compose files to analyze instead of the live daemon.
+	composeFiles []string
+
+	// composeProjectName overrides the project name derived for composeFiles.
+	composeProjectName string
+
 	// rootCmd is the base command when called without any subcommands.
 	rootCmd = &cobra.Command{
 		Use:   AppName,
@@ -54,7 +60,23 @@ and through which networks.`,
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
+//
+// When run by the Docker CLI as a plugin, the Docker CLI's global flags and
+// the plugin name are translated first; see parsePluginArgs.
 func Execute() error {
+	if isCLIPlugin() {
+		inv, err := parsePluginArgs(os.Args[1:])
+		if err != nil {
+			return err
+		}
+		if inv.configDir != "" {
+			if err := os.Setenv("DOCKER_CONFIG", inv.configDir); err != nil {
+				return fmt.Errorf("failed to set Docker config directory: %w", err)
+			}
+		}
+		rootCmd.SetArgs(inv.args)
+	}
+
 	return rootCmd.Execute()
 }
 
@@ -65,6 +87,11 @@ func init() {
 		"config file (default is $HOME/.docker-network-viz.yaml)")
 	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false,
 		"disable colored output")
+	rootCmd.PersistentFlags().StringSliceVarP(&composeFiles, "compose-file", "f", nil,
+		"analyze these Compose files instead of the running Docker daemon (repeatable)")
+	rootCmd.PersistentFlags().StringVar(&composeProjectName, "project-name", "",
+		"Compose project name used with --compose-file")
+	addConnectionFlags(rootCmd.PersistentFlags())
 
 	// Flags for visualize command (also available on root for default behavior)
 	rootCmd.Flags().StringVar(&onlyNetwork, "only-network", "",
@@ -73,12 +100,17 @@ func init() {
 		"show only the specified container's connectivity")
 	rootCmd.Flags().BoolVar(&noAliases, "no-aliases", false,
 		"hide container aliases in the output")
+	rootCmd.Flags().BoolVar(&noGeneratedAliases, "no-generated-aliases", false,
+		"hide names Docker generates for every container, such as the short container ID")
 
 	// Bind flags to viper
 	_ = viper.BindPFlag("no-color", rootCmd.PersistentFlags().Lookup("no-color"))
+	_ = viper.BindPFlag("compose-file", rootCmd.PersistentFlags().Lookup("compose-file"))
+	_ = viper.BindPFlag("project-name", rootCmd.PersistentFlags().Lookup("project-name"))
 	_ = viper.BindPFlag("only-network", rootCmd.Flags().Lookup("only-network"))
 	_ = viper.BindPFlag("container", rootCmd.Flags().Lookup("container"))
 	_ = viper.BindPFlag("no-aliases", rootCmd.Flags().Lookup("no-aliases"))
+	_ = viper.BindPFlag("no-generated-aliases", rootCmd.Flags().Lookup("no-generated-aliases"))
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -129,18 +161,46 @@ func ResetRootCmd() {
 		"config file (default is $HOME/.docker-network-viz.yaml)")
 	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false,
 		"disable colored output")
+	rootCmd.PersistentFlags().StringSliceVarP(&composeFiles, "compose-file", "f", nil,
+		"analyze these Compose files instead of the running Docker daemon (repeatable)")
+	rootCmd.PersistentFlags().StringVar(&composeProjectName, "project-name", "",
+		"Compose project name used with --compose-file")
+	addConnectionFlags(rootCmd.PersistentFlags())
 	rootCmd.Flags().StringVar(&onlyNetwork, "only-network", "",
 		"show only the specified network")
 	rootCmd.Flags().StringVar(&containerFilter, "container", "",
 		"show only the specified container's connectivity")
 	rootCmd.Flags().BoolVar(&noAliases, "no-aliases", false,
 		"hide container aliases in the output")
+	rootCmd.Flags().BoolVar(&noGeneratedAliases, "no-generated-aliases", false,
+		"hide names Docker generates for every container, such as the short container ID")
 
 	_ = viper.BindPFlag("no-color", rootCmd.PersistentFlags().Lookup("no-color"))
+	_ = viper.BindPFlag("compose-file", rootCmd.PersistentFlags().Lookup("compose-file"))
+	_ = viper.BindPFlag("project-name", rootCmd.PersistentFlags().Lookup("project-name"))
 	_ = viper.BindPFlag("only-network", rootCmd.Flags().Lookup("only-network"))
 	_ = viper.BindPFlag("container", rootCmd.Flags().Lookup("container"))
 	_ = viper.BindPFlag("no-aliases", rootCmd.Flags().Lookup("no-aliases"))
+	_ = viper.BindPFlag("no-generated-aliases", rootCmd.Flags().Lookup("no-generated-aliases"))
 
 	// Re-add subcommands
 	rootCmd.AddCommand(visualizeCmd)
+	rootCmd.AddCommand(exporterCmd)
+	rootCmd.AddCommand(capacityCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(verifyCmd)
+	rootCmd.AddCommand(dnsCmd)
+	rootCmd.AddCommand(lintCmd)
+	rootCmd.AddCommand(pruneCmd)
+	rootCmd.AddCommand(connectCmd)
+	rootCmd.AddCommand(disconnectCmd)
+	rootCmd.AddCommand(simulateCmd)
+	rootCmd.AddCommand(ingressCmd)
+	rootCmd.AddCommand(depsCmd)
+	rootCmd.AddCommand(blastRadiusCmd)
+	rootCmd.AddCommand(graphStatsCmd)
+	rootCmd.AddCommand(segmentationCmd)
+	rootCmd.AddCommand(portConflictsCmd)
+	rootCmd.AddCommand(probeCmd)
+	rootCmd.AddCommand(pluginMetadataCmd)
 }
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
@@ -205,3 +206,24 @@ func TestAppConstants(t *testing.T) {
 		t.Errorf("AppName should be 'docker-network-viz', got %q", AppName)
 	}
 }
+
+// TestSubcommandFlagsDoNotClash verifies that no subcommand redefines a
+// shorthand of the global flags, which makes Cobra panic when it merges them.
+func TestSubcommandFlagsDoNotClash(t *testing.T) {
+	var check func(c *cobra.Command)
+	check = func(c *cobra.Command) {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					t.Errorf("flags of %q clash: %v", c.CommandPath(), r)
+				}
+			}()
+			_ = c.LocalFlags()
+		}()
+		for _, sub := range c.Commands() {
+			check(sub)
+		}
+	}
+
+	check(GetRootCmd())
+}
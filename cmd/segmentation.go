@@ -0,0 +1,164 @@
+// Package cmd provides the CLI commands for the docker-network-viz tool.
+// This file contains the segmentation command which grades the network design.
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/deps"
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+	"git.o.ocom.com.au/go/docker-network-viz/internal/output"
+	"git.o.ocom.com.au/go/docker-network-viz/internal/segment"
+)
+
+var (
+	// segmentationPolicy is the path of a policy file listing the allowed
+	// communication.
+	segmentationPolicy string
+
+	// segmentationBroadThreshold is the number of containers from which a
+	// network counts as overly broad.
+	segmentationBroadThreshold int
+
+	// segmentationCmd represents the segmentation command.
+	segmentationCmd = &cobra.Command{
+		Use:   "segmentation",
+		Short: "Score the network design and propose networks for the needed communication",
+		Long: `Score how well containers are segmented into networks, from 0 to 100, and
+propose a minimal set of networks that allows only the communication the
+containers need.
+
+The score starts at 100 and loses points, up to a cap per category, for:
+  - Containers on the default bridge network (5 each, up to 25)
+  - Overly broad networks shared by --broad-threshold or more containers
+    (10 each, up to 25)
+  - Databases sharing a network with a container that publishes ports on
+    all host interfaces (15 each, up to 30)
+  - Multi-homed containers attached to several networks (2 each, up to 20)
+
+The needed communication is read from a policy file given with --policy:
+
+  allow:
+    - from: web
+      to: api
+    - from: api
+      to: [db, cache]
+
+Names are container or Compose service names. Without a policy file it is
+derived from the host names in each container's environment and its Compose
+depends_on, like the deps command does. The proposal groups containers that
+all need to talk to each other into one network, so that every pair sharing a
+proposed network has a need.
+
+Examples:
+  # Score the local host and propose networks from the environment
+  docker-network-viz segmentation
+
+  # Propose networks from a policy file
+  docker-network-viz segmentation --policy allow.yml
+
+  # Grade a Compose project before deploying it
+  docker-network-viz segmentation -f docker-compose.yml`,
+		Args: cobra.NoArgs,
+		RunE: runSegmentation,
+	}
+)
+
+func init() {
+	// Add segmentation command to root
+	rootCmd.AddCommand(segmentationCmd)
+
+	// Local flags for segmentation command
+	segmentationCmd.Flags().StringVar(&segmentationPolicy, "policy", "",
+		"policy file listing the allowed communication")
+	segmentationCmd.Flags().IntVar(&segmentationBroadThreshold, "broad-threshold", segment.DefaultBroadNetworkSize,
+		"number of containers from which a network counts as overly broad")
+
+	// Bind flags to viper
+	_ = viper.BindPFlag("segmentation.policy", segmentationCmd.Flags().Lookup("policy"))
+	_ = viper.BindPFlag("segmentation.broad-threshold", segmentationCmd.Flags().Lookup("broad-threshold"))
+}
+
+// runSegmentation executes the segmentation command logic.
+func runSegmentation(cmd *cobra.Command, _ []string) error {
+	ctx := context.Background()
+
+	// Load the policy first so a bad file fails before Docker is queried.
+	var policy *segment.Policy
+	policyPath := viper.GetString("segmentation.policy")
+	if policyPath != "" {
+		var err error
+		policy, err = segment.LoadPolicy(policyPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Initialize Docker client
+	client, err := newDockerClient()
+	if err != nil {
+		return fmt.Errorf("failed to create Docker client: %w", err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	topo, err := fetchTopology(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	var needs []segment.Need
+	source := "policy " + policyPath
+	if policy != nil {
+		needs, err = policy.Needs(topo.containerMap)
+		if err != nil {
+			return err
+		}
+	} else {
+		// Without a policy, the needs come from the environment.
+		if err := topo.loadEnv(ctx, client); err != nil {
+			return err
+		}
+		needs = dependencyNeeds(topo.containerMap)
+		source = "environment and depends_on"
+	}
+
+	report := segment.Score(topo.containerMap, segment.Options{
+		BroadNetworkSize: viper.GetInt("segmentation.broad-threshold"),
+	})
+
+	w := cmd.OutOrStdout()
+	fmt.Fprintln(w, "=== Segmentation ===")
+	output.PrintSegmentationScore(w, report)
+	fmt.Fprintln(w)
+
+	if len(needs) == 0 {
+		fmt.Fprintf(w, "No communication needs found in %s; use --policy to propose networks.\n", source)
+		return nil
+	}
+	output.PrintSegmentationProposal(w, segment.Propose(topo.containerMap, needs), source)
+
+	return nil
+}
+
+// dependencyNeeds derives the communication needs from the host names each
+// container refers to, mapping every name to the containers that answer to
+// it on any network.
+func dependencyNeeds(containers map[string]*models.ContainerInfo) []segment.Need {
+	var needs []segment.Need
+	for _, c := range containers {
+		for _, ref := range deps.References(c) {
+			for _, provider := range deps.Providers(ref.Name, containers) {
+				if provider != c.Name {
+					needs = append(needs, segment.Need{From: c.Name, To: provider})
+				}
+			}
+		}
+	}
+	return needs
+}
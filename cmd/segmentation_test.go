@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+	"git.o.ocom.com.au/go/docker-network-viz/internal/segment"
+)
+
+// TestSegmentationCommandExists verifies that the segmentation command is properly defined.
+func TestSegmentationCommandExists(t *testing.T) {
+	if segmentationCmd == nil {
+		t.Fatal("segmentation command should not be nil")
+	}
+
+	if segmentationCmd.Use != "segmentation" {
+		t.Errorf("segmentation command Use should be 'segmentation', got %q", segmentationCmd.Use)
+	}
+
+	for _, name := range []string{"policy", "broad-threshold"} {
+		if segmentationCmd.Flags().Lookup(name) == nil {
+			t.Errorf("segmentation command should have a --%s flag", name)
+		}
+	}
+}
+
+// setSegmentationCompose points the configuration at a Compose file with
+// every service on the default network: a public web server, an api
+// depending on the database and a worker with no dependencies.
+func setSegmentationCompose(t *testing.T) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "docker-compose.yml")
+	content := `
+services:
+  web:
+    image: nginx
+    ports: ["80:80"]
+    environment:
+      API_URL: http://api:8080
+  api:
+    image: shop/api
+    environment:
+      DB_HOST: db
+    depends_on: [db]
+  db:
+    image: postgres
+  worker:
+    image: shop/worker
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	viper.Reset()
+	viper.Set("compose-file", []string{path})
+	viper.Set("project-name", "shop")
+	viper.Set("segmentation.broad-threshold", segment.DefaultBroadNetworkSize)
+}
+
+// runSegmentationOutput runs the segmentation command and returns its output.
+func runSegmentationOutput(t *testing.T) (string, error) {
+	t.Helper()
+
+	buf := new(bytes.Buffer)
+	segmentationCmd.SetOut(buf)
+	defer segmentationCmd.SetOut(nil)
+
+	err := runSegmentation(segmentationCmd, nil)
+	return buf.String(), err
+}
+
+// TestRunSegmentationFromEnvironment verifies the score and the proposal
+// derived from the environment of a Compose project.
+func TestRunSegmentationFromEnvironment(t *testing.T) {
+	setSegmentationCompose(t)
+	defer viper.Reset()
+
+	out, err := runSegmentationOutput(t)
+	if err != nil {
+		t.Fatalf("runSegmentation returned error: %v", err)
+	}
+
+	for _, want := range []string{
+		"=== Segmentation ===",
+		"Score: 85/100",
+		"shop-db-1 (PostgreSQL) shares shop_default with shop-web-1",
+		"Proposed networks (from environment and depends_on):",
+		"api_net: shop-api-1, shop-db-1",
+		"api_net_2: shop-api-1, shop-web-1",
+		"Unconnected: shop-worker-1",
+		"Reachable pairs: 6 now, 2 proposed",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+// TestRunSegmentationFromPolicy verifies the proposal read from a policy file.
+func TestRunSegmentationFromPolicy(t *testing.T) {
+	setSegmentationCompose(t)
+	defer viper.Reset()
+
+	path := filepath.Join(t.TempDir(), "policy.yml")
+	content := "allow:\n  - from: worker\n    to: [db]\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	viper.Set("segmentation.policy", path)
+
+	out, err := runSegmentationOutput(t)
+	if err != nil {
+		t.Fatalf("runSegmentation returned error: %v", err)
+	}
+
+	for _, want := range []string{
+		"Proposed networks (from policy " + path + "):",
+		"db_net: shop-db-1, shop-worker-1",
+		"Unconnected: shop-api-1, shop-web-1",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+// TestRunSegmentationPolicyErrors verifies that missing policy files and
+// unknown names are reported.
+func TestRunSegmentationPolicyErrors(t *testing.T) {
+	setSegmentationCompose(t)
+	defer viper.Reset()
+
+	viper.Set("segmentation.policy", filepath.Join(t.TempDir(), "missing.yml"))
+	if _, err := runSegmentationOutput(t); err == nil {
+		t.Error("expected error for missing policy file")
+	}
+
+	path := filepath.Join(t.TempDir(), "policy.yml")
+	if err := os.WriteFile(path, []byte("allow:\n  - from: web\n    to: ghost\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	viper.Set("segmentation.policy", path)
+	if _, err := runSegmentationOutput(t); err == nil || !strings.Contains(err.Error(), "ghost") {
+		t.Errorf("expected error naming ghost, got %v", err)
+	}
+}
+
+// TestDependencyNeeds verifies that references are mapped to the containers
+// answering to them.
+func TestDependencyNeeds(t *testing.T) {
+	containers := map[string]*models.ContainerInfo{
+		"api": {Name: "api", Networks: []string{"back"}, Env: map[string]string{"DB_HOST": "db", "SELF_URL": "http://api"}},
+		"db":  {Name: "db", Networks: []string{"back"}},
+	}
+
+	needs := dependencyNeeds(containers)
+
+	if len(needs) != 1 || needs[0] != (segment.Need{From: "api", To: "db"}) {
+		t.Errorf("dependencyNeeds = %+v, want api -> db", needs)
+	}
+}
@@ -0,0 +1,226 @@
+// Package cmd provides the CLI commands for the docker-network-viz tool.
+// This file contains the simulate command which previews hypothetical topology changes.
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+	"git.o.ocom.com.au/go/docker-network-viz/internal/output"
+	"git.o.ocom.com.au/go/docker-network-viz/internal/simulate"
+)
+
+var (
+	// simulateChangesFile is a YAML file of hypothetical changes.
+	simulateChangesFile string
+
+	// simulateDiffOnly prints only the reachability diff.
+	simulateDiffOnly bool
+
+	// simulateFlagChanges holds the changes given as flags, keyed by action.
+	simulateFlagChanges = map[simulate.Action]*[]string{
+		simulate.ActionAddNetwork:      new([]string),
+		simulate.ActionAddContainer:    new([]string),
+		simulate.ActionConnect:         new([]string),
+		simulate.ActionDisconnect:      new([]string),
+		simulate.ActionRemoveContainer: new([]string),
+		simulate.ActionRemoveNetwork:   new([]string),
+	}
+
+	// simulateFlagOrder is the order in which flag changes are applied.
+	simulateFlagOrder = []simulate.Action{
+		simulate.ActionAddNetwork,
+		simulate.ActionAddContainer,
+		simulate.ActionConnect,
+		simulate.ActionDisconnect,
+		simulate.ActionRemoveContainer,
+		simulate.ActionRemoveNetwork,
+	}
+
+	// simulateCmd represents the simulate command.
+	simulateCmd = &cobra.Command{
+		Use:   "simulate",
+		Short: "Preview the topology after hypothetical changes",
+		Long: `Apply hypothetical changes to an in-memory copy of the topology and print
+the resulting network and container trees, followed by the reachability diff:
+which containers gain or lose which peers. Nothing is changed on the Docker
+daemon, so segmentation refactors can be reviewed before they are executed.
+
+Changes are read from a YAML file with --changes and from flags. File changes
+are applied first, in order, followed by flag changes in this order:
+add-network, add-container, connect, disconnect, remove-container,
+remove-network.
+
+  changes:
+    - action: add-network
+      network: data
+      internal: true
+    - action: connect
+      container: postgres
+      network: data
+      aliases: [db]
+    - action: disconnect
+      container: postgres
+      network: backend
+    - action: add-container
+      container: worker
+      networks: [data]
+    - action: remove-network
+      network: legacy
+
+Removing a network detaches all its containers.
+
+Examples:
+  # Preview a change file
+  docker-network-viz simulate --changes refactor.yml
+
+  # Preview ad-hoc changes, showing only the reachability diff
+  docker-network-viz simulate --connect api:backend --disconnect api:frontend --diff-only
+
+  # Preview changes to a Compose project before deploying it
+  docker-network-viz simulate -f docker-compose.yml --add-container worker:shop_back`,
+		Args: cobra.NoArgs,
+		RunE: runSimulate,
+	}
+)
+
+func init() {
+	// Add simulate command to root
+	rootCmd.AddCommand(simulateCmd)
+
+	// Local flags for simulate command
+	simulateCmd.Flags().StringVar(&simulateChangesFile, "changes", "",
+		"YAML file of changes to simulate")
+	simulateCmd.Flags().BoolVar(&simulateDiffOnly, "diff-only", false,
+		"print only the reachability diff, not the resulting trees")
+
+	simulateCmd.Flags().StringArrayVar(simulateFlagChanges[simulate.ActionConnect], "connect", nil,
+		"connect a container to a network, as CONTAINER:NETWORK (repeatable)")
+	simulateCmd.Flags().StringArrayVar(simulateFlagChanges[simulate.ActionDisconnect], "disconnect", nil,
+		"disconnect a container from a network, as CONTAINER:NETWORK (repeatable)")
+	simulateCmd.Flags().StringArrayVar(simulateFlagChanges[simulate.ActionAddNetwork], "add-network", nil,
+		"add a bridge network (repeatable)")
+	simulateCmd.Flags().StringArrayVar(simulateFlagChanges[simulate.ActionRemoveNetwork], "remove-network", nil,
+		"remove a network, detaching its containers (repeatable)")
+	simulateCmd.Flags().StringArrayVar(simulateFlagChanges[simulate.ActionAddContainer], "add-container", nil,
+		"add a container, as CONTAINER:NETWORK[,NETWORK...] (repeatable)")
+	simulateCmd.Flags().StringArrayVar(simulateFlagChanges[simulate.ActionRemoveContainer], "remove-container", nil,
+		"remove a container (repeatable)")
+
+	// Bind flags to viper
+	_ = viper.BindPFlag("simulate.changes", simulateCmd.Flags().Lookup("changes"))
+	_ = viper.BindPFlag("simulate.diff-only", simulateCmd.Flags().Lookup("diff-only"))
+}
+
+// runSimulate executes the simulate command logic.
+func runSimulate(cmd *cobra.Command, _ []string) error {
+	ctx := context.Background()
+
+	changes, err := simulationChanges()
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		return fmt.Errorf("no changes to simulate: use --changes or a change flag such as --connect")
+	}
+
+	// Initialize Docker client
+	client, err := newDockerClient()
+	if err != nil {
+		return fmt.Errorf("failed to create Docker client: %w", err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	topo, err := fetchTopology(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	networks := topo.networkInfos()
+	state := simulate.NewState(networks, topo.containerMap)
+	if err := state.Apply(changes); err != nil {
+		return fmt.Errorf("failed to simulate changes: %w", err)
+	}
+
+	return printSimulation(cmd.OutOrStdout(), changes, state, topo.networkToContainers, networks,
+		viper.GetBool("simulate.diff-only"))
+}
+
+// simulationChanges collects the changes from the change file and flags.
+func simulationChanges() ([]simulate.Change, error) {
+	var changes []simulate.Change
+
+	if path := viper.GetString("simulate.changes"); path != "" {
+		fileChanges, err := simulate.LoadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, fileChanges...)
+	}
+
+	for _, action := range simulateFlagOrder {
+		for _, spec := range *simulateFlagChanges[action] {
+			change, err := simulate.ParseChange(action, spec)
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, change)
+		}
+	}
+
+	return changes, nil
+}
+
+// printSimulation prints the simulated changes, the resulting topology unless
+// diffOnly is set, and the reachability diff against the current topology.
+// beforeNetworks are the current networks, whose inter-container
+// communication settings still apply to networks the simulation removes.
+func printSimulation(
+	w io.Writer,
+	changes []simulate.Change,
+	state *simulate.State,
+	before map[string][]models.ContainerInfo,
+	beforeNetworks []*models.NetworkInfo,
+	diffOnly bool,
+) error {
+	fmt.Fprintln(w, "=== Simulated Changes ===")
+	for i, c := range changes {
+		fmt.Fprintf(w, "%d. %s\n", i+1, c)
+	}
+	fmt.Fprintln(w)
+
+	after := state.NetworkToContainers()
+
+	if !diffOnly {
+		if err := printTopology(w, state.Networks, state.Containers, after); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintln(w, "=== Reachability Diff ===")
+
+	networks := output.NetworksByName(beforeNetworks)
+	for _, n := range state.Networks {
+		networks[n.Name] = n
+	}
+
+	diff := output.DiffReachability(before, after, networks)
+	if len(diff) == 0 {
+		fmt.Fprintln(w, "No reachability change")
+		return nil
+	}
+
+	for _, change := range diff {
+		output.PrintReachabilityChange(w, change)
+		fmt.Fprintln(w)
+	}
+
+	return nil
+}
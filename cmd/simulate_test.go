@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/simulate"
+)
+
+// TestSimulateCommandExists verifies that the simulate command is properly defined.
+func TestSimulateCommandExists(t *testing.T) {
+	if simulateCmd.Use != "simulate" {
+		t.Errorf("simulate command Use should be 'simulate', got %q", simulateCmd.Use)
+	}
+
+	for _, name := range []string{
+		"changes", "diff-only", "connect", "disconnect", "add-network",
+		"remove-network", "add-container", "remove-container",
+	} {
+		if simulateCmd.Flags().Lookup(name) == nil {
+			t.Errorf("simulate command should have a %s flag", name)
+		}
+	}
+}
+
+// TestRunSimulateWithComposeFile verifies a simulation of a Compose project.
+func TestRunSimulateWithComposeFile(t *testing.T) {
+	dir := t.TempDir()
+	composePath := filepath.Join(dir, "docker-compose.yml")
+	composeContent := `
+services:
+  api:
+    networks: [front, back]
+  web:
+    networks: [front]
+  db:
+    networks: [back]
+networks:
+  front:
+  back:
+`
+	changesPath := filepath.Join(dir, "changes.yml")
+	changesContent := `
+changes:
+  - action: disconnect
+    container: shop-api-1
+    network: shop_front
+  - action: connect
+    container: shop-web-1
+    network: shop_back
+`
+	for path, content := range map[string]string{composePath: composeContent, changesPath: changesContent} {
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("compose-file", []string{composePath})
+	viper.Set("project-name", "shop")
+	viper.Set("simulate.changes", changesPath)
+	viper.Set("simulate.diff-only", true)
+
+	buf := new(bytes.Buffer)
+	simulateCmd.SetOut(buf)
+	defer simulateCmd.SetOut(nil)
+
+	if err := runSimulate(simulateCmd, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"1. disconnect shop-api-1 from shop_front",
+		"2. connect shop-web-1 to shop_back",
+		"=== Reachability Diff ===",
+		"Container: shop-db-1\n└── newly reachable:\n    └── shop-web-1 (via shop_back)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+
+	// api still reaches web through back, so nothing is lost.
+	if strings.Contains(out, "no longer reachable") {
+		t.Errorf("unexpected lost peers:\n%s", out)
+	}
+	if strings.Contains(out, "=== Networks ===") {
+		t.Errorf("--diff-only should not print the trees:\n%s", out)
+	}
+}
+
+// TestRunSimulateErrors verifies errors for missing or invalid changes.
+func TestRunSimulateErrors(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	if err := runSimulate(simulateCmd, nil); err == nil {
+		t.Error("expected error without changes")
+	}
+
+	viper.Set("simulate.changes", filepath.Join(t.TempDir(), "missing.yml"))
+	if err := runSimulate(simulateCmd, nil); err == nil {
+		t.Error("expected error for a missing change file")
+	}
+}
+
+// TestSimulationChangesFromFlags verifies the order of flag changes.
+func TestSimulationChangesFromFlags(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	*simulateFlagChanges[simulate.ActionConnect] = []string{"api:data"}
+	*simulateFlagChanges[simulate.ActionAddNetwork] = []string{"data"}
+	defer func() {
+		*simulateFlagChanges[simulate.ActionConnect] = nil
+		*simulateFlagChanges[simulate.ActionAddNetwork] = nil
+	}()
+
+	changes, err := simulationChanges()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(changes) != 2 || changes[0].Action != simulate.ActionAddNetwork || changes[1].Action != simulate.ActionConnect {
+		t.Errorf("unexpected changes: %+v", changes)
+	}
+}
+
+// TestPrintSimulationNoChange verifies the output when reachability is unchanged.
+func TestPrintSimulationNoChange(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	networks, containers := attachmentTopology().networkInfos(), attachmentTopology().containerMap
+	state := simulate.NewState(networks, containers)
+	changes := []simulate.Change{{Action: simulate.ActionAddNetwork, Network: "spare"}}
+	if err := state.Apply(changes); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := printSimulation(buf, changes, state, attachmentTopology().networkToContainers, nil, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"1. add network spare", "=== Networks ===", "spare", "No reachability change"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+// Package cmd provides the CLI commands for the docker-network-viz tool.
+// This file contains the shared logic for loading the Docker topology.
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/errdefs"
+	"github.com/spf13/viper"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/compose"
+	"git.o.ocom.com.au/go/docker-network-viz/internal/docker"
+	"git.o.ocom.com.au/go/docker-network-viz/internal/lint"
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// topology bundles the Docker state that commands render or analyze.
+type topology struct {
+	// networks holds all networks sorted by name.
+	networks []network.Summary
+
+	// containers holds all containers, including stopped ones, sorted by name.
+	containers []types.Container
+
+	// containerMap maps container names to their network information.
+	containerMap map[string]*models.ContainerInfo
+
+	// networkToContainers maps network names to the containers attached to them.
+	networkToContainers map[string][]models.ContainerInfo
+
+	// addresses maps network names to the endpoint addresses allocated on them.
+	addresses map[string][]string
+}
+
+// fetchTopology fetches networks and containers from the Docker daemon and
+// builds the mappings used by the renderers. When Compose files are given
+// with --compose-file, the topology is built from them instead and the
+// daemon is not contacted.
+func fetchTopology(ctx context.Context, client *docker.Client) (*topology, error) {
+	if usingComposeFiles() {
+		return loadComposeTopology(client, viper.GetStringSlice("compose-file"), viper.GetString("project-name"))
+	}
+
+	return fetchLiveTopology(ctx, client)
+}
+
+// fetchLiveTopology fetches networks and containers from the Docker daemon,
+// regardless of --compose-file.
+func fetchLiveTopology(ctx context.Context, client *docker.Client) (*topology, error) {
+	networks, err := client.FetchNetworks(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch networks: %w", err)
+	}
+
+	containers, err := client.FetchContainers(ctx, &docker.ContainerListOptions{All: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch containers: %w", err)
+	}
+
+	return newTopology(client, networks, containers), nil
+}
+
+// usingComposeFiles reports whether the topology is read from Compose files
+// rather than the Docker daemon.
+func usingComposeFiles() bool {
+	return len(viper.GetStringSlice("compose-file")) > 0
+}
+
+// loadComposeTopology builds the topology that the given Compose files would
+// create, so a stack's network design can be reviewed before it is deployed.
+func loadComposeTopology(client *docker.Client, files []string, projectName string) (*topology, error) {
+	project, err := compose.Load(files, projectName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load compose files: %w", err)
+	}
+
+	networks, containers := project.Resources()
+	topo := newTopology(client, networks, containers)
+	for name, env := range project.Environment() {
+		if c := topo.containerMap[name]; c != nil {
+			c.Env = env
+		}
+	}
+	return topo, nil
+}
+
+// newTopology builds the renderer mappings for the given networks and containers.
+func newTopology(client *docker.Client, networks []network.Summary, containers []types.Container) *topology {
+	return &topology{
+		networks:            networks,
+		containers:          containers,
+		containerMap:        client.BuildContainerMap(containers),
+		networkToContainers: client.BuildNetworkToContainersMap(containers),
+		addresses:           client.BuildNetworkAddressMap(containers),
+	}
+}
+
+// networkInfos converts the topology's networks to internal models, including
+// the endpoint addresses allocated on each network.
+func (t *topology) networkInfos() []*models.NetworkInfo {
+	infos := docker.ConvertNetworksToNetworkInfos(t.networks)
+	for _, info := range infos {
+		info.Addresses = t.addresses[info.Name]
+	}
+	return infos
+}
+
+// lintTopology converts the topology to the form checked by lint rules.
+func (t *topology) lintTopology() *lint.Topology {
+	return &lint.Topology{
+		Networks:            t.networkInfos(),
+		Containers:          t.containerMap,
+		NetworkToContainers: t.networkToContainers,
+	}
+}
+
+// loadEnv inspects every container of a live topology and sets its
+// environment variables, which the container list does not include.
+// Containers removed since the topology was fetched are skipped. Compose
+// topologies are left unchanged, as they take the environment from the
+// Compose files.
+func (t *topology) loadEnv(ctx context.Context, client *docker.Client) error {
+	if usingComposeFiles() {
+		return nil
+	}
+
+	for _, c := range t.containerMap {
+		env, err := client.FetchContainerEnv(ctx, c.ID)
+		if errdefs.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read environment of %s: %w", c.Name, err)
+		}
+		c.Env = env
+	}
+
+	return nil
+}
+
+// loadPortBindings inspects every stopped container of a live topology and
+// adds the host ports it is configured to publish, which the container list
+// reports only while a container runs. Containers removed since the topology
+// was fetched are skipped. Compose topologies are left unchanged, as they
+// take the ports from the Compose files.
+func (t *topology) loadPortBindings(ctx context.Context, client *docker.Client) error {
+	if usingComposeFiles() {
+		return nil
+	}
+
+	for _, c := range t.containerMap {
+		if c.IsActive() {
+			continue
+		}
+		bindings, err := client.FetchContainerPortBindings(ctx, c.ID)
+		if errdefs.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read port bindings of %s: %w", c.Name, err)
+		}
+		for _, p := range bindings {
+			if !slices.Contains(c.Ports, p) {
+				c.Ports = append(c.Ports, p)
+			}
+		}
+	}
+
+	return nil
+}
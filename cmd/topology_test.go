@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/go-connections/nat"
+	"github.com/spf13/viper"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/docker"
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// TestFetchTopologyFromComposeFiles verifies that --compose-file builds the
+// topology from Compose files without contacting the daemon.
+func TestFetchTopologyFromComposeFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "docker-compose.yml")
+	content := `
+services:
+  web:
+    networks: [front]
+  api:
+    networks: [front, back]
+networks:
+  front:
+  back:
+    internal: true
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("compose-file", []string{path})
+	viper.Set("project-name", "shop")
+
+	client, err := docker.NewClient()
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	topo, err := fetchTopology(context.Background(), client)
+	if err != nil {
+		t.Fatalf("fetchTopology returned error: %v", err)
+	}
+
+	if len(topo.networks) != 2 {
+		t.Errorf("expected 2 networks, got %d", len(topo.networks))
+	}
+
+	if got := len(topo.networkToContainers["shop_front"]); got != 2 {
+		t.Errorf("expected 2 containers on shop_front, got %d", got)
+	}
+
+	api := topo.containerMap["shop-api-1"]
+	if api == nil || len(api.Networks) != 2 {
+		t.Errorf("expected shop-api-1 on two networks, got %+v", api)
+	}
+}
+
+// TestFetchTopologyComposeError verifies that Compose load errors are reported.
+func TestFetchTopologyComposeError(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("compose-file", []string{filepath.Join(t.TempDir(), "missing.yml")})
+
+	client, err := docker.NewClient()
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if _, err := fetchTopology(context.Background(), client); err == nil {
+		t.Error("expected error for missing compose file")
+	}
+}
+
+// inspectClient is a Docker API client whose containers can be inspected
+// until they are removed.
+type inspectClient struct {
+	client.APIClient
+
+	// containers maps the IDs of existing containers to their inspect data.
+	containers map[string]types.ContainerJSON
+}
+
+// ContainerInspect implements the ContainerInspect method of the Docker API client.
+func (f *inspectClient) ContainerInspect(_ context.Context, id string) (types.ContainerJSON, error) {
+	cont, ok := f.containers[id]
+	if !ok {
+		return types.ContainerJSON{}, errdefs.NotFound(fmt.Errorf("no such container: %s", id))
+	}
+	return cont, nil
+}
+
+// removedContainerTopology returns a topology of api and a container that
+// was removed after the topology was fetched, with a client for it.
+func removedContainerTopology(t *testing.T) (*topology, *docker.Client) {
+	t.Helper()
+
+	api := models.NewContainerInfo("api")
+	api.ID = "api-id"
+	api.State = "exited"
+	gone := models.NewContainerInfo("gone")
+	gone.ID = "gone-id"
+	gone.State = "exited"
+
+	fake := &inspectClient{containers: map[string]types.ContainerJSON{
+		"api-id": {
+			ContainerJSONBase: &types.ContainerJSONBase{HostConfig: &container.HostConfig{
+				PortBindings: nat.PortMap{"5432/tcp": {{HostIP: "0.0.0.0", HostPort: "5432"}}},
+			}},
+			Config: &container.Config{Env: []string{"DATABASE_URL=postgres://db/shop"}},
+		},
+	}}
+	client, err := docker.NewClient(docker.WithDockerClient(fake))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	return &topology{containerMap: map[string]*models.ContainerInfo{"api": api, "gone": gone}}, client
+}
+
+// TestLoadEnvSkipsRemovedContainers verifies that containers removed since
+// the topology was fetched do not fail loading the environment.
+func TestLoadEnvSkipsRemovedContainers(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	topo, client := removedContainerTopology(t)
+	if err := topo.loadEnv(context.Background(), client); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := topo.containerMap["api"].Env["DATABASE_URL"]; got != "postgres://db/shop" {
+		t.Errorf("expected api's environment, got %v", topo.containerMap["api"].Env)
+	}
+	if topo.containerMap["gone"].Env != nil {
+		t.Errorf("expected no environment for the removed container, got %v", topo.containerMap["gone"].Env)
+	}
+}
+
+// TestLoadPortBindingsSkipsRemovedContainers verifies that containers removed
+// since the topology was fetched do not fail loading the port bindings.
+func TestLoadPortBindingsSkipsRemovedContainers(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	topo, client := removedContainerTopology(t)
+	if err := topo.loadPortBindings(context.Background(), client); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if ports := topo.containerMap["api"].Ports; len(ports) != 1 || ports[0].PublicPort != 5432 {
+		t.Errorf("expected api's port binding, got %+v", ports)
+	}
+	if ports := topo.containerMap["gone"].Ports; len(ports) != 0 {
+		t.Errorf("expected no ports for the removed container, got %+v", ports)
+	}
+}
@@ -0,0 +1,116 @@
+// Package cmd provides the CLI commands for the docker-network-viz tool.
+// This file contains the verify command which detects drift from Compose files.
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/compose"
+	"git.o.ocom.com.au/go/docker-network-viz/internal/output"
+)
+
+var (
+	// verifyComposeFiles are the Compose files describing the intended topology.
+	verifyComposeFiles []string
+
+	// verifyCmd represents the verify command.
+	verifyCmd = &cobra.Command{
+		Use:   "verify",
+		Short: "Compare a Compose project's declared topology with the running stack",
+		Long: `Compare the networks, network attachments and aliases declared in Compose
+files with the running containers of the same project, and list every
+difference:
+
+- declared networks or containers that do not exist
+- project containers that are not declared
+- missing attachments and extra attachments (e.g. a manual "docker network connect")
+- aliases that were added or removed on a network
+
+The command exits with an error when any drift is found, so it can gate
+deployments or run in a scheduled job.
+
+Examples:
+  # Check the stack defined in the current directory
+  docker-network-viz verify --compose docker-compose.yml
+
+  # Check a stack deployed with an override file and explicit project name
+  docker-network-viz verify --compose docker-compose.yml --compose docker-compose.prod.yml --project-name shop`,
+		Args: cobra.NoArgs,
+		RunE: runVerify,
+	}
+)
+
+func init() {
+	// Add verify command to root
+	rootCmd.AddCommand(verifyCmd)
+
+	// Local flags for verify command
+	verifyCmd.Flags().StringSliceVar(&verifyComposeFiles, "compose", nil,
+		"Compose files describing the intended topology (repeatable; defaults to --compose-file)")
+
+	// Bind flags to viper
+	_ = viper.BindPFlag("verify.compose", verifyCmd.Flags().Lookup("compose"))
+}
+
+// runVerify executes the verify command logic.
+func runVerify(cmd *cobra.Command, _ []string) error {
+	ctx := context.Background()
+
+	files := viper.GetStringSlice("verify.compose")
+	if len(files) == 0 {
+		files = viper.GetStringSlice("compose-file")
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no compose files specified: use --compose")
+	}
+
+	project, err := compose.Load(files, viper.GetString("project-name"))
+	if err != nil {
+		return fmt.Errorf("failed to load compose files: %w", err)
+	}
+
+	// Initialize Docker client
+	client, err := newDockerClient()
+	if err != nil {
+		return fmt.Errorf("failed to create Docker client: %w", err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	// The running stack is always read from the daemon.
+	topo, err := fetchLiveTopology(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	drifts := compose.Verify(project, topo.networks, topo.containers)
+	printDrift(cmd.OutOrStdout(), project.Name, drifts)
+
+	if len(drifts) > 0 {
+		return fmt.Errorf("project %s has drifted from its compose files: %d difference(s)", project.Name, len(drifts))
+	}
+
+	return nil
+}
+
+// printDrift prints the drift report for a Compose project.
+func printDrift(w io.Writer, projectName string, drifts []compose.Drift) {
+	cw := output.NewColorWriter(w)
+
+	fmt.Fprintf(w, "=== Compose Drift: %s ===\n", projectName)
+
+	if len(drifts) == 0 {
+		fmt.Fprintln(w, "Running stack matches the compose files")
+		return
+	}
+
+	for _, d := range drifts {
+		fmt.Fprintf(w, "%s %s\n", cw.Warning(fmt.Sprintf("%-20s", d.Kind)), d)
+	}
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/compose"
+)
+
+// TestVerifyCommandExists verifies that the verify command is properly defined.
+func TestVerifyCommandExists(t *testing.T) {
+	if verifyCmd == nil {
+		t.Fatal("verify command should not be nil")
+	}
+
+	if verifyCmd.Use != "verify" {
+		t.Errorf("verify command Use should be 'verify', got %q", verifyCmd.Use)
+	}
+
+	if verifyCmd.Flags().Lookup("compose") == nil {
+		t.Error("verify command should have a compose flag")
+	}
+}
+
+// TestPrintDrift verifies the drift report output.
+func TestPrintDrift(t *testing.T) {
+	buf := new(bytes.Buffer)
+	printDrift(buf, "shop", []compose.Drift{
+		{Kind: compose.DriftExtraAttachment, Container: "shop-api-1", Network: "bridge"},
+	})
+
+	out := buf.String()
+	for _, want := range []string{
+		"=== Compose Drift: shop ===",
+		"extra-attachment",
+		"container shop-api-1 is attached to undeclared network bridge",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+// TestPrintDriftNone verifies the output when the stack matches.
+func TestPrintDriftNone(t *testing.T) {
+	buf := new(bytes.Buffer)
+	printDrift(buf, "shop", nil)
+
+	if !strings.Contains(buf.String(), "Running stack matches the compose files") {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+}
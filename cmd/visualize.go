@@ -27,16 +27,23 @@ var (
 	// noAliases disables the display of container aliases.
 	noAliases bool
 
+	// noGeneratedAliases hides the names Docker generates for every
+	// container, such as the short container ID.
+	noGeneratedAliases bool
+
 	// visualizeCmd represents the visualize command.
 	visualizeCmd = &cobra.Command{
 		Use:   "visualize",
 		Short: "Display Docker network topology",
 		Long: `Visualize Docker network topology in a tree-style format.
 
-This command displays two views:
-1. Network tree: Shows each network with its connected containers and aliases
+This command displays three views:
+1. Network tree: Shows each network with its connected containers and the
+   aliases and DNS names they have on that network
 2. Container reachability: Shows each container with the networks it belongs to
    and other containers it can reach through those networks
+3. Egress: Shows whether each container has a route to the outside world,
+   through which network, and which network provides its default route
 
 Examples:
   # Show all networks and containers
@@ -49,7 +56,10 @@ Examples:
   docker-network-viz visualize --container web_app
 
   # Hide container aliases
-  docker-network-viz visualize --no-aliases`,
+  docker-network-viz visualize --no-aliases
+
+  # Hide generated names such as the short container ID
+  docker-network-viz visualize --no-generated-aliases`,
 		RunE: runVisualize,
 	}
 )
@@ -65,11 +75,14 @@ func init() {
 		"show only the specified container's connectivity")
 	visualizeCmd.Flags().BoolVar(&noAliases, "no-aliases", false,
 		"hide container aliases in the output")
+	visualizeCmd.Flags().BoolVar(&noGeneratedAliases, "no-generated-aliases", false,
+		"hide names Docker generates for every container, such as the short container ID")
 
 	// Bind flags to viper
 	_ = viper.BindPFlag("only-network", visualizeCmd.Flags().Lookup("only-network"))
 	_ = viper.BindPFlag("container", visualizeCmd.Flags().Lookup("container"))
 	_ = viper.BindPFlag("no-aliases", visualizeCmd.Flags().Lookup("no-aliases"))
+	_ = viper.BindPFlag("no-generated-aliases", visualizeCmd.Flags().Lookup("no-generated-aliases"))
 }
 
 // runVisualize executes the visualize command logic.
@@ -79,7 +92,7 @@ func runVisualize(cmd *cobra.Command, _ []string) error {
 	ctx := context.Background()
 
 	// Initialize Docker client
-	client, err := docker.NewClient()
+	client, err := newDockerClient()
 	if err != nil {
 		return fmt.Errorf("failed to create Docker client: %w", err)
 	}
@@ -87,56 +100,69 @@ func runVisualize(cmd *cobra.Command, _ []string) error {
 		_ = client.Close()
 	}()
 
-	// Fetch networks
-	networks, err := client.FetchNetworks(ctx, nil)
-	if err != nil {
-		return fmt.Errorf("failed to fetch networks: %w", err)
-	}
-
-	// Fetch containers
-	containers, err := client.FetchContainers(ctx, &docker.ContainerListOptions{All: true})
+	// Fetch networks and containers and build mappings
+	topo, err := fetchTopology(ctx, client)
 	if err != nil {
-		return fmt.Errorf("failed to fetch containers: %w", err)
+		return err
 	}
 
-	// Build mappings
-	containerMap := client.BuildContainerMap(containers)
-	networkToContainers := client.BuildNetworkToContainersMap(containers)
-
 	// Get output writer
 	writer := cmd.OutOrStdout()
 
 	// Apply filters and print output
-	return printVisualization(writer, networks, containerMap, networkToContainers)
+	return printVisualization(writer, topo.networks, topo.containerMap, topo.networkToContainers, topo.addresses)
 }
 
 // printVisualization handles the actual output of the network topology.
-// It respects the command flags for filtering and formatting.
+// It respects the command flags for filtering and formatting. The addresses
+// map holds the endpoint addresses allocated on each network and is used to
+// report address pool utilization; it may be nil.
 func printVisualization(
 	w io.Writer,
 	networks []network.Summary,
 	containerMap map[string]*models.ContainerInfo,
 	networkToContainers map[string][]models.ContainerInfo,
+	addresses map[string][]string,
+) error {
+	netInfos := docker.ConvertNetworksToNetworkInfos(networks)
+	for _, netInfo := range netInfos {
+		netInfo.Addresses = addresses[netInfo.Name]
+	}
+
+	return printTopology(w, netInfos, containerMap, networkToContainers)
+}
+
+// printTopology prints the network tree, container reachability and egress
+// sections for networks already converted to internal models. It respects the
+// same command flags as printVisualization.
+func printTopology(
+	w io.Writer,
+	networks []*models.NetworkInfo,
+	containerMap map[string]*models.ContainerInfo,
+	networkToContainers map[string][]models.ContainerInfo,
 ) error {
 	onlyNetworkFlag := viper.GetString("only-network")
 	containerFlag := viper.GetString("container")
 	noAliasesFlag := viper.GetBool("no-aliases")
+	noGeneratedAliasesFlag := viper.GetBool("no-generated-aliases")
 
 	// Print network tree section
 	fmt.Fprintln(w, "=== Networks ===")
 
-	for _, net := range networks {
+	for _, netInfo := range networks {
 		// Filter by network name if specified
-		if onlyNetworkFlag != "" && net.Name != onlyNetworkFlag {
+		if onlyNetworkFlag != "" && netInfo.Name != onlyNetworkFlag {
 			continue
 		}
 
-		netInfo := models.NewNetworkInfo(net.Name, net.Driver)
-		netContainers := networkToContainers[net.Name]
+		netContainers := networkToContainers[netInfo.Name]
 
 		// Apply alias filtering if needed
-		if noAliasesFlag {
+		switch {
+		case noAliasesFlag:
 			netContainers = removeAliasesFromContainers(netContainers)
+		case noGeneratedAliasesFlag:
+			netContainers = removeGeneratedAliasesFromContainers(netContainers)
 		}
 
 		output.PrintNetworkTree(w, *netInfo, netContainers)
@@ -146,6 +172,8 @@ func printVisualization(
 	// Print container reachability section
 	fmt.Fprintln(w, "=== Containers (Reachability) ===")
 
+	networksByName := output.NetworksByName(networks)
+
 	// Sort container names for consistent output
 	containerNames := make([]string, 0, len(containerMap))
 	for name := range containerMap {
@@ -160,7 +188,19 @@ func printVisualization(
 		}
 
 		container := containerMap[name]
-		output.PrintContainerTree(w, container, networkToContainers)
+		output.PrintContainerTree(w, container, networkToContainers, networksByName)
+		fmt.Fprintln(w)
+	}
+
+	// Print egress section
+	fmt.Fprintln(w, "=== Egress ===")
+
+	for _, name := range containerNames {
+		if containerFlag != "" && name != containerFlag {
+			continue
+		}
+
+		output.PrintContainerEgress(w, output.ContainerEgressOf(containerMap[name], networksByName))
 		fmt.Fprintln(w)
 	}
 
@@ -172,11 +212,24 @@ func printVisualization(
 func removeAliasesFromContainers(containers []models.ContainerInfo) []models.ContainerInfo {
 	result := make([]models.ContainerInfo, len(containers))
 	for i, c := range containers {
-		result[i] = models.ContainerInfo{
-			Name:     c.Name,
-			Aliases:  []string{}, // Empty aliases
-			Networks: c.Networks,
-		}
+		// c is a copy, so clearing its aliases leaves the input untouched
+		c.Aliases = []string{}
+		c.NetworkAliases = nil
+		c.NetworkDNSNames = nil
+		result[i] = c
+	}
+	return result
+}
+
+// removeGeneratedAliasesFromContainers creates a copy of the container list
+// without the names Docker generates for every container. This is used when
+// the --no-generated-aliases flag is set.
+func removeGeneratedAliasesFromContainers(containers []models.ContainerInfo) []models.ContainerInfo {
+	result := make([]models.ContainerInfo, len(containers))
+	for i, c := range containers {
+		// RemoveGeneratedNames replaces the shared slices and maps of the copy
+		c.RemoveGeneratedNames()
+		result[i] = c
 	}
 	return result
 }
@@ -79,7 +79,7 @@ func TestPrintVisualizationNetworkTree(t *testing.T) {
 	}
 
 	buf := new(bytes.Buffer)
-	err := printVisualization(buf, networks, containerMap, networkToContainers)
+	err := printVisualization(buf, networks, containerMap, networkToContainers, nil)
 
 	if err != nil {
 		t.Errorf("printVisualization should not return error: %v", err)
@@ -106,6 +106,11 @@ func TestPrintVisualizationNetworkTree(t *testing.T) {
 		t.Error("output should contain '=== Containers (Reachability) ===' header")
 	}
 
+	// Verify egress section header
+	if !strings.Contains(output, "=== Egress ===") {
+		t.Error("output should contain '=== Egress ===' header")
+	}
+
 	// Verify containers are listed
 	if !strings.Contains(output, "Container: web") {
 		t.Error("output should contain 'Container: web'")
@@ -141,7 +146,7 @@ func TestPrintVisualizationWithOnlyNetworkFilter(t *testing.T) {
 	}
 
 	buf := new(bytes.Buffer)
-	err := printVisualization(buf, networks, containerMap, networkToContainers)
+	err := printVisualization(buf, networks, containerMap, networkToContainers, nil)
 
 	if err != nil {
 		t.Errorf("printVisualization should not return error: %v", err)
@@ -191,7 +196,7 @@ func TestPrintVisualizationWithContainerFilter(t *testing.T) {
 	}
 
 	buf := new(bytes.Buffer)
-	err := printVisualization(buf, networks, containerMap, networkToContainers)
+	err := printVisualization(buf, networks, containerMap, networkToContainers, nil)
 
 	if err != nil {
 		t.Errorf("printVisualization should not return error: %v", err)
@@ -245,7 +250,7 @@ func TestPrintVisualizationWithNoAliases(t *testing.T) {
 	}
 
 	buf := new(bytes.Buffer)
-	err := printVisualization(buf, networks, containerMap, networkToContainers)
+	err := printVisualization(buf, networks, containerMap, networkToContainers, nil)
 
 	if err != nil {
 		t.Errorf("printVisualization should not return error: %v", err)
@@ -298,6 +303,61 @@ func TestRemoveAliasesFromContainers(t *testing.T) {
 	}
 }
 
+// TestRemoveGeneratedAliasesFromContainers verifies that only generated names are removed.
+func TestRemoveGeneratedAliasesFromContainers(t *testing.T) {
+	web := models.NewContainerInfo("web")
+	web.ID = "3f4e5d6c7b8a9f0e1d2c3b4a"
+	web.AddNetwork("frontend")
+	web.AddNetworkAlias("frontend", "www")
+	web.AddNetworkAlias("frontend", "3f4e5d6c7b8a")
+	web.AddNetworkDNSName("frontend", "3f4e5d6c7b8a")
+	web.AddNetworkDNSName("frontend", "web")
+
+	containers := []models.ContainerInfo{*web}
+	result := removeGeneratedAliasesFromContainers(containers)
+
+	if names := result[0].NamesOn("frontend"); strings.Join(names, ",") != "www" {
+		t.Errorf("expected only www to remain, got %v", names)
+	}
+	if names := containers[0].NamesOn("frontend"); len(names) != 2 {
+		t.Errorf("original container names should not be modified, got %v", names)
+	}
+}
+
+// TestPrintVisualizationPerNetworkAliases verifies that each network lists
+// only the aliases valid on it.
+func TestPrintVisualizationPerNetworkAliases(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	api := models.NewContainerInfo("api")
+	api.AddNetwork("frontend")
+	api.AddNetwork("backend")
+	api.AddNetworkAlias("frontend", "public-api")
+	api.AddNetworkAlias("backend", "internal-api")
+
+	networks := []network.Summary{
+		{Name: "backend", Driver: "bridge"},
+		{Name: "frontend", Driver: "bridge"},
+	}
+	containerMap := map[string]*models.ContainerInfo{"api": api}
+	networkToContainers := map[string][]models.ContainerInfo{
+		"backend":  {*api},
+		"frontend": {*api},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := printVisualization(buf, networks, containerMap, networkToContainers, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := buf.String()
+	backend := out[strings.Index(out, "Network: backend"):strings.Index(out, "Network: frontend")]
+	if !strings.Contains(backend, "alias: internal-api") || strings.Contains(backend, "public-api") {
+		t.Errorf("backend should only list internal-api:\n%s", backend)
+	}
+}
+
 // TestPrintVisualizationEmptyNetworks verifies behavior with no networks.
 func TestPrintVisualizationEmptyNetworks(t *testing.T) {
 	// Reset viper for this test
@@ -308,7 +368,7 @@ func TestPrintVisualizationEmptyNetworks(t *testing.T) {
 	networkToContainers := map[string][]models.ContainerInfo{}
 
 	buf := new(bytes.Buffer)
-	err := printVisualization(buf, networks, containerMap, networkToContainers)
+	err := printVisualization(buf, networks, containerMap, networkToContainers, nil)
 
 	if err != nil {
 		t.Errorf("printVisualization should not return error with empty data: %v", err)
@@ -388,7 +448,7 @@ func TestPrintVisualizationMultipleNetworksPerContainer(t *testing.T) {
 	}
 
 	buf := new(bytes.Buffer)
-	err := printVisualization(buf, networks, containerMap, networkToContainers)
+	err := printVisualization(buf, networks, containerMap, networkToContainers, nil)
 
 	if err != nil {
 		t.Errorf("printVisualization should not return error: %v", err)
@@ -0,0 +1,339 @@
+// Package compose converts between Docker network topology and Docker Compose files.
+package compose
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/network"
+	"go.yaml.in/yaml/v3"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// Labels set by Docker Compose on the resources it creates.
+const (
+	// LabelProject holds the Compose project name.
+	LabelProject = "com.docker.compose.project"
+
+	// LabelService holds the Compose service name of a container.
+	LabelService = "com.docker.compose.service"
+
+	// LabelNetwork holds the key a network was declared with in the Compose file.
+	LabelNetwork = "com.docker.compose.network"
+
+	// labelPrefix is the prefix shared by all Compose-managed labels.
+	labelPrefix = "com.docker.compose."
+)
+
+// ExportOptions controls how live topology is converted to a Compose file.
+type ExportOptions struct {
+	// Project restricts the export to containers and networks of a Compose
+	// project. An empty value exports everything.
+	Project string
+
+	// ExternalNetworks declares the networks as external instead of letting
+	// Compose create them.
+	ExternalNetworks bool
+}
+
+// Export reverse-engineers a Compose file from the live networks and
+// containers. Each container becomes a service, keyed by its Compose service
+// name when it has one and by its container name otherwise. When several
+// Compose projects are exported, Compose services are keyed by project and
+// service, such as "shop_db", so services of the same name in different
+// projects stay apart; see serviceKeys. User-defined
+// networks become top-level networks with their driver, IPAM and flags, and
+// each service lists its network attachments with aliases and static IPs.
+//
+// The predefined "host" and "none" networks are expressed as network_mode,
+// as is the default "bridge" network when it is a container's only network.
+func Export(networks []network.Summary, containers []types.Container, opts ExportOptions) *File {
+	f := &File{
+		Services: make(map[string]*Service),
+		Networks: make(map[string]*Network),
+	}
+
+	keys := networkKeys(networks)
+	names := containerNames(containers)
+	used := make(map[string]bool)
+
+	var exported []types.Container
+	for _, cont := range containers {
+		if opts.Project == "" || cont.Labels[LabelProject] == opts.Project {
+			exported = append(exported, cont)
+		}
+	}
+	services := serviceKeys(exported)
+
+	for _, cont := range exported {
+		key := services[cont.ID]
+		svc, ok := f.Services[key]
+		if !ok {
+			svc = &Service{Image: cont.Image}
+			if cont.Labels[LabelService] == "" {
+				svc.ContainerName = containerName(cont)
+			}
+			f.Services[key] = svc
+		}
+
+		if target, ok := strings.CutPrefix(cont.HostConfig.NetworkMode, "container:"); ok {
+			svc.NetworkMode = "container:" + nameOrID(names, target)
+			continue
+		}
+
+		if cont.NetworkSettings == nil {
+			continue
+		}
+
+		for netName, ep := range cont.NetworkSettings.Networks {
+			switch {
+			case netName == network.NetworkHost || netName == network.NetworkNone:
+				svc.NetworkMode = netName
+				continue
+			case netName == network.NetworkBridge && len(cont.NetworkSettings.Networks) == 1:
+				svc.NetworkMode = netName
+				continue
+			}
+
+			netKey := keys[netName]
+			if netKey == "" {
+				netKey = netName
+			}
+			used[netName] = true
+
+			if svc.Networks == nil {
+				svc.Networks = make(map[string]*ServiceNetwork)
+			}
+			svc.Networks[netKey] = serviceNetwork(cont, serviceName(cont), ep)
+		}
+	}
+
+	for _, net := range networks {
+		if models.IsPredefinedNetwork(net.Name) && !used[net.Name] {
+			continue
+		}
+		if opts.Project != "" && !used[net.Name] && net.Labels[LabelProject] != opts.Project {
+			continue
+		}
+
+		f.Networks[keys[net.Name]] = exportNetwork(net, keys[net.Name], opts.ExternalNetworks)
+	}
+
+	return f
+}
+
+// Write encodes the Compose file as YAML, preceded by a comment explaining
+// where it came from.
+func (f *File) Write(w io.Writer) error {
+	if _, err := fmt.Fprintln(w, "# Generated by docker-network-viz from the live Docker topology."); err != nil {
+		return fmt.Errorf("failed to write compose file: %w", err)
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(f); err != nil {
+		return fmt.Errorf("failed to encode compose file: %w", err)
+	}
+
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("failed to encode compose file: %w", err)
+	}
+
+	return nil
+}
+
+// exportNetwork converts a Docker network to a Compose network definition.
+func exportNetwork(net network.Summary, key string, external bool) *Network {
+	n := &Network{}
+	if key != net.Name {
+		n.Name = net.Name
+	}
+
+	if external || models.IsPredefinedNetwork(net.Name) {
+		n.External = true
+		return n
+	}
+
+	n.Driver = net.Driver
+	n.Internal = net.Internal
+	n.Attachable = net.Attachable
+	n.EnableIPv6 = net.EnableIPv6
+
+	if len(net.Options) > 0 {
+		n.DriverOpts = net.Options
+	}
+
+	for k, v := range net.Labels {
+		if strings.HasPrefix(k, labelPrefix) {
+			continue
+		}
+		if n.Labels == nil {
+			n.Labels = make(map[string]string)
+		}
+		n.Labels[k] = v
+	}
+
+	if len(net.IPAM.Config) > 0 {
+		n.IPAM = &IPAM{}
+		if net.IPAM.Driver != "" && net.IPAM.Driver != "default" {
+			n.IPAM.Driver = net.IPAM.Driver
+		}
+		for _, cfg := range net.IPAM.Config {
+			n.IPAM.Config = append(n.IPAM.Config, IPAMPool{
+				Subnet:       cfg.Subnet,
+				IPRange:      cfg.IPRange,
+				Gateway:      cfg.Gateway,
+				AuxAddresses: cfg.AuxAddress,
+			})
+		}
+	}
+
+	return n
+}
+
+// serviceNetwork converts a container endpoint to a Compose service network
+// attachment. Aliases that Docker generates automatically are omitted.
+func serviceNetwork(cont types.Container, service string, ep *network.EndpointSettings) *ServiceNetwork {
+	sn := &ServiceNetwork{}
+	if ep == nil {
+		return sn
+	}
+
+	name := containerName(cont)
+	for _, alias := range ep.Aliases {
+		if alias == name || alias == service || models.IsShortID(cont.ID, alias) {
+			continue
+		}
+		sn.Aliases = append(sn.Aliases, alias)
+	}
+	sort.Strings(sn.Aliases)
+
+	if ep.IPAMConfig != nil {
+		sn.IPv4Address = ep.IPAMConfig.IPv4Address
+		sn.IPv6Address = ep.IPAMConfig.IPv6Address
+	}
+
+	return sn
+}
+
+// networkKeys assigns each network the key it is declared with in the Compose
+// file: its Compose network label when it has one, otherwise its name. When
+// two networks would share a key, the later one falls back to its name.
+func networkKeys(networks []network.Summary) map[string]string {
+	keys := make(map[string]string, len(networks))
+	taken := make(map[string]bool, len(networks))
+
+	for _, net := range networks {
+		key := net.Labels[LabelNetwork]
+		if key == "" || taken[key] {
+			key = net.Name
+		}
+		keys[net.Name] = key
+		taken[key] = true
+	}
+
+	return keys
+}
+
+// serviceName returns the Compose service name of a container, or its
+// container name when it is not managed by Compose.
+func serviceName(cont types.Container) string {
+	if svc := cont.Labels[LabelService]; svc != "" {
+		return svc
+	}
+	return containerName(cont)
+}
+
+// serviceKeys maps container IDs to the keys of the services they become.
+// Replicas of a Compose service share a key. Services are keyed by their
+// service name, prefixed with the project when containers of several
+// projects are exported. A Compose service whose key is also a container's
+// name, or any key taken by another service, is prefixed with its project,
+// and then numbered, such as "db_2", until it is unique.
+func serviceKeys(containers []types.Container) map[string]string {
+	projects := make(map[string]bool)
+	for _, cont := range containers {
+		if project := cont.Labels[LabelProject]; project != "" && cont.Labels[LabelService] != "" {
+			projects[project] = true
+		}
+	}
+
+	// group identifies the service a container belongs to.
+	group := func(cont types.Container) string {
+		if svc := cont.Labels[LabelService]; svc != "" {
+			return "service\x00" + cont.Labels[LabelProject] + "\x00" + svc
+		}
+		return "container\x00" + containerName(cont)
+	}
+	qualified := func(cont types.Container) string {
+		if project := cont.Labels[LabelProject]; project != "" && cont.Labels[LabelService] != "" {
+			return project + "_" + cont.Labels[LabelService]
+		}
+		return containerName(cont)
+	}
+
+	// Plain containers claim their names first, since container_name must
+	// stay as it is.
+	sorted := append([]types.Container(nil), containers...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Labels[LabelService] == "" && sorted[j].Labels[LabelService] != ""
+	})
+
+	owners := make(map[string]string)
+	groups := make(map[string]string)
+	keys := make(map[string]string, len(containers))
+	for _, cont := range sorted {
+		g := group(cont)
+		if key, ok := groups[g]; ok {
+			keys[cont.ID] = key
+			continue
+		}
+
+		key := serviceName(cont)
+		if len(projects) > 1 {
+			key = qualified(cont)
+		}
+		if owner, taken := owners[key]; taken && owner != g {
+			key = qualified(cont)
+		}
+		for base, n := key, 2; owners[key] != "" && owners[key] != g; n++ {
+			key = fmt.Sprintf("%s_%d", base, n)
+		}
+
+		owners[key] = g
+		groups[g] = key
+		keys[cont.ID] = key
+	}
+
+	return keys
+}
+
+// containerNames maps container IDs to container names.
+func containerNames(containers []types.Container) map[string]string {
+	names := make(map[string]string, len(containers))
+	for _, cont := range containers {
+		names[cont.ID] = containerName(cont)
+	}
+	return names
+}
+
+// nameOrID resolves a container ID to its name, returning the ID unchanged
+// when it is unknown.
+func nameOrID(names map[string]string, id string) string {
+	if name, ok := names[id]; ok {
+		return name
+	}
+	return id
+}
+
+// containerName returns the container name without the leading slash.
+func containerName(cont types.Container) string {
+	if len(cont.Names) == 0 {
+		return ""
+	}
+	return strings.TrimPrefix(cont.Names[0], "/")
+}
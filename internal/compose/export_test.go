@@ -0,0 +1,271 @@
+package compose
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/network"
+)
+
+// testContainer creates a container attached to the given networks.
+func testContainer(name string, labels map[string]string, networks map[string]*network.EndpointSettings) types.Container {
+	return types.Container{
+		ID:              name + "0123456789abcdef",
+		Names:           []string{"/" + name},
+		Image:           name + ":latest",
+		Labels:          labels,
+		NetworkSettings: &types.SummaryNetworkSettings{Networks: networks},
+	}
+}
+
+func TestExport(t *testing.T) {
+	networks := []network.Summary{
+		{Name: "bridge", Driver: "bridge"},
+		{Name: "podman", Driver: "bridge"},
+		{
+			Name:     "shop_backend",
+			Driver:   "bridge",
+			Internal: true,
+			Labels:   map[string]string{LabelProject: "shop", LabelNetwork: "backend", "team": "payments"},
+			IPAM: network.IPAM{Driver: "default", Config: []network.IPAMConfig{
+				{Subnet: "172.28.0.0/24", Gateway: "172.28.0.1"},
+			}},
+		},
+		{Name: "adhoc", Driver: "bridge"},
+		{Name: "unused", Driver: "overlay", Attachable: true},
+	}
+
+	api := testContainer("shop-api-1", map[string]string{LabelProject: "shop", LabelService: "api"},
+		map[string]*network.EndpointSettings{
+			"shop_backend": {
+				Aliases:    []string{"shop-api-1", "api", "shop-api-101", "payments-api"},
+				IPAMConfig: &network.EndpointIPAMConfig{IPv4Address: "172.28.0.10"},
+			},
+			"adhoc": {Aliases: []string{"legacy-api"}},
+		})
+	legacy := testContainer("legacy", nil, map[string]*network.EndpointSettings{
+		"bridge": {},
+	})
+	metricsCont := testContainer("node-exporter", nil, map[string]*network.EndpointSettings{
+		"host": {},
+	})
+
+	f := Export(networks, []types.Container{api, legacy, metricsCont}, ExportOptions{})
+
+	svc := f.Services["api"]
+	if svc == nil {
+		t.Fatal("expected service keyed by compose service name")
+	}
+	if svc.ContainerName != "" {
+		t.Errorf("compose-managed service should not set container_name, got %q", svc.ContainerName)
+	}
+
+	backend := svc.Networks["backend"]
+	if backend == nil {
+		t.Fatalf("expected attachment keyed by compose network name, got %v", svc.Networks)
+	}
+	if len(backend.Aliases) != 1 || backend.Aliases[0] != "payments-api" {
+		t.Errorf("expected only user aliases, got %v", backend.Aliases)
+	}
+	if backend.IPv4Address != "172.28.0.10" {
+		t.Errorf("expected static IP, got %q", backend.IPv4Address)
+	}
+	if svc.Networks["adhoc"] == nil || svc.Networks["adhoc"].Aliases[0] != "legacy-api" {
+		t.Errorf("expected ad-hoc attachment with alias, got %v", svc.Networks["adhoc"])
+	}
+
+	if f.Services["legacy"].NetworkMode != "bridge" || f.Services["legacy"].ContainerName != "legacy" {
+		t.Errorf("unexpected legacy service: %+v", f.Services["legacy"])
+	}
+	if f.Services["node-exporter"].NetworkMode != "host" {
+		t.Errorf("expected host network mode, got %+v", f.Services["node-exporter"])
+	}
+
+	net := f.Networks["backend"]
+	if net == nil {
+		t.Fatalf("expected backend network, got %v", f.Networks)
+	}
+	if net.Name != "shop_backend" || !net.Internal || net.Driver != "bridge" {
+		t.Errorf("unexpected backend network: %+v", net)
+	}
+	if net.IPAM == nil || net.IPAM.Driver != "" || net.IPAM.Config[0].Subnet != "172.28.0.0/24" {
+		t.Errorf("unexpected IPAM: %+v", net.IPAM)
+	}
+	if len(net.Labels) != 1 || net.Labels["team"] != "payments" {
+		t.Errorf("expected compose labels to be dropped, got %v", net.Labels)
+	}
+
+	for _, name := range []string{"bridge", "podman"} {
+		if _, ok := f.Networks[name]; ok {
+			t.Errorf("unused predefined network %s should not be exported", name)
+		}
+	}
+	if f.Networks["unused"] == nil || !f.Networks["unused"].Attachable {
+		t.Error("user-defined networks without containers should be exported")
+	}
+}
+
+func TestExport_ProjectFilter(t *testing.T) {
+	networks := []network.Summary{
+		{Name: "shop_default", Driver: "bridge", Labels: map[string]string{LabelProject: "shop", LabelNetwork: "default"}},
+		{Name: "blog_default", Driver: "bridge", Labels: map[string]string{LabelProject: "blog", LabelNetwork: "default"}},
+	}
+	containers := []types.Container{
+		testContainer("shop-web-1", map[string]string{LabelProject: "shop", LabelService: "web"},
+			map[string]*network.EndpointSettings{"shop_default": {}}),
+		testContainer("blog-web-1", map[string]string{LabelProject: "blog", LabelService: "web"},
+			map[string]*network.EndpointSettings{"blog_default": {}}),
+	}
+
+	f := Export(networks, containers, ExportOptions{Project: "shop"})
+
+	if len(f.Services) != 1 || len(f.Networks) != 1 {
+		t.Fatalf("expected one service and network, got %d and %d", len(f.Services), len(f.Networks))
+	}
+	if f.Networks["default"] == nil || f.Networks["default"].Name != "shop_default" {
+		t.Errorf("unexpected networks: %v", f.Networks)
+	}
+}
+
+func TestExport_SeveralProjects(t *testing.T) {
+	networks := []network.Summary{
+		{Name: "shop_default", Driver: "bridge", Labels: map[string]string{LabelProject: "shop", LabelNetwork: "default"}},
+		{Name: "blog_default", Driver: "bridge", Labels: map[string]string{LabelProject: "blog", LabelNetwork: "default"}},
+	}
+	containers := []types.Container{
+		testContainer("shop-db-1", map[string]string{LabelProject: "shop", LabelService: "db"},
+			map[string]*network.EndpointSettings{"shop_default": {
+				Aliases:    []string{"db", "orders-db"},
+				IPAMConfig: &network.EndpointIPAMConfig{IPv4Address: "172.30.0.5"},
+			}}),
+		testContainer("blog-db-1", map[string]string{LabelProject: "blog", LabelService: "db"},
+			map[string]*network.EndpointSettings{"blog_default": {Aliases: []string{"db", "posts-db"}}}),
+		testContainer("db", nil, map[string]*network.EndpointSettings{"bridge": {}}),
+	}
+
+	f := Export(networks, containers, ExportOptions{})
+
+	if len(f.Services) != 3 {
+		t.Fatalf("expected 3 services, got %v", f.Services)
+	}
+
+	shop, blog, plain := f.Services["shop_db"], f.Services["blog_db"], f.Services["db"]
+	if shop == nil || blog == nil || plain == nil {
+		t.Fatalf("expected services shop_db, blog_db and db, got %v", f.Services)
+	}
+
+	// Both networks are declared as "default"; the second keeps its name.
+	if sn := shop.Networks["default"]; len(shop.Networks) != 1 || sn == nil ||
+		sn.IPv4Address != "172.30.0.5" || len(sn.Aliases) != 1 || sn.Aliases[0] != "orders-db" {
+		t.Errorf("expected shop_db only on its own network, got %v", shop.Networks)
+	}
+	if sn := blog.Networks["blog_default"]; len(blog.Networks) != 1 || sn == nil ||
+		sn.IPv4Address != "" || len(sn.Aliases) != 1 || sn.Aliases[0] != "posts-db" {
+		t.Errorf("expected blog_db only on its own network, got %v", blog.Networks)
+	}
+
+	if plain.ContainerName != "db" || plain.NetworkMode != "bridge" {
+		t.Errorf("unexpected plain container service: %+v", plain)
+	}
+}
+
+func TestServiceKeys(t *testing.T) {
+	shopDB := testContainer("shop-db-1", map[string]string{LabelProject: "shop", LabelService: "db"}, nil)
+	plainDB := testContainer("db", nil, nil)
+	web := testContainer("shop-web-1", map[string]string{LabelProject: "shop", LabelService: "web"}, nil)
+	taken := testContainer("shop_db", nil, nil)
+
+	tests := []struct {
+		name       string
+		containers []types.Container
+		want       map[string]string
+	}{
+		{
+			name:       "one project",
+			containers: []types.Container{shopDB, web},
+			want:       map[string]string{shopDB.ID: "db", web.ID: "web"},
+		},
+		{
+			name:       "service named like a container",
+			containers: []types.Container{shopDB, plainDB},
+			want:       map[string]string{shopDB.ID: "shop_db", plainDB.ID: "db"},
+		},
+		{
+			name:       "qualified key taken",
+			containers: []types.Container{shopDB, plainDB, taken},
+			want:       map[string]string{shopDB.ID: "shop_db_2", plainDB.ID: "db", taken.ID: "shop_db"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := serviceKeys(tt.containers)
+			for id, want := range tt.want {
+				if got[id] != want {
+					t.Errorf("key of %s = %q, want %q", id, got[id], want)
+				}
+			}
+		})
+	}
+}
+
+func TestExport_ExternalNetworksAndSharedNamespace(t *testing.T) {
+	networks := []network.Summary{{Name: "backend", Driver: "bridge", Internal: true}}
+	db := testContainer("db", nil, map[string]*network.EndpointSettings{"backend": {}})
+	sidecar := testContainer("sidecar", nil, nil)
+	sidecar.HostConfig.NetworkMode = "container:" + db.ID
+
+	f := Export(networks, []types.Container{db, sidecar}, ExportOptions{ExternalNetworks: true})
+
+	if !f.Networks["backend"].External || f.Networks["backend"].Internal {
+		t.Errorf("expected bare external network, got %+v", f.Networks["backend"])
+	}
+	if f.Services["sidecar"].NetworkMode != "container:db" {
+		t.Errorf("expected shared namespace by name, got %q", f.Services["sidecar"].NetworkMode)
+	}
+}
+
+func TestNetworkKeys_Collision(t *testing.T) {
+	keys := networkKeys([]network.Summary{
+		{Name: "a_default", Labels: map[string]string{LabelNetwork: "default"}},
+		{Name: "b_default", Labels: map[string]string{LabelNetwork: "default"}},
+	})
+
+	if keys["a_default"] != "default" || keys["b_default"] != "b_default" {
+		t.Errorf("unexpected keys: %v", keys)
+	}
+}
+
+func TestFile_Write(t *testing.T) {
+	f := &File{
+		Services: map[string]*Service{
+			"web": {Image: "nginx", Networks: map[string]*ServiceNetwork{
+				"frontend": {Aliases: []string{"www"}},
+				"backend":  {},
+			}},
+		},
+		Networks: map[string]*Network{
+			"frontend": {Driver: "bridge"},
+			"backend":  {Driver: "bridge", Internal: true},
+		},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := f.Write(buf); err != nil {
+		t.Fatalf("Write should not return error: %v", err)
+	}
+
+	out := buf.String()
+	expected := []string{
+		"# Generated by docker-network-viz",
+		"services:\n  web:\n    image: nginx\n    networks:\n      backend: {}\n      frontend:\n        aliases:\n          - www\n",
+		"networks:\n  backend:\n    driver: bridge\n    internal: true\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(out, want) {
+			t.Errorf("output should contain %q, got:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,346 @@
+// Package compose converts between Docker network topology and Docker Compose
+// files. It models the subset of the Compose specification that describes
+// networking: top-level networks, each service's network attachments and
+// published ports, the labels reverse proxies route by, and the environment
+// and dependencies that name the services a service talks to.
+package compose
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// File represents the networking-related parts of a docker-compose.yml file.
+type File struct {
+	// Name is the Compose project name, if set in the file.
+	Name string `yaml:"name,omitempty"`
+
+	// Services maps service names to their definitions.
+	Services map[string]*Service `yaml:"services"`
+
+	// Networks maps network keys to their definitions.
+	Networks map[string]*Network `yaml:"networks,omitempty"`
+}
+
+// Service represents a Compose service definition.
+type Service struct {
+	// Image is the image the service runs.
+	Image string `yaml:"image,omitempty"`
+
+	// ContainerName is the fixed container name, if any.
+	ContainerName string `yaml:"container_name,omitempty"`
+
+	// NetworkMode is the network mode, such as "host", "none" or "service:db".
+	NetworkMode string `yaml:"network_mode,omitempty"`
+
+	// Networks maps network keys to the service's attachment settings.
+	Networks ServiceNetworks `yaml:"networks,omitempty"`
+
+	// Links are legacy links in "service" or "service:alias" form.
+	Links []string `yaml:"links,omitempty"`
+
+	// Deploy holds deployment settings; only the replica count is used.
+	Deploy *Deploy `yaml:"deploy,omitempty"`
+
+	// Labels are the labels set on the service's containers.
+	Labels Labels `yaml:"labels,omitempty"`
+
+	// Ports are the ports the service publishes.
+	Ports ServicePorts `yaml:"ports,omitempty"`
+
+	// Environment holds the environment variables set in the service's
+	// containers.
+	Environment Environment `yaml:"environment,omitempty"`
+
+	// DependsOn maps the services this service depends on to the condition
+	// it waits for.
+	DependsOn DependsOn `yaml:"depends_on,omitempty"`
+}
+
+// Labels maps label names to values.
+//
+// Compose allows both a map and a list of "name=value" entries; both forms
+// are accepted when decoding.
+type Labels map[string]string
+
+// UnmarshalYAML decodes either the list or the map form of labels.
+func (l *Labels) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		var entries []string
+		if err := node.Decode(&entries); err != nil {
+			return err
+		}
+		*l = make(Labels, len(entries))
+		for _, entry := range entries {
+			name, value, _ := strings.Cut(entry, "=")
+			(*l)[name] = value
+		}
+		return nil
+	case yaml.MappingNode:
+		m := make(map[string]string)
+		if err := node.Decode(&m); err != nil {
+			return err
+		}
+		*l = m
+		return nil
+	default:
+		return fmt.Errorf("line %d: labels must be a list or a map", node.Line)
+	}
+}
+
+// Environment maps environment variable names to values.
+//
+// Compose allows both a map and a list of "NAME=value" entries; both forms
+// are accepted when decoding. Variables listed without a value, or with a
+// null value, take their value from the environment Compose runs in and are
+// left out when it does not set them.
+type Environment map[string]string
+
+// UnmarshalYAML decodes either the list or the map form of an environment.
+func (e *Environment) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		var entries []string
+		if err := node.Decode(&entries); err != nil {
+			return err
+		}
+		*e = make(Environment, len(entries))
+		for _, entry := range entries {
+			name, value, ok := strings.Cut(entry, "=")
+			e.set(name, value, ok)
+		}
+		return nil
+	case yaml.MappingNode:
+		m := make(map[string]*string)
+		if err := node.Decode(&m); err != nil {
+			return err
+		}
+		*e = make(Environment, len(m))
+		for name, value := range m {
+			if value == nil {
+				e.set(name, "", false)
+				continue
+			}
+			e.set(name, *value, true)
+		}
+		return nil
+	default:
+		return fmt.Errorf("line %d: environment must be a list or a map", node.Line)
+	}
+}
+
+// set stores a variable, looking its value up in the process environment
+// when it has none.
+func (e Environment) set(name, value string, hasValue bool) {
+	if !hasValue {
+		var ok bool
+		if value, ok = os.LookupEnv(name); !ok {
+			return
+		}
+	}
+	e[name] = value
+}
+
+// DefaultDependsOnCondition is the condition of dependencies declared in the
+// short list form.
+const DefaultDependsOnCondition = "service_started"
+
+// DependsOn maps service names to the condition a dependent service waits
+// for, such as "service_started" or "service_healthy".
+//
+// Compose allows both a list of service names and a map of names to
+// settings; both forms are accepted when decoding.
+type DependsOn map[string]string
+
+// UnmarshalYAML decodes either the list or the map form of depends_on.
+func (d *DependsOn) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		var services []string
+		if err := node.Decode(&services); err != nil {
+			return err
+		}
+		*d = make(DependsOn, len(services))
+		for _, svc := range services {
+			(*d)[svc] = DefaultDependsOnCondition
+		}
+		return nil
+	case yaml.MappingNode:
+		m := make(map[string]*struct {
+			Condition string `yaml:"condition"`
+		})
+		if err := node.Decode(&m); err != nil {
+			return err
+		}
+		*d = make(DependsOn, len(m))
+		for svc, settings := range m {
+			condition := DefaultDependsOnCondition
+			if settings != nil && settings.Condition != "" {
+				condition = settings.Condition
+			}
+			(*d)[svc] = condition
+		}
+		return nil
+	default:
+		return fmt.Errorf("line %d: depends_on must be a list or a map", node.Line)
+	}
+}
+
+// Deploy represents the deploy section of a Compose service.
+type Deploy struct {
+	// Replicas is the number of containers to run for the service.
+	Replicas *int `yaml:"replicas,omitempty"`
+}
+
+// ServiceNetworks maps network keys to a service's attachment settings.
+// A nil value means the service is attached with default settings.
+//
+// Compose allows both a list of network keys and a map of keys to settings;
+// both forms are accepted when decoding.
+type ServiceNetworks map[string]*ServiceNetwork
+
+// UnmarshalYAML decodes either the list or the map form of service networks.
+func (sn *ServiceNetworks) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		var keys []string
+		if err := node.Decode(&keys); err != nil {
+			return err
+		}
+		*sn = make(ServiceNetworks, len(keys))
+		for _, key := range keys {
+			(*sn)[key] = nil
+		}
+		return nil
+	case yaml.MappingNode:
+		m := make(map[string]*ServiceNetwork)
+		if err := node.Decode(&m); err != nil {
+			return err
+		}
+		*sn = m
+		return nil
+	default:
+		return fmt.Errorf("line %d: service networks must be a list or a map", node.Line)
+	}
+}
+
+// ServiceNetwork represents a service's attachment to a single network.
+type ServiceNetwork struct {
+	// Aliases are additional DNS names for the service on the network.
+	Aliases []string `yaml:"aliases,omitempty"`
+
+	// IPv4Address is a static IPv4 address on the network.
+	IPv4Address string `yaml:"ipv4_address,omitempty"`
+
+	// IPv6Address is a static IPv6 address on the network.
+	IPv6Address string `yaml:"ipv6_address,omitempty"`
+}
+
+// Network represents a top-level Compose network definition.
+type Network struct {
+	// Name is the actual network name when it differs from the key.
+	Name string `yaml:"name,omitempty"`
+
+	// Driver is the network driver.
+	Driver string `yaml:"driver,omitempty"`
+
+	// DriverOpts are driver-specific options.
+	DriverOpts map[string]string `yaml:"driver_opts,omitempty"`
+
+	// External marks a network that is created outside of Compose.
+	External bool `yaml:"external,omitempty"`
+
+	// Internal restricts external access to the network.
+	Internal bool `yaml:"internal,omitempty"`
+
+	// Attachable allows standalone containers to attach to the network.
+	Attachable bool `yaml:"attachable,omitempty"`
+
+	// EnableIPv6 enables IPv6 on the network.
+	EnableIPv6 bool `yaml:"enable_ipv6,omitempty"`
+
+	// IPAM holds the network's address management configuration.
+	IPAM *IPAM `yaml:"ipam,omitempty"`
+
+	// Labels are metadata labels for the network.
+	Labels map[string]string `yaml:"labels,omitempty"`
+}
+
+// UnmarshalYAML decodes a network definition, accepting the legacy
+// "external: {name: ...}" form in addition to "external: true".
+func (n *Network) UnmarshalYAML(node *yaml.Node) error {
+	// networkFields has the same fields as Network without its methods,
+	// so decoding into it does not recurse.
+	type networkFields Network
+
+	if node.Kind != yaml.MappingNode {
+		return node.Decode((*networkFields)(n))
+	}
+
+	// Decode everything except "external", which is handled separately.
+	rest := *node
+	rest.Content = nil
+	var external *yaml.Node
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == "external" {
+			external = node.Content[i+1]
+			continue
+		}
+		rest.Content = append(rest.Content, node.Content[i], node.Content[i+1])
+	}
+
+	if err := rest.Decode((*networkFields)(n)); err != nil {
+		return err
+	}
+
+	if external == nil {
+		return nil
+	}
+
+	switch external.Kind {
+	case yaml.ScalarNode:
+		return external.Decode(&n.External)
+	case yaml.MappingNode:
+		var legacy struct {
+			Name string `yaml:"name"`
+		}
+		if err := external.Decode(&legacy); err != nil {
+			return err
+		}
+		n.External = true
+		if legacy.Name != "" {
+			n.Name = legacy.Name
+		}
+		return nil
+	default:
+		return fmt.Errorf("line %d: external must be a boolean or a map", external.Line)
+	}
+}
+
+// IPAM represents a Compose network's address management configuration.
+type IPAM struct {
+	// Driver is the IPAM driver.
+	Driver string `yaml:"driver,omitempty"`
+
+	// Config holds the address pools.
+	Config []IPAMPool `yaml:"config,omitempty"`
+}
+
+// IPAMPool represents a single Compose IPAM pool.
+type IPAMPool struct {
+	// Subnet is the pool's CIDR.
+	Subnet string `yaml:"subnet,omitempty"`
+
+	// IPRange restricts dynamic allocation to a sub-range of Subnet.
+	IPRange string `yaml:"ip_range,omitempty"`
+
+	// Gateway is the gateway address.
+	Gateway string `yaml:"gateway,omitempty"`
+
+	// AuxAddresses are reserved addresses keyed by hostname.
+	AuxAddresses map[string]string `yaml:"aux_addresses,omitempty"`
+}
@@ -0,0 +1,173 @@
+package compose
+
+import (
+	"reflect"
+	"testing"
+
+	"go.yaml.in/yaml/v3"
+)
+
+func TestServiceNetworksListAndMap(t *testing.T) {
+	var f File
+	err := yaml.Unmarshal([]byte(`
+services:
+  list:
+    networks: [front, back]
+  map:
+    networks:
+      front:
+      back:
+        aliases: [b]
+`), &f)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, svc := range []string{"list", "map"} {
+		nets := f.Services[svc].Networks
+		if _, ok := nets["front"]; !ok || len(nets) != 2 {
+			t.Errorf("%s: expected front and back networks, got %v", svc, nets)
+		}
+	}
+
+	if sn := f.Services["map"].Networks["back"]; sn == nil || len(sn.Aliases) != 1 {
+		t.Errorf("expected aliases on back network, got %+v", sn)
+	}
+
+	var bad File
+	if err := yaml.Unmarshal([]byte("services:\n  x:\n    networks: front\n"), &bad); err == nil {
+		t.Error("expected error for scalar networks")
+	}
+}
+
+func TestLabelsListAndMap(t *testing.T) {
+	var f File
+	err := yaml.Unmarshal([]byte(`
+services:
+  list:
+    labels:
+      - traefik.enable=true
+      - empty
+  map:
+    labels:
+      traefik.enable: "true"
+`), &f)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, svc := range []string{"list", "map"} {
+		if got := f.Services[svc].Labels["traefik.enable"]; got != "true" {
+			t.Errorf("%s: expected traefik.enable=true, got %q", svc, got)
+		}
+	}
+	if value, ok := f.Services["list"].Labels["empty"]; !ok || value != "" {
+		t.Errorf("expected empty label, got %q (%v)", value, ok)
+	}
+
+	var bad File
+	if err := yaml.Unmarshal([]byte("services:\n  x:\n    labels: yes\n"), &bad); err == nil {
+		t.Error("expected error for scalar labels")
+	}
+}
+
+func TestEnvironmentListAndMap(t *testing.T) {
+	t.Setenv("DNV_TEST_FROM_SHELL", "shell")
+
+	var f File
+	err := yaml.Unmarshal([]byte(`
+services:
+  list:
+    environment:
+      - DB_HOST=db
+      - DNV_TEST_FROM_SHELL
+      - DNV_TEST_UNSET
+  map:
+    environment:
+      DB_HOST: db
+      PORT: 5432
+      DNV_TEST_FROM_SHELL:
+      DNV_TEST_UNSET:
+`), &f)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, svc := range []string{"list", "map"} {
+		env := f.Services[svc].Environment
+		if env["DB_HOST"] != "db" {
+			t.Errorf("%s: expected DB_HOST=db, got %q", svc, env["DB_HOST"])
+		}
+		if env["DNV_TEST_FROM_SHELL"] != "shell" {
+			t.Errorf("%s: expected value from the environment, got %q", svc, env["DNV_TEST_FROM_SHELL"])
+		}
+		if _, ok := env["DNV_TEST_UNSET"]; ok {
+			t.Errorf("%s: expected unset variable to be left out", svc)
+		}
+	}
+	if got := f.Services["map"].Environment["PORT"]; got != "5432" {
+		t.Errorf("expected PORT=5432, got %q", got)
+	}
+
+	var bad File
+	if err := yaml.Unmarshal([]byte("services:\n  x:\n    environment: yes\n"), &bad); err == nil {
+		t.Error("expected error for scalar environment")
+	}
+}
+
+func TestDependsOnListAndMap(t *testing.T) {
+	var f File
+	err := yaml.Unmarshal([]byte(`
+services:
+  list:
+    depends_on: [db, cache]
+  map:
+    depends_on:
+      db:
+        condition: service_healthy
+      cache: {}
+`), &f)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := (DependsOn{"db": DefaultDependsOnCondition, "cache": DefaultDependsOnCondition}); !reflect.DeepEqual(f.Services["list"].DependsOn, want) {
+		t.Errorf("expected %v, got %v", want, f.Services["list"].DependsOn)
+	}
+	if want := (DependsOn{"db": "service_healthy", "cache": DefaultDependsOnCondition}); !reflect.DeepEqual(f.Services["map"].DependsOn, want) {
+		t.Errorf("expected %v, got %v", want, f.Services["map"].DependsOn)
+	}
+
+	var bad File
+	if err := yaml.Unmarshal([]byte("services:\n  x:\n    depends_on: db\n"), &bad); err == nil {
+		t.Error("expected error for scalar depends_on")
+	}
+}
+
+func TestNetworkExternalForms(t *testing.T) {
+	var f File
+	err := yaml.Unmarshal([]byte(`
+networks:
+  modern:
+    external: true
+    name: shared
+  legacy:
+    external:
+      name: old_shared
+  local:
+    driver: overlay
+`), &f)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if n := f.Networks["modern"]; !n.External || n.Name != "shared" {
+		t.Errorf("unexpected modern network: %+v", n)
+	}
+	if n := f.Networks["legacy"]; !n.External || n.Name != "old_shared" {
+		t.Errorf("unexpected legacy network: %+v", n)
+	}
+	if n := f.Networks["local"]; n.External || n.Driver != "overlay" {
+		t.Errorf("unexpected local network: %+v", n)
+	}
+}
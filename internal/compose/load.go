@@ -0,0 +1,143 @@
+// Package compose converts between Docker network topology and Docker Compose files.
+package compose
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// projectNameEnv is the environment variable Compose reads the project name from.
+const projectNameEnv = "COMPOSE_PROJECT_NAME"
+
+// Project is a loaded Compose project: the merged file contents and the
+// project name used to derive resource names.
+type Project struct {
+	// Name is the normalized project name.
+	Name string
+
+	// File is the merged content of all loaded Compose files.
+	File *File
+}
+
+// Load reads and merges one or more Compose files, in the same way as
+// "docker compose -f a.yml -f b.yml": later files override earlier ones.
+// Environment variables are interpolated before parsing.
+//
+// The project name is taken, in order of precedence, from the name argument,
+// the COMPOSE_PROJECT_NAME environment variable, the top-level "name" of the
+// files, or the name of the directory containing the first file.
+func Load(paths []string, name string) (*Project, error) {
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no compose files specified")
+	}
+
+	merged := map[string]any{}
+	for _, path := range paths {
+		data, err := os.ReadFile(path) // #nosec G304 -- path is supplied by the user
+		if err != nil {
+			return nil, fmt.Errorf("failed to read compose file %s: %w", path, err)
+		}
+
+		var doc map[string]any
+		if err := yaml.Unmarshal([]byte(interpolate(string(data), os.LookupEnv)), &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse compose file %s: %w", path, err)
+		}
+
+		merged = mergeMaps(merged, doc)
+	}
+
+	// Round-trip the merged document through YAML to decode it into the model.
+	data, err := yaml.Marshal(merged)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge compose files: %w", err)
+	}
+
+	file := &File{}
+	if err := yaml.Unmarshal(data, file); err != nil {
+		return nil, fmt.Errorf("failed to decode compose files: %w", err)
+	}
+
+	return &Project{
+		Name: projectName(name, file, paths[0]),
+		File: file,
+	}, nil
+}
+
+// projectName determines the project name for a set of Compose files.
+func projectName(name string, file *File, firstPath string) string {
+	if name == "" {
+		name = os.Getenv(projectNameEnv)
+	}
+	if name == "" {
+		name = file.Name
+	}
+	if name == "" {
+		if abs, err := filepath.Abs(firstPath); err == nil {
+			name = filepath.Base(filepath.Dir(abs))
+		}
+	}
+	return NormalizeProjectName(name)
+}
+
+// invalidProjectChars matches characters Compose strips from project names.
+var invalidProjectChars = regexp.MustCompile(`[^a-z0-9_-]`)
+
+// NormalizeProjectName lower-cases a project name and removes the characters
+// Compose does not allow, matching the names Compose gives its resources.
+func NormalizeProjectName(name string) string {
+	return invalidProjectChars.ReplaceAllString(strings.ToLower(name), "")
+}
+
+// mergeMaps deep-merges src into dst. Nested maps are merged recursively;
+// any other value in src replaces the value in dst.
+func mergeMaps(dst, src map[string]any) map[string]any {
+	for key, srcVal := range src {
+		srcMap, srcIsMap := srcVal.(map[string]any)
+		dstMap, dstIsMap := dst[key].(map[string]any)
+		if srcIsMap && dstIsMap {
+			dst[key] = mergeMaps(dstMap, srcMap)
+			continue
+		}
+		dst[key] = srcVal
+	}
+	return dst
+}
+
+// variablePattern matches "$$", "${VAR}", "${VAR:-default}", "${VAR-default}" and "$VAR".
+var variablePattern = regexp.MustCompile(`\$\$|\$\{([A-Za-z_][A-Za-z0-9_]*)(?:(:?-)([^}]*))?\}|\$([A-Za-z_][A-Za-z0-9_]*)`)
+
+// interpolate substitutes environment variables in Compose file content.
+// "$$" is an escaped dollar sign. With ":-" the default is used when the
+// variable is unset or empty; with "-" only when it is unset.
+func interpolate(content string, lookup func(string) (string, bool)) string {
+	return variablePattern.ReplaceAllStringFunc(content, func(match string) string {
+		if match == "$$" {
+			return "$"
+		}
+
+		parts := variablePattern.FindStringSubmatch(match)
+		name := parts[1]
+		if name == "" {
+			name = parts[4]
+		}
+
+		value, ok := lookup(name)
+		switch parts[2] {
+		case ":-":
+			if !ok || value == "" {
+				return parts[3]
+			}
+		case "-":
+			if !ok {
+				return parts[3]
+			}
+		}
+
+		return value
+	})
+}
@@ -0,0 +1,144 @@
+package compose
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeFile writes a test Compose file and returns its path.
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestLoadMergesFiles(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "My.Shop")
+	if err := os.Mkdir(dir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(projectNameEnv, "")
+	t.Setenv("API_TAG", "2.1")
+
+	base := writeFile(t, dir, "docker-compose.yml", `
+services:
+  api:
+    image: shop/api:${API_TAG}
+    networks: [backend]
+  db:
+    image: postgres:${PG_TAG:-16}
+    networks:
+      backend:
+        aliases: [database]
+networks:
+  backend:
+    internal: true
+`)
+	override := writeFile(t, dir, "docker-compose.override.yml", `
+services:
+  api:
+    networks:
+      backend:
+        ipv4_address: 172.30.0.10
+networks:
+  backend:
+    ipam:
+      config:
+        - subnet: 172.30.0.0/24
+`)
+
+	project, err := Load([]string{base, override}, "")
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	if project.Name != "myshop" {
+		t.Errorf("expected project name from directory, got %q", project.Name)
+	}
+
+	api := project.File.Services["api"]
+	if api.Image != "shop/api:2.1" {
+		t.Errorf("expected interpolated image, got %q", api.Image)
+	}
+	if sn := api.Networks["backend"]; sn == nil || sn.IPv4Address != "172.30.0.10" {
+		t.Errorf("expected override to set static address, got %+v", sn)
+	}
+
+	if db := project.File.Services["db"]; db.Image != "postgres:16" {
+		t.Errorf("expected default image tag, got %q", db.Image)
+	}
+
+	backend := project.File.Networks["backend"]
+	if !backend.Internal {
+		t.Error("expected internal flag from base file to survive merge")
+	}
+	if backend.IPAM == nil || len(backend.IPAM.Config) != 1 {
+		t.Errorf("expected IPAM from override file, got %+v", backend.IPAM)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	if _, err := Load(nil, ""); err == nil {
+		t.Error("expected error for no files")
+	}
+
+	if _, err := Load([]string{filepath.Join(t.TempDir(), "missing.yml")}, ""); err == nil {
+		t.Error("expected error for missing file")
+	}
+
+	bad := writeFile(t, t.TempDir(), "bad.yml", "services: [\n")
+	if _, err := Load([]string{bad}, ""); err == nil {
+		t.Error("expected error for invalid YAML")
+	}
+}
+
+func TestProjectNamePrecedence(t *testing.T) {
+	file := &File{Name: "from-file"}
+
+	t.Setenv(projectNameEnv, "")
+	if got := projectName("", file, "/srv/stack/docker-compose.yml"); got != "from-file" {
+		t.Errorf("expected file name, got %q", got)
+	}
+	if got := projectName("", &File{}, "/srv/stack/docker-compose.yml"); got != "stack" {
+		t.Errorf("expected directory name, got %q", got)
+	}
+
+	t.Setenv(projectNameEnv, "From_Env")
+	if got := projectName("", file, ""); got != "from_env" {
+		t.Errorf("expected environment name, got %q", got)
+	}
+	if got := projectName("Flag", file, ""); got != "flag" {
+		t.Errorf("expected flag name, got %q", got)
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	env := map[string]string{"SET": "value", "EMPTY": ""}
+	lookup := func(name string) (string, bool) {
+		v, ok := env[name]
+		return v, ok
+	}
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"$SET and ${SET}", "value and value"},
+		{"${UNSET:-def}", "def"},
+		{"${EMPTY:-def}", "def"},
+		{"${EMPTY-def}", ""},
+		{"${UNSET-def}", "def"},
+		{"${UNSET}", ""},
+		{"cost: $$5", "cost: $5"},
+	}
+
+	for _, tt := range tests {
+		if got := interpolate(tt.in, lookup); got != tt.want {
+			t.Errorf("interpolate(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+// Package compose converts between Docker network topology and Docker Compose files.
+// This file contains the service port definitions.
+package compose
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// defaultProtocol is the protocol of ports that do not name one.
+const defaultProtocol = "tcp"
+
+// ServicePorts lists the ports a service publishes.
+//
+// Compose allows each entry in the short "[host_ip:][published:]target[/protocol]"
+// syntax, where both port numbers may be ranges, or in the long syntax as a
+// map; both forms are accepted when decoding. Ranges are expanded to one
+// entry per port.
+type ServicePorts []ServicePort
+
+// ServicePort is a single container port and its host mapping.
+type ServicePort struct {
+	// Target is the port inside the container.
+	Target uint16 `yaml:"target"`
+
+	// Published is the host port; zero when Docker picks a random one.
+	Published uint16 `yaml:"published,omitempty"`
+
+	// HostIP is the host address the port is published on; empty for all.
+	HostIP string `yaml:"host_ip,omitempty"`
+
+	// Protocol is "tcp", "udp" or "sctp".
+	Protocol string `yaml:"protocol,omitempty"`
+}
+
+// UnmarshalYAML decodes a list of ports in the short or long syntax.
+func (sp *ServicePorts) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.SequenceNode {
+		return fmt.Errorf("line %d: ports must be a list", node.Line)
+	}
+
+	var ports ServicePorts
+	for _, item := range node.Content {
+		switch item.Kind {
+		case yaml.ScalarNode:
+			parsed, err := ParsePort(item.Value)
+			if err != nil {
+				return fmt.Errorf("line %d: %w", item.Line, err)
+			}
+			ports = append(ports, parsed...)
+		case yaml.MappingNode:
+			var long struct {
+				Target    uint16 `yaml:"target"`
+				Published string `yaml:"published"`
+				HostIP    string `yaml:"host_ip"`
+				Protocol  string `yaml:"protocol"`
+			}
+			if err := item.Decode(&long); err != nil {
+				return err
+			}
+			published, err := parsePortNumber(long.Published, true)
+			if err != nil {
+				return fmt.Errorf("line %d: %w", item.Line, err)
+			}
+			p := ServicePort{Target: long.Target, Published: published, HostIP: long.HostIP, Protocol: long.Protocol}
+			if p.Protocol == "" {
+				p.Protocol = defaultProtocol
+			}
+			ports = append(ports, p)
+		default:
+			return fmt.Errorf("line %d: a port must be a string or a map", item.Line)
+		}
+	}
+
+	*sp = ports
+	return nil
+}
+
+// ParsePort parses a port in the Compose short syntax, such as "80",
+// "8080:80", "127.0.0.1:8080:80/udp", "[::1]:8080:80" or "8000-8001:80-81".
+func ParsePort(spec string) ([]ServicePort, error) {
+	rest, protocol, hasProtocol := strings.Cut(spec, "/")
+	if !hasProtocol {
+		protocol = defaultProtocol
+	}
+
+	var hostIP string
+	if strings.HasPrefix(rest, "[") {
+		end := strings.Index(rest, "]:")
+		if end < 0 {
+			return nil, fmt.Errorf("invalid port %q", spec)
+		}
+		hostIP, rest = rest[1:end], rest[end+2:]
+	}
+
+	parts := strings.Split(rest, ":")
+	var published, target string
+	switch {
+	case len(parts) == 1:
+		target = parts[0]
+	case len(parts) == 2:
+		published, target = parts[0], parts[1]
+	case len(parts) == 3 && hostIP == "":
+		hostIP, published, target = parts[0], parts[1], parts[2]
+	default:
+		return nil, fmt.Errorf("invalid port %q", spec)
+	}
+
+	targetStart, targetEnd, err := parsePortRange(target, false)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port %q: %w", spec, err)
+	}
+	publishedStart, publishedEnd, err := parsePortRange(published, true)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port %q: %w", spec, err)
+	}
+
+	count := int(targetEnd-targetStart) + 1
+	if publishedStart != 0 && int(publishedEnd-publishedStart)+1 != count {
+		return nil, fmt.Errorf("invalid port %q: published and target ranges differ in size", spec)
+	}
+
+	ports := make([]ServicePort, 0, count)
+	for i := 0; i < count; i++ {
+		p := ServicePort{Target: targetStart + uint16(i), HostIP: hostIP, Protocol: protocol}
+		if publishedStart != 0 {
+			p.Published = publishedStart + uint16(i)
+		}
+		ports = append(ports, p)
+	}
+	return ports, nil
+}
+
+// parsePortRange parses a port or a "start-end" range. An empty value is
+// allowed when optional is set and returns zeros.
+func parsePortRange(value string, optional bool) (uint16, uint16, error) {
+	startValue, endValue, isRange := strings.Cut(value, "-")
+
+	start, err := parsePortNumber(startValue, optional && !isRange)
+	if err != nil {
+		return 0, 0, err
+	}
+	if !isRange {
+		return start, start, nil
+	}
+
+	end, err := parsePortNumber(endValue, false)
+	if err != nil {
+		return 0, 0, err
+	}
+	if end < start {
+		return 0, 0, fmt.Errorf("range %q ends before it starts", value)
+	}
+	return start, end, nil
+}
+
+// parsePortNumber parses a port number. An empty value is allowed when
+// optional is set and returns zero.
+func parsePortNumber(value string, optional bool) (uint16, error) {
+	if value == "" && optional {
+		return 0, nil
+	}
+	port, err := strconv.ParseUint(value, 10, 16)
+	if err != nil || port == 0 {
+		return 0, fmt.Errorf("invalid port number %q", value)
+	}
+	return uint16(port), nil
+}
@@ -0,0 +1,82 @@
+package compose
+
+import (
+	"reflect"
+	"testing"
+
+	"go.yaml.in/yaml/v3"
+)
+
+func TestParsePort(t *testing.T) {
+	tests := []struct {
+		spec string
+		want []ServicePort
+	}{
+		{"80", []ServicePort{{Target: 80, Protocol: "tcp"}}},
+		{"8080:80", []ServicePort{{Target: 80, Published: 8080, Protocol: "tcp"}}},
+		{"53:53/udp", []ServicePort{{Target: 53, Published: 53, Protocol: "udp"}}},
+		{"127.0.0.1:5432:5432", []ServicePort{{Target: 5432, Published: 5432, HostIP: "127.0.0.1", Protocol: "tcp"}}},
+		{"127.0.0.1::80", []ServicePort{{Target: 80, HostIP: "127.0.0.1", Protocol: "tcp"}}},
+		{"[::1]:8443:443", []ServicePort{{Target: 443, Published: 8443, HostIP: "::1", Protocol: "tcp"}}},
+		{"8000-8001:80-81", []ServicePort{
+			{Target: 80, Published: 8000, Protocol: "tcp"},
+			{Target: 81, Published: 8001, Protocol: "tcp"},
+		}},
+		{"3000-3001", []ServicePort{{Target: 3000, Protocol: "tcp"}, {Target: 3001, Protocol: "tcp"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParsePort(tt.spec)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParsePort(%q) = %+v, want %+v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePort_Invalid(t *testing.T) {
+	for _, spec := range []string{"", "http", "0", "70000", "1:2:3:4", "[::1:80", "8000-8002:80-81", "81-80"} {
+		if _, err := ParsePort(spec); err == nil {
+			t.Errorf("ParsePort(%q): expected error", spec)
+		}
+	}
+}
+
+func TestServicePortsShortAndLong(t *testing.T) {
+	var f File
+	err := yaml.Unmarshal([]byte(`
+services:
+  web:
+    ports:
+      - "8080:80"
+      - 9000
+      - target: 443
+        published: 8443
+        host_ip: 127.0.0.1
+      - target: 53
+        published: "53"
+        protocol: udp
+`), &f)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := ServicePorts{
+		{Target: 80, Published: 8080, Protocol: "tcp"},
+		{Target: 9000, Protocol: "tcp"},
+		{Target: 443, Published: 8443, HostIP: "127.0.0.1", Protocol: "tcp"},
+		{Target: 53, Published: 53, Protocol: "udp"},
+	}
+	if got := f.Services["web"].Ports; !reflect.DeepEqual(got, want) {
+		t.Errorf("ports = %+v, want %+v", got, want)
+	}
+
+	var bad File
+	if err := yaml.Unmarshal([]byte("services:\n  x:\n    ports: \"80\"\n"), &bad); err == nil {
+		t.Error("expected error for scalar ports")
+	}
+}
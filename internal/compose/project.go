@@ -0,0 +1,387 @@
+// Package compose converts between Docker network topology and Docker Compose files.
+package compose
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/network"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// Additional labels set by Docker Compose on the containers it creates.
+const (
+	// labelContainerNumber holds the replica number of a container.
+	labelContainerNumber = "com.docker.compose.container-number"
+
+	// labelOneOff marks containers created by "docker compose run".
+	labelOneOff = "com.docker.compose.oneoff"
+
+	// labelDependsOn lists a container's service dependencies as
+	// "service:condition:restart" entries separated by commas.
+	labelDependsOn = "com.docker.compose.depends_on"
+)
+
+// defaultNetworkKey is the key of the network Compose creates for services
+// that do not declare any networks.
+const defaultNetworkKey = "default"
+
+// externalDriver is reported as the driver of external networks, whose real
+// driver cannot be known without a daemon.
+const externalDriver = "external"
+
+// Resources returns the networks and containers Compose would create for the
+// project, as the Docker API would report them once the stack is running.
+// Results are sorted by name, like the live listings.
+//
+// Networks are named "<project>_<key>" unless they set an explicit name, and
+// a "<project>_default" network is added for services without networks or a
+// network_mode. Only networks used by at least one service are returned, as
+// Compose does not create unused networks. Containers are named
+// "<project>-<service>-<n>" unless container_name is set, with one container
+// per replica. Only static addresses are known; dynamically allocated
+// addresses are left empty.
+func (p *Project) Resources() ([]network.Summary, []types.Container) {
+	netNames := p.networkNames()
+	containerNames := p.containerNames()
+
+	used := make(map[string]bool)
+	var containers []types.Container
+
+	for _, svcName := range slices.Sorted(maps.Keys(p.File.Services)) {
+		svc := p.File.Services[svcName]
+		if svc == nil {
+			svc = &Service{}
+		}
+
+		for i, name := range containerNames[svcName] {
+			cont := types.Container{
+				ID:     containerID(name),
+				Names:  []string{"/" + name},
+				Image:  svc.Image,
+				State:  "running",
+				Labels: make(map[string]string, len(svc.Labels)+4),
+				Ports:  containerPorts(svc.Ports),
+				NetworkSettings: &types.SummaryNetworkSettings{
+					Networks: make(map[string]*network.EndpointSettings),
+				},
+			}
+			for k, v := range svc.Labels {
+				cont.Labels[k] = v
+			}
+			cont.Labels[LabelProject] = p.Name
+			cont.Labels[LabelService] = svcName
+			cont.Labels[labelContainerNumber] = strconv.Itoa(i + 1)
+			cont.Labels[labelOneOff] = "False"
+			if deps := dependsOnLabel(svc.DependsOn); deps != "" {
+				cont.Labels[labelDependsOn] = deps
+			}
+
+			links := p.links(svc, containerNames)
+			mode := p.networkMode(svc, containerNames)
+			switch {
+			case mode == "":
+				for key, settings := range p.serviceNetworks(svc) {
+					netName := netNames[key]
+					if netName == "" {
+						netName = key
+					}
+					used[key] = true
+					cont.NetworkSettings.Networks[netName] = endpoint(settings, svcName, name, links)
+				}
+			case strings.HasPrefix(mode, "container:"):
+				cont.HostConfig.NetworkMode = mode
+			default:
+				cont.HostConfig.NetworkMode = mode
+				used[mode] = true
+				cont.NetworkSettings.Networks[mode] = &network.EndpointSettings{Links: links}
+			}
+
+			containers = append(containers, cont)
+		}
+	}
+
+	var networks []network.Summary
+	for key := range used {
+		networks = append(networks, p.network(key, netNames[key]))
+	}
+
+	sort.Slice(networks, func(i, j int) bool {
+		return networks[i].Name < networks[j].Name
+	})
+	sort.Slice(containers, func(i, j int) bool {
+		return containers[i].Names[0] < containers[j].Names[0]
+	})
+
+	return networks, containers
+}
+
+// Environment maps the name of each container Compose would create to the
+// environment variables its service sets. Containers of services without an
+// environment are left out.
+func (p *Project) Environment() map[string]map[string]string {
+	env := make(map[string]map[string]string)
+
+	for svcName, names := range p.containerNames() {
+		svc := p.File.Services[svcName]
+		if svc == nil || len(svc.Environment) == 0 {
+			continue
+		}
+		for _, name := range names {
+			vars := make(map[string]string, len(svc.Environment))
+			for k, v := range svc.Environment {
+				vars[k] = v
+			}
+			env[name] = vars
+		}
+	}
+
+	return env
+}
+
+// dependsOnLabel formats a service's dependencies the way Compose records
+// them in the com.docker.compose.depends_on label, sorted by service.
+func dependsOnLabel(deps DependsOn) string {
+	entries := make([]string, 0, len(deps))
+	for _, svc := range slices.Sorted(maps.Keys(deps)) {
+		entries = append(entries, svc+":"+deps[svc]+":false")
+	}
+	return strings.Join(entries, ",")
+}
+
+// network builds the Docker network for a network key.
+func (p *Project) network(key, name string) network.Summary {
+	if models.IsPredefinedNetwork(key) && p.File.Networks[key] == nil {
+		return predefinedNetwork(key)
+	}
+
+	def := p.File.Networks[key]
+	if def == nil {
+		def = &Network{}
+	}
+
+	if name == "" {
+		name = key
+	}
+
+	net := network.Summary{
+		Name:   name,
+		ID:     containerID(name),
+		Scope:  "local",
+		Driver: def.Driver,
+	}
+
+	if def.External {
+		net.Driver = externalDriver
+		return net
+	}
+
+	if net.Driver == "" {
+		net.Driver = network.NetworkBridge
+	}
+	net.Internal = def.Internal
+	net.Attachable = def.Attachable
+	net.EnableIPv6 = def.EnableIPv6
+	net.Options = def.DriverOpts
+
+	net.Labels = map[string]string{
+		LabelProject: p.Name,
+		LabelNetwork: key,
+	}
+	for k, v := range def.Labels {
+		net.Labels[k] = v
+	}
+
+	net.IPAM.Driver = "default"
+	if def.IPAM != nil {
+		if def.IPAM.Driver != "" {
+			net.IPAM.Driver = def.IPAM.Driver
+		}
+		for _, pool := range def.IPAM.Config {
+			net.IPAM.Config = append(net.IPAM.Config, network.IPAMConfig{
+				Subnet:     pool.Subnet,
+				IPRange:    pool.IPRange,
+				Gateway:    pool.Gateway,
+				AuxAddress: pool.AuxAddresses,
+			})
+		}
+	}
+
+	return net
+}
+
+// predefinedNetwork builds one of Docker's predefined networks.
+func predefinedNetwork(name string) network.Summary {
+	driver := name
+	switch name {
+	case network.NetworkNone:
+		driver = "null"
+	case models.PodmanDefaultNetwork:
+		driver = network.NetworkBridge
+	}
+
+	return network.Summary{
+		Name:   name,
+		ID:     containerID(name),
+		Scope:  "local",
+		Driver: driver,
+	}
+}
+
+// networkNames maps each network key to the name Compose gives the network.
+func (p *Project) networkNames() map[string]string {
+	names := map[string]string{
+		defaultNetworkKey: p.Name + "_" + defaultNetworkKey,
+	}
+
+	for key, def := range p.File.Networks {
+		switch {
+		case def != nil && def.Name != "":
+			names[key] = def.Name
+		case def != nil && def.External:
+			names[key] = key
+		default:
+			names[key] = p.Name + "_" + key
+		}
+	}
+
+	return names
+}
+
+// containerNames maps each service to the names of its containers.
+func (p *Project) containerNames() map[string][]string {
+	names := make(map[string][]string, len(p.File.Services))
+
+	for svcName, svc := range p.File.Services {
+		if svc != nil && svc.ContainerName != "" {
+			names[svcName] = []string{svc.ContainerName}
+			continue
+		}
+
+		replicas := 1
+		if svc != nil && svc.Deploy != nil && svc.Deploy.Replicas != nil {
+			replicas = *svc.Deploy.Replicas
+		}
+
+		for i := 1; i <= replicas; i++ {
+			names[svcName] = append(names[svcName], fmt.Sprintf("%s-%s-%d", p.Name, svcName, i))
+		}
+	}
+
+	return names
+}
+
+// serviceNetworks returns the networks a service is attached to, defaulting
+// to the project's default network.
+func (p *Project) serviceNetworks(svc *Service) ServiceNetworks {
+	if len(svc.Networks) == 0 {
+		return ServiceNetworks{defaultNetworkKey: nil}
+	}
+	return svc.Networks
+}
+
+// networkMode resolves a service's network_mode to the value Docker reports
+// in HostConfig.NetworkMode. "service:<name>" and "container:<name>" become
+// "container:<id>". An empty result means the service uses its networks.
+func (p *Project) networkMode(svc *Service, containerNames map[string][]string) string {
+	mode := svc.NetworkMode
+
+	if target, ok := strings.CutPrefix(mode, "service:"); ok {
+		if names := containerNames[target]; len(names) > 0 {
+			return "container:" + containerID(names[0])
+		}
+		return "container:" + target
+	}
+
+	if target, ok := strings.CutPrefix(mode, "container:"); ok {
+		for _, names := range containerNames {
+			for _, name := range names {
+				if name == target {
+					return "container:" + containerID(name)
+				}
+			}
+		}
+	}
+
+	return mode
+}
+
+// links converts a service's legacy links to the "container:alias" form
+// Docker reports on endpoints.
+func (p *Project) links(svc *Service, containerNames map[string][]string) []string {
+	var links []string
+
+	for _, link := range svc.Links {
+		target, alias, ok := strings.Cut(link, ":")
+		if !ok {
+			alias = target
+		}
+
+		name := target
+		if names := containerNames[target]; len(names) > 0 {
+			name = names[0]
+		}
+
+		links = append(links, name+":"+alias)
+	}
+
+	return links
+}
+
+// endpoint builds a container's endpoint on a user-defined network. Compose
+// registers the container name and the service name as aliases, followed by
+// any user aliases.
+func endpoint(settings *ServiceNetwork, service, name string, links []string) *network.EndpointSettings {
+	ep := &network.EndpointSettings{
+		Aliases: []string{name, service},
+		Links:   links,
+	}
+
+	if settings == nil {
+		return ep
+	}
+
+	ep.Aliases = append(ep.Aliases, settings.Aliases...)
+
+	if settings.IPv4Address != "" || settings.IPv6Address != "" {
+		ep.IPAMConfig = &network.EndpointIPAMConfig{
+			IPv4Address: settings.IPv4Address,
+			IPv6Address: settings.IPv6Address,
+		}
+		ep.IPAddress = settings.IPv4Address
+		ep.GlobalIPv6Address = settings.IPv6Address
+	}
+
+	return ep
+}
+
+// containerID derives a stable, Docker-style ID from a resource name so that
+// repeated runs produce identical output.
+func containerID(name string) string {
+	sum := sha256.Sum256([]byte(name))
+	return hex.EncodeToString(sum[:])
+}
+
+// containerPorts converts service ports to the ports the Docker API reports
+// for a container. Ports published on a random host port are reported with
+// a zero public port, as the host port is not known until the container starts.
+func containerPorts(ports ServicePorts) []types.Port {
+	var result []types.Port
+	for _, p := range ports {
+		result = append(result, types.Port{
+			IP:          p.HostIP,
+			PrivatePort: p.Target,
+			PublicPort:  p.Published,
+			Type:        p.Protocol,
+		})
+	}
+	return result
+}
@@ -0,0 +1,189 @@
+package compose
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/network"
+)
+
+// testProject returns a project covering networks, modes, replicas and links.
+func testProject() *Project {
+	two := 2
+	return &Project{
+		Name: "shop",
+		File: &File{
+			Services: map[string]*Service{
+				"web": {
+					Image:    "nginx",
+					Networks: ServiceNetworks{"front": nil, "shared": nil},
+					Labels:   Labels{"traefik.enable": "true", LabelService: "ignored"},
+					Ports:    ServicePorts{{Target: 80, Published: 8080, Protocol: "tcp"}},
+				},
+				"api": {
+					Image: "shop/api",
+					Networks: ServiceNetworks{
+						"front": nil,
+						"back":  {Aliases: []string{"backend-api"}, IPv4Address: "172.30.0.10"},
+					},
+					Links:       []string{"db:database"},
+					Environment: Environment{"DATABASE_URL": "postgres://db/shop"},
+					DependsOn:   DependsOn{"db": "service_healthy"},
+				},
+				"db":      {Image: "postgres", ContainerName: "shop-db", Networks: ServiceNetworks{"back": nil}},
+				"worker":  {Image: "shop/worker", Deploy: &Deploy{Replicas: &two}},
+				"sidecar": {Image: "envoy", NetworkMode: "service:api"},
+				"agent":   {Image: "agent", NetworkMode: "host"},
+			},
+			Networks: map[string]*Network{
+				"front":  {},
+				"back":   {Internal: true, IPAM: &IPAM{Config: []IPAMPool{{Subnet: "172.30.0.0/24"}}}},
+				"shared": {External: true, Name: "proxy"},
+				"unused": {},
+			},
+		},
+	}
+}
+
+// findContainer returns the container with the given name.
+func findContainer(t *testing.T, containers []types.Container, name string) types.Container {
+	t.Helper()
+	for _, cont := range containers {
+		if containerName(cont) == name {
+			return cont
+		}
+	}
+	t.Fatalf("container %q not found", name)
+	return types.Container{}
+}
+
+func TestResourcesNetworks(t *testing.T) {
+	networks, _ := testProject().Resources()
+
+	var names []string
+	byName := make(map[string]network.Summary)
+	for _, net := range networks {
+		names = append(names, net.Name)
+		byName[net.Name] = net
+	}
+
+	want := []string{"host", "proxy", "shop_back", "shop_default", "shop_front"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("expected networks %v, got %v", want, names)
+	}
+
+	back := byName["shop_back"]
+	if !back.Internal || back.Driver != "bridge" || len(back.IPAM.Config) != 1 {
+		t.Errorf("unexpected back network: %+v", back)
+	}
+	if back.Labels[LabelProject] != "shop" || back.Labels[LabelNetwork] != "back" {
+		t.Errorf("expected compose labels, got %v", back.Labels)
+	}
+
+	if byName["proxy"].Driver != externalDriver {
+		t.Errorf("expected external driver, got %q", byName["proxy"].Driver)
+	}
+}
+
+func TestResourcesPodmanNetwork(t *testing.T) {
+	p := &Project{
+		Name: "shop",
+		File: &File{Services: map[string]*Service{
+			"web": {Image: "nginx", NetworkMode: "podman"},
+		}},
+	}
+
+	networks, containers := p.Resources()
+	if len(networks) != 1 || networks[0].Name != "podman" || networks[0].Driver != "bridge" {
+		t.Errorf("expected Podman's predefined bridge network, got %+v", networks)
+	}
+	if _, ok := containers[0].NetworkSettings.Networks["podman"]; !ok {
+		t.Errorf("expected web on podman, got %v", containers[0].NetworkSettings.Networks)
+	}
+}
+
+func TestResourcesContainers(t *testing.T) {
+	_, containers := testProject().Resources()
+
+	if len(containers) != 7 {
+		t.Fatalf("expected 7 containers, got %d", len(containers))
+	}
+
+	api := findContainer(t, containers, "shop-api-1")
+	back := api.NetworkSettings.Networks["shop_back"]
+	if back == nil {
+		t.Fatal("expected api on shop_back")
+	}
+	if want := []string{"shop-api-1", "api", "backend-api"}; !reflect.DeepEqual(back.Aliases, want) {
+		t.Errorf("expected aliases %v, got %v", want, back.Aliases)
+	}
+	if back.IPAddress != "172.30.0.10" || back.IPAMConfig == nil {
+		t.Errorf("expected static address, got %+v", back)
+	}
+	if want := []string{"shop-db:database"}; !reflect.DeepEqual(back.Links, want) {
+		t.Errorf("expected links %v, got %v", want, back.Links)
+	}
+	if api.Labels[LabelService] != "api" {
+		t.Errorf("expected service label, got %v", api.Labels)
+	}
+	if got := api.Labels[labelDependsOn]; got != "db:service_healthy:false" {
+		t.Errorf("expected depends_on label, got %q", got)
+	}
+
+	web := findContainer(t, containers, "shop-web-1")
+	if _, ok := web.NetworkSettings.Networks["proxy"]; !ok {
+		t.Error("expected web on external network by its real name")
+	}
+	if web.Labels["traefik.enable"] != "true" || web.Labels[LabelService] != "web" {
+		t.Errorf("expected service labels with Compose labels taking precedence, got %v", web.Labels)
+	}
+	if want := []types.Port{{PrivatePort: 80, PublicPort: 8080, Type: "tcp"}}; !reflect.DeepEqual(web.Ports, want) {
+		t.Errorf("expected ports %v, got %v", want, web.Ports)
+	}
+
+	worker := findContainer(t, containers, "shop-worker-2")
+	if _, ok := worker.NetworkSettings.Networks["shop_default"]; !ok {
+		t.Error("expected worker on default network")
+	}
+
+	sidecar := findContainer(t, containers, "shop-sidecar-1")
+	if sidecar.HostConfig.NetworkMode != "container:"+api.ID {
+		t.Errorf("expected sidecar to share api's namespace, got %q", sidecar.HostConfig.NetworkMode)
+	}
+	if len(sidecar.NetworkSettings.Networks) != 0 {
+		t.Errorf("expected no networks for sidecar, got %v", sidecar.NetworkSettings.Networks)
+	}
+
+	agent := findContainer(t, containers, "shop-agent-1")
+	if agent.HostConfig.NetworkMode != "host" {
+		t.Errorf("expected host mode, got %q", agent.HostConfig.NetworkMode)
+	}
+	if _, ok := agent.NetworkSettings.Networks["host"]; !ok {
+		t.Error("expected agent on host network")
+	}
+}
+
+func TestEnvironment(t *testing.T) {
+	env := testProject().Environment()
+
+	want := map[string]map[string]string{
+		"shop-api-1": {"DATABASE_URL": "postgres://db/shop"},
+	}
+	if !reflect.DeepEqual(env, want) {
+		t.Errorf("expected %v, got %v", want, env)
+	}
+}
+
+func TestResourcesDeterministic(t *testing.T) {
+	_, first := testProject().Resources()
+	_, second := testProject().Resources()
+
+	if !reflect.DeepEqual(first, second) {
+		t.Error("expected identical resources on repeated calls")
+	}
+	if !strings.HasPrefix(first[0].Names[0], "/") {
+		t.Errorf("expected Docker-style name, got %q", first[0].Names[0])
+	}
+}
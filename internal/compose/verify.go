@@ -0,0 +1,216 @@
+// Package compose converts between Docker network topology and Docker Compose files.
+package compose
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/network"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// DriftKind identifies how the running stack differs from its Compose files.
+type DriftKind string
+
+// Kinds of drift reported by Verify.
+const (
+	// DriftMissingNetwork is a declared network that does not exist.
+	DriftMissingNetwork DriftKind = "missing-network"
+
+	// DriftMissingContainer is a declared container that does not exist.
+	DriftMissingContainer DriftKind = "missing-container"
+
+	// DriftUnexpectedContainer is a project container not declared in the files.
+	DriftUnexpectedContainer DriftKind = "unexpected-container"
+
+	// DriftMissingAttachment is a declared network attachment that is absent.
+	DriftMissingAttachment DriftKind = "missing-attachment"
+
+	// DriftExtraAttachment is an attachment not declared in the files, such as
+	// one added with "docker network connect".
+	DriftExtraAttachment DriftKind = "extra-attachment"
+
+	// DriftMissingAlias is a declared alias absent from the running endpoint.
+	DriftMissingAlias DriftKind = "missing-alias"
+
+	// DriftExtraAlias is an alias on the running endpoint that is not declared.
+	DriftExtraAlias DriftKind = "extra-alias"
+)
+
+// Drift is a single difference between a Compose project and the running stack.
+type Drift struct {
+	// Kind is the kind of difference.
+	Kind DriftKind
+
+	// Container is the container name; empty for network drift.
+	Container string
+
+	// Network is the network name; empty for container drift.
+	Network string
+
+	// Alias is the alias concerned; only set for alias drift.
+	Alias string
+}
+
+// String returns a human-readable description of the drift.
+func (d Drift) String() string {
+	switch d.Kind {
+	case DriftMissingNetwork:
+		return fmt.Sprintf("network %s is declared but does not exist", d.Network)
+	case DriftMissingContainer:
+		return fmt.Sprintf("container %s is declared but does not exist", d.Container)
+	case DriftUnexpectedContainer:
+		return fmt.Sprintf("container %s is not declared in the compose files", d.Container)
+	case DriftMissingAttachment:
+		return fmt.Sprintf("container %s is not attached to network %s", d.Container, d.Network)
+	case DriftExtraAttachment:
+		return fmt.Sprintf("container %s is attached to undeclared network %s", d.Container, d.Network)
+	case DriftMissingAlias:
+		return fmt.Sprintf("container %s is missing alias %q on network %s", d.Container, d.Alias, d.Network)
+	case DriftExtraAlias:
+		return fmt.Sprintf("container %s has undeclared alias %q on network %s", d.Container, d.Alias, d.Network)
+	default:
+		return string(d.Kind)
+	}
+}
+
+// Verify compares the networks, attachments and aliases a Compose project
+// declares with the running networks and containers, and returns every
+// difference sorted by container, network and kind.
+//
+// Containers are matched by their Compose service and container number
+// labels, so naming differences between Compose versions do not count as
+// drift. Only containers labeled with the project are compared, excluding
+// one-off "docker compose run" containers. Aliases Docker adds automatically
+// (the container name and short ID) are ignored.
+func Verify(project *Project, networks []network.Summary, containers []types.Container) []Drift {
+	wantNetworks, wantContainers := project.Resources()
+	var drifts []Drift
+
+	existing := make(map[string]bool, len(networks))
+	for _, net := range networks {
+		existing[net.Name] = true
+	}
+	for _, net := range wantNetworks {
+		if !existing[net.Name] {
+			drifts = append(drifts, Drift{Kind: DriftMissingNetwork, Network: net.Name})
+		}
+	}
+
+	running := make(map[string]types.Container)
+	for _, cont := range containers {
+		if cont.Labels[LabelProject] != project.Name || cont.Labels[labelOneOff] == "True" {
+			continue
+		}
+		running[replicaKey(cont)] = cont
+	}
+
+	for _, want := range wantContainers {
+		key := replicaKey(want)
+		got, ok := running[key]
+		if !ok {
+			drifts = append(drifts, Drift{Kind: DriftMissingContainer, Container: containerName(want)})
+			continue
+		}
+		delete(running, key)
+
+		drifts = append(drifts, attachmentDrift(want, got)...)
+	}
+
+	for _, cont := range running {
+		drifts = append(drifts, Drift{Kind: DriftUnexpectedContainer, Container: containerName(cont)})
+	}
+
+	sort.Slice(drifts, func(i, j int) bool {
+		a, b := drifts[i], drifts[j]
+		if a.Container != b.Container {
+			return a.Container < b.Container
+		}
+		if a.Network != b.Network {
+			return a.Network < b.Network
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Alias < b.Alias
+	})
+
+	return drifts
+}
+
+// attachmentDrift compares the network attachments and aliases of a declared
+// container with its running counterpart.
+func attachmentDrift(want, got types.Container) []Drift {
+	var drifts []Drift
+	name := containerName(got)
+
+	wantEndpoints := endpoints(want)
+	gotEndpoints := endpoints(got)
+
+	for netName, wantEP := range wantEndpoints {
+		gotEP, ok := gotEndpoints[netName]
+		if !ok {
+			drifts = append(drifts, Drift{Kind: DriftMissingAttachment, Container: name, Network: netName})
+			continue
+		}
+
+		wantAliases := userAliases(want, wantEP)
+		gotAliases := userAliases(got, gotEP)
+		for alias := range wantAliases {
+			if !gotAliases[alias] {
+				drifts = append(drifts, Drift{Kind: DriftMissingAlias, Container: name, Network: netName, Alias: alias})
+			}
+		}
+		for alias := range gotAliases {
+			if !wantAliases[alias] {
+				drifts = append(drifts, Drift{Kind: DriftExtraAlias, Container: name, Network: netName, Alias: alias})
+			}
+		}
+	}
+
+	for netName := range gotEndpoints {
+		if _, ok := wantEndpoints[netName]; !ok {
+			drifts = append(drifts, Drift{Kind: DriftExtraAttachment, Container: name, Network: netName})
+		}
+	}
+
+	return drifts
+}
+
+// endpoints returns a container's network endpoints, which may be empty.
+func endpoints(cont types.Container) map[string]*network.EndpointSettings {
+	if cont.NetworkSettings == nil {
+		return nil
+	}
+	return cont.NetworkSettings.Networks
+}
+
+// userAliases returns the aliases of an endpoint, excluding those Docker
+// generates from the container name and ID.
+func userAliases(cont types.Container, ep *network.EndpointSettings) map[string]bool {
+	aliases := make(map[string]bool)
+	if ep == nil {
+		return aliases
+	}
+
+	name := containerName(cont)
+	for _, alias := range ep.Aliases {
+		if alias == name || models.IsShortID(cont.ID, alias) {
+			continue
+		}
+		aliases[alias] = true
+	}
+
+	return aliases
+}
+
+// replicaKey identifies a container by its Compose service and replica number.
+func replicaKey(cont types.Container) string {
+	number := cont.Labels[labelContainerNumber]
+	if number == "" {
+		number = "1"
+	}
+	return cont.Labels[LabelService] + "#" + number
+}
@@ -0,0 +1,106 @@
+package compose
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/network"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// verifyProject returns a small project with a front and back network.
+func verifyProject() *Project {
+	return &Project{
+		Name: "shop",
+		File: &File{
+			Services: map[string]*Service{
+				"web": {Networks: ServiceNetworks{"front": nil}},
+				"api": {Networks: ServiceNetworks{"front": nil, "back": {Aliases: []string{"backend"}}}},
+				"db":  {Networks: ServiceNetworks{"back": nil}},
+			},
+			Networks: map[string]*Network{"front": {}, "back": {}},
+		},
+	}
+}
+
+func TestVerifyNoDrift(t *testing.T) {
+	project := verifyProject()
+	networks, containers := project.Resources()
+
+	// Docker adds the short ID as an alias; it must not count as drift.
+	for _, ep := range containers[0].NetworkSettings.Networks {
+		ep.Aliases = append(ep.Aliases, containers[0].ID[:models.ShortIDLength])
+	}
+
+	if drifts := Verify(project, networks, containers); len(drifts) != 0 {
+		t.Errorf("expected no drift, got %v", drifts)
+	}
+}
+
+func TestVerifyDrift(t *testing.T) {
+	project := verifyProject()
+	networks, containers := project.Resources()
+
+	// Drop the back network and the db container, hot-patch the api container
+	// and add a container that is not in the compose file.
+	networks = networks[1:]
+	var running []types.Container
+	for _, cont := range containers {
+		switch containerName(cont) {
+		case "shop-db-1":
+			continue
+		case "shop-api-1":
+			cont.NetworkSettings.Networks["shop_back"].Aliases = []string{"shop-api-1", "api", "legacy"}
+			cont.NetworkSettings.Networks["bridge"] = &network.EndpointSettings{}
+		case "shop-web-1":
+			delete(cont.NetworkSettings.Networks, "shop_front")
+		}
+		running = append(running, cont)
+	}
+	running = append(running, types.Container{
+		Names:           []string{"/shop-debug-1"},
+		Labels:          map[string]string{LabelProject: "shop", LabelService: "debug"},
+		NetworkSettings: &types.SummaryNetworkSettings{},
+	})
+
+	got := Verify(project, networks, running)
+	want := []Drift{
+		{Kind: DriftMissingNetwork, Network: "shop_back"},
+		{Kind: DriftExtraAttachment, Container: "shop-api-1", Network: "bridge"},
+		{Kind: DriftExtraAlias, Container: "shop-api-1", Network: "shop_back", Alias: "legacy"},
+		{Kind: DriftMissingAlias, Container: "shop-api-1", Network: "shop_back", Alias: "backend"},
+		{Kind: DriftMissingContainer, Container: "shop-db-1"},
+		{Kind: DriftUnexpectedContainer, Container: "shop-debug-1"},
+		{Kind: DriftMissingAttachment, Container: "shop-web-1", Network: "shop_front"},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected drift:\n got %v\nwant %v", got, want)
+	}
+}
+
+func TestVerifyIgnoresOtherProjects(t *testing.T) {
+	project := verifyProject()
+	networks, containers := project.Resources()
+
+	containers = append(containers, types.Container{
+		Names:  []string{"/other-web-1"},
+		Labels: map[string]string{LabelProject: "other", LabelService: "web"},
+	}, types.Container{
+		Names:  []string{"/shop-web-run-1"},
+		Labels: map[string]string{LabelProject: "shop", LabelService: "web", labelOneOff: "True"},
+	})
+
+	if drifts := Verify(project, networks, containers); len(drifts) != 0 {
+		t.Errorf("expected no drift, got %v", drifts)
+	}
+}
+
+func TestDriftString(t *testing.T) {
+	d := Drift{Kind: DriftMissingAlias, Container: "api", Network: "back", Alias: "db"}
+	if got := d.String(); got != `container api is missing alias "db" on network back` {
+		t.Errorf("unexpected string: %s", got)
+	}
+}
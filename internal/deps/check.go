@@ -0,0 +1,168 @@
+// Package deps finds the services each container depends on.
+// This file checks that each dependency resolves from the container.
+package deps
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// Dependency is a reference from a container to a host name and whether the
+// name resolves from the container.
+type Dependency struct {
+	Reference
+
+	// Container is the name of the dependent container.
+	Container string
+
+	// Network is the first network, by name, the name resolves on; empty
+	// when it does not resolve.
+	Network string
+
+	// Targets are the containers the name resolves to on Network, sorted by
+	// name.
+	Targets []string
+
+	// Problem explains why the name does not resolve; it is empty when it
+	// does.
+	Problem string
+}
+
+// Resolved reports whether the dependency's name resolves from the container.
+func (d Dependency) Resolved() bool {
+	return d.Problem == ""
+}
+
+// Check resolves a container's references against its DNS view, which lists
+// the names it can resolve on each of its networks. For names that do not
+// resolve, the other containers explain why: the name belongs to a container
+// on a network the container is not attached to, to a stopped container, or
+// to a container reached only over a network without embedded DNS.
+//
+// Dotted names that no container answers to are taken to be external hosts
+// and left out. The result is sorted like the references.
+func Check(c *models.ContainerInfo, dns *models.ContainerDNS, containers map[string]*models.ContainerInfo) []Dependency {
+	var result []Dependency
+
+	for _, ref := range References(c) {
+		dep := Dependency{Reference: ref, Container: c.Name}
+
+		if network, targets, ok := lookup(dns, ref.Name); ok {
+			dep.Network, dep.Targets = network, targets
+			result = append(result, dep)
+			continue
+		}
+
+		dep.Problem = explain(c, ref.Name, containers)
+		if dep.Problem == "" {
+			continue
+		}
+		result = append(result, dep)
+	}
+
+	return result
+}
+
+// lookup finds the first network in a DNS view on which name resolves and
+// the containers it resolves to. Names are matched case-insensitively, like
+// DNS.
+func lookup(dns *models.ContainerDNS, name string) (string, []string, bool) {
+	if dns == nil {
+		return "", nil, false
+	}
+
+	for _, nd := range dns.Networks {
+		for _, rec := range nd.Records {
+			if !strings.EqualFold(rec.Name, name) {
+				continue
+			}
+			targets := make([]string, len(rec.Targets))
+			for i, t := range rec.Targets {
+				targets[i] = t.Container
+			}
+			return nd.Network, targets, true
+		}
+	}
+
+	return "", nil, false
+}
+
+// explain tells why name does not resolve from c, or returns an empty string
+// for external host names.
+func explain(c *models.ContainerInfo, name string, containers map[string]*models.ContainerInfo) string {
+	var elsewhere, noDNS, stopped []string
+
+	for _, other := range models.SortedContainers(containers) {
+		if other.Name == c.Name {
+			continue
+		}
+		for _, network := range other.SortedNetworks() {
+			if !answersTo(other, network, name) {
+				continue
+			}
+			switch {
+			case !other.IsActive():
+				if state := fmt.Sprintf("%s is %s", other.Name, other.State); !slices.Contains(stopped, state) {
+					stopped = append(stopped, state)
+				}
+			case !models.HasEmbeddedDNS(network):
+				if c.HasNetwork(network) && !slices.Contains(noDNS, network) {
+					noDNS = append(noDNS, network)
+				}
+			case !c.HasNetwork(network) && !slices.Contains(elsewhere, network):
+				elsewhere = append(elsewhere, network)
+			}
+		}
+	}
+
+	switch {
+	case len(elsewhere) > 0:
+		return fmt.Sprintf("resolves only on %s, which %s is not attached to", strings.Join(elsewhere, ", "), c.Name)
+	case len(noDNS) > 0:
+		return fmt.Sprintf("shared network %s has no embedded DNS", noDNS[0])
+	case len(stopped) > 0:
+		return strings.Join(stopped, ", ")
+	case strings.Contains(name, "."):
+		return ""
+	default:
+		return "no container answers to " + name
+	}
+}
+
+// Providers returns the containers that answer to name on any of their
+// networks, whether or not they are running, sorted by name. It tells which
+// containers a reference is meant for regardless of the current network
+// layout.
+func Providers(name string, containers map[string]*models.ContainerInfo) []string {
+	var providers []string
+	for _, c := range models.SortedContainers(containers) {
+		if strings.EqualFold(c.Name, name) || (c.Service != "" && strings.EqualFold(c.Service, name)) {
+			providers = append(providers, c.Name)
+			continue
+		}
+		for _, network := range c.SortedNetworks() {
+			if answersTo(c, network, name) {
+				providers = append(providers, c.Name)
+				break
+			}
+		}
+	}
+	return providers
+}
+
+// answersTo reports whether a container registers name on a network: its
+// container name, Compose service name, or an alias or DNS name there.
+func answersTo(c *models.ContainerInfo, network, name string) bool {
+	if strings.EqualFold(c.Name, name) || (c.Service != "" && strings.EqualFold(c.Service, name)) {
+		return true
+	}
+	for _, n := range c.NamesOn(network) {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
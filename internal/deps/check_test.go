@@ -0,0 +1,118 @@
+package deps
+
+import (
+	"reflect"
+	"testing"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+func TestCheck(t *testing.T) {
+	containers := map[string]*models.ContainerInfo{
+		"api": {
+			Name:     "api",
+			Networks: []string{"backend", "bridge"},
+			Env: map[string]string{
+				"DB_HOST":      "database",
+				"MAIL_HOST":    "mail",
+				"LEGACY_HOST":  "legacy",
+				"OLD_HOST":     "old",
+				"GHOST_HOST":   "ghost",
+				"EXTERNAL_URL": "https://api.example.com",
+			},
+		},
+		"db": {
+			Name:           "db",
+			Networks:       []string{"backend"},
+			NetworkAliases: map[string][]string{"backend": {"database"}},
+		},
+		"mail":   {Name: "mail", Networks: []string{"mail_net"}},
+		"legacy": {Name: "legacy", Networks: []string{"bridge"}},
+		"old":    {Name: "old", State: "exited", Networks: []string{"backend"}},
+	}
+
+	dns := &models.ContainerDNS{
+		Container: "api",
+		Networks: []models.NetworkDNS{
+			{Network: "backend", EmbeddedDNS: true, Records: []models.DNSRecord{
+				{Name: "api", Targets: []models.DNSTarget{{Container: "api"}}},
+				{Name: "Database", Targets: []models.DNSTarget{{Container: "db"}}},
+				{Name: "db", Targets: []models.DNSTarget{{Container: "db"}}},
+			}},
+			{Network: "bridge"},
+		},
+	}
+
+	got := Check(containers["api"], dns, containers)
+
+	want := []Dependency{
+		{
+			Reference: Reference{Name: "database", Source: SourceEnv, Variable: "DB_HOST"},
+			Container: "api", Network: "backend", Targets: []string{"db"},
+		},
+		{
+			Reference: Reference{Name: "ghost", Source: SourceEnv, Variable: "GHOST_HOST"},
+			Container: "api", Problem: "no container answers to ghost",
+		},
+		{
+			Reference: Reference{Name: "legacy", Source: SourceEnv, Variable: "LEGACY_HOST"},
+			Container: "api", Problem: "shared network bridge has no embedded DNS",
+		},
+		{
+			Reference: Reference{Name: "mail", Source: SourceEnv, Variable: "MAIL_HOST"},
+			Container: "api", Problem: "resolves only on mail_net, which api is not attached to",
+		},
+		{
+			Reference: Reference{Name: "old", Source: SourceEnv, Variable: "OLD_HOST"},
+			Container: "api", Problem: "old is exited",
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Check() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestCheckWithoutReferences(t *testing.T) {
+	c := &models.ContainerInfo{Name: "web", Networks: []string{"frontend"}}
+
+	if got := Check(c, nil, map[string]*models.ContainerInfo{"web": c}); len(got) != 0 {
+		t.Errorf("expected no dependencies, got %+v", got)
+	}
+}
+
+func TestDependencyResolved(t *testing.T) {
+	if !(Dependency{Network: "backend"}).Resolved() {
+		t.Error("expected a dependency without a problem to be resolved")
+	}
+	if (Dependency{Problem: "no container answers to x"}).Resolved() {
+		t.Error("expected a dependency with a problem not to be resolved")
+	}
+}
+
+func TestProviders(t *testing.T) {
+	containers := map[string]*models.ContainerInfo{
+		"shop-db-1": {
+			Name: "shop-db-1", Service: "db", Networks: []string{"backend"},
+			NetworkAliases: map[string][]string{"backend": {"database"}},
+		},
+		"shop-db-2":  {Name: "shop-db-2", Service: "db", State: "exited"},
+		"shop-api-1": {Name: "shop-api-1", Service: "api", Networks: []string{"backend"}},
+	}
+
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{"db", []string{"shop-db-1", "shop-db-2"}},
+		{"Database", []string{"shop-db-1"}},
+		{"shop-api-1", []string{"shop-api-1"}},
+		{"mail", nil},
+	}
+
+	for _, tt := range tests {
+		if got := Providers(tt.name, containers); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Providers(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
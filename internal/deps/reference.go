@@ -0,0 +1,177 @@
+// Package deps finds the services each container depends on, from the host
+// names in its environment and its Compose depends_on, and checks that every
+// one of them resolves from a network the container is attached to.
+package deps
+
+import (
+	"net"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// Sources of a dependency.
+const (
+	// SourceEnv is an environment variable naming a host.
+	SourceEnv = "env"
+
+	// SourceDependsOn is the Compose depends_on of the container's service.
+	SourceDependsOn = "depends_on"
+)
+
+// dependsOnLabel lists a Compose container's service dependencies as
+// "service:condition:restart" entries separated by commas.
+const dependsOnLabel = "com.docker.compose.depends_on"
+
+// hostVariableSuffixes are the suffixes of environment variables that
+// conventionally name a host, such as DB_HOST, REDIS_URL or DATABASE_URL.
+var hostVariableSuffixes = []string{
+	"_HOST", "_HOSTNAME", "_HOSTS",
+	"_ADDR", "_ADDRESS",
+	"_SERVER", "_SERVERS",
+	"_URL", "_URI", "_DSN", "_ENDPOINT",
+}
+
+// ignoredVariables name hosts that are not dependencies, such as the public
+// host names reverse proxies route by.
+var ignoredVariables = map[string]bool{
+	"VIRTUAL_HOST":     true,
+	"LETSENCRYPT_HOST": true,
+}
+
+// localHosts are host names that refer to the container itself.
+var localHosts = map[string]bool{
+	"localhost": true,
+	"0.0.0.0":   true,
+}
+
+// hostName matches a DNS name or container name.
+var hostName = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9_.-]*[A-Za-z0-9])?$`)
+
+// dsnAddress matches the address of a Go MySQL style DSN, such as
+// "user:pass@tcp(db:3306)/app".
+var dsnAddress = regexp.MustCompile(`@\w+\(([^)]+)\)`)
+
+// Reference is a host name a container refers to.
+type Reference struct {
+	// Name is the host name.
+	Name string
+
+	// Source is where the reference was found, SourceEnv or SourceDependsOn.
+	Source string
+
+	// Variable is the environment variable holding the name; empty for
+	// depends_on references.
+	Variable string
+}
+
+// String describes where the reference was found: the variable name, or
+// "depends_on".
+func (r Reference) String() string {
+	if r.Source == SourceEnv {
+		return r.Variable
+	}
+	return r.Source
+}
+
+// References returns the host names a container refers to in hostname-like
+// environment variables and in its com.docker.compose.depends_on label,
+// sorted by name and source. URLs, comma-separated lists, "host:port" pairs
+// and MySQL style DSNs are understood; IP addresses and local names are
+// skipped. The environment is only known for inspected containers.
+func References(c *models.ContainerInfo) []Reference {
+	seen := make(map[Reference]bool)
+	var refs []Reference
+	add := func(ref Reference) {
+		if !seen[ref] {
+			seen[ref] = true
+			refs = append(refs, ref)
+		}
+	}
+
+	for variable, value := range c.Env {
+		if !isHostVariable(variable) {
+			continue
+		}
+		for _, host := range ParseHosts(value) {
+			add(Reference{Name: host, Source: SourceEnv, Variable: variable})
+		}
+	}
+
+	for _, entry := range strings.Split(c.Labels[dependsOnLabel], ",") {
+		service, _, _ := strings.Cut(strings.TrimSpace(entry), ":")
+		if service != "" {
+			add(Reference{Name: service, Source: SourceDependsOn})
+		}
+	}
+
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].Name != refs[j].Name {
+			return refs[i].Name < refs[j].Name
+		}
+		if refs[i].Source != refs[j].Source {
+			return refs[i].Source < refs[j].Source
+		}
+		return refs[i].Variable < refs[j].Variable
+	})
+	return refs
+}
+
+// isHostVariable reports whether an environment variable conventionally
+// names a host.
+func isHostVariable(variable string) bool {
+	upper := strings.ToUpper(variable)
+	if ignoredVariables[upper] {
+		return false
+	}
+	for _, suffix := range hostVariableSuffixes {
+		if strings.HasSuffix(upper, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseHosts returns the host names in an environment variable value, such
+// as "db" in "postgres://app:secret@db:5432/app". Values that are not host
+// names, IP addresses and local names are skipped.
+func ParseHosts(value string) []string {
+	var hosts []string
+	for _, field := range strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ' ' || r == ';'
+	}) {
+		host := parseHost(field)
+		if host == "" || localHosts[strings.ToLower(host)] || net.ParseIP(host) != nil || !hostName.MatchString(host) {
+			continue
+		}
+		hosts = append(hosts, host)
+	}
+	return hosts
+}
+
+// parseHost extracts the host from a URL, DSN or "[user@]host[:port][/path]".
+func parseHost(field string) string {
+	if strings.Contains(field, "://") {
+		if u, err := url.Parse(field); err == nil && u.Host != "" {
+			return u.Hostname()
+		}
+		_, field, _ = strings.Cut(field, "://")
+	}
+
+	if match := dsnAddress.FindStringSubmatch(field); match != nil {
+		field = match[1]
+	}
+
+	if i := strings.LastIndex(field, "@"); i >= 0 {
+		field = field[i+1:]
+	}
+	field, _, _ = strings.Cut(field, "/")
+
+	if host, _, err := net.SplitHostPort(field); err == nil {
+		return host
+	}
+	return field
+}
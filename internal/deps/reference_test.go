@@ -0,0 +1,72 @@
+package deps
+
+import (
+	"reflect"
+	"testing"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+func TestParseHosts(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{"db", []string{"db"}},
+		{"db:5432", []string{"db"}},
+		{"postgres://app:secret@db:5432/app?sslmode=disable", []string{"db"}},
+		{"redis://cache", []string{"cache"}},
+		{"app:secret@tcp(mysql:3306)/shop", []string{"mysql"}},
+		{"kafka-1:9092,kafka-2:9092", []string{"kafka-1", "kafka-2"}},
+		{"mongodb://a:27017,b:27017/shop", []string{"a", "b"}},
+		{"api.example.com", []string{"api.example.com"}},
+		{"localhost", nil},
+		{"0.0.0.0", nil},
+		{"10.0.0.5:8080", nil},
+		{"[::1]:8080", nil},
+		{"", nil},
+		{"/var/run/app.sock", nil},
+	}
+
+	for _, tt := range tests {
+		if got := ParseHosts(tt.value); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseHosts(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestReferences(t *testing.T) {
+	c := &models.ContainerInfo{
+		Name: "api",
+		Env: map[string]string{
+			"DATABASE_URL": "postgres://app@db:5432/app",
+			"REDIS_HOST":   "cache",
+			"VIRTUAL_HOST": "api.example.com",
+			"LISTEN_ADDR":  "0.0.0.0:8080",
+			"LOG_LEVEL":    "debug",
+		},
+		Labels: map[string]string{
+			dependsOnLabel: "db:service_healthy:false,queue:service_started:true",
+		},
+	}
+
+	want := []Reference{
+		{Name: "cache", Source: SourceEnv, Variable: "REDIS_HOST"},
+		{Name: "db", Source: SourceDependsOn},
+		{Name: "db", Source: SourceEnv, Variable: "DATABASE_URL"},
+		{Name: "queue", Source: SourceDependsOn},
+	}
+
+	if got := References(c); !reflect.DeepEqual(got, want) {
+		t.Errorf("References() = %+v, want %+v", got, want)
+	}
+}
+
+func TestReferenceString(t *testing.T) {
+	if got := (Reference{Name: "db", Source: SourceEnv, Variable: "DB_HOST"}).String(); got != "DB_HOST" {
+		t.Errorf("expected DB_HOST, got %q", got)
+	}
+	if got := (Reference{Name: "db", Source: SourceDependsOn}).String(); got != "depends_on" {
+		t.Errorf("expected depends_on, got %q", got)
+	}
+}
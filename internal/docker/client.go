@@ -6,8 +6,25 @@ package docker
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 
 	"github.com/docker/docker/client"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// PodmanDefaultNetwork is the name of Podman's default network, the
+// counterpart of Docker's "bridge" network.
+const PodmanDefaultNetwork = models.PodmanDefaultNetwork
+
+// Daemon socket paths probed when DOCKER_HOST is not set.
+const (
+	// dockerSocket is the default Docker daemon socket.
+	dockerSocket = "/var/run/docker.sock"
+
+	// podmanRootfulSocket is the socket of the system-wide Podman service.
+	podmanRootfulSocket = "/run/podman/podman.sock"
 )
 
 // Client wraps the Docker SDK client with additional functionality
@@ -16,6 +33,9 @@ import (
 type Client struct {
 	// cli is the underlying Docker SDK client.
 	cli client.APIClient
+
+	// conn holds the connection settings used when cli is created.
+	conn ConnectionConfig
 }
 
 // ClientOption is a functional option for configuring the Client.
@@ -33,8 +53,16 @@ func WithDockerClient(cli client.APIClient) ClientOption {
 // It initializes the Docker SDK client using environment configuration
 // and API version negotiation.
 //
+// The daemon is chosen from, in order of precedence: the host set with
+// WithConnection, the Docker CLI context set with WithConnection,
+// DOCKER_HOST, and the first existing socket of the Docker daemon, the
+// rootless Podman service ($XDG_RUNTIME_DIR/podman/podman.sock) and the
+// rootful Podman service (/run/podman/podman.sock). Podman serves a
+// Docker-compatible API on these sockets.
+//
 // The client can be configured with the following options:
 //   - WithDockerClient: Inject a custom Docker API client (useful for testing)
+//   - WithConnection: Set the host, TLS files or Docker CLI context
 //
 // Returns an error if the Docker client cannot be initialized.
 func NewClient(opts ...ClientOption) (*Client, error) {
@@ -47,10 +75,18 @@ func NewClient(opts ...ClientOption) (*Client, error) {
 
 	// If no custom client was provided, create one from environment
 	if c.cli == nil {
-		cli, err := client.NewClientWithOpts(
-			client.FromEnv,
-			client.WithAPIVersionNegotiation(),
-		)
+		conn, err := c.conn.resolve()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Docker client: %w", err)
+		}
+		c.conn = conn
+
+		clientOpts, err := c.conn.clientOpts()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Docker client: %w", err)
+		}
+
+		cli, err := client.NewClientWithOpts(clientOpts...)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create Docker client: %w", err)
 		}
@@ -83,3 +119,30 @@ func (c *Client) Close() error {
 func (c *Client) APIClient() client.APIClient {
 	return c.cli
 }
+
+// socketCandidates returns the daemon socket paths to probe, in order of
+// preference: Docker, rootless Podman, then rootful Podman.
+func socketCandidates() []string {
+	candidates := []string{dockerSocket}
+	if runtimeDir := os.Getenv("XDG_RUNTIME_DIR"); runtimeDir != "" {
+		candidates = append(candidates, filepath.Join(runtimeDir, "podman", "podman.sock"))
+	}
+	return append(candidates, podmanRootfulSocket)
+}
+
+// discoverHost returns the unix:// host of the first candidate socket that
+// exists, or an empty string if none does.
+func discoverHost(candidates []string, exists func(path string) bool) string {
+	for _, path := range candidates {
+		if exists(path) {
+			return "unix://" + path
+		}
+	}
+	return ""
+}
+
+// socketExists reports whether path exists and is a unix socket.
+func socketExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode()&os.ModeSocket != 0
+}
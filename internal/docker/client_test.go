@@ -8,6 +8,7 @@ import (
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/client"
 )
@@ -17,12 +18,19 @@ type mockAPIClient struct {
 	client.APIClient
 
 	// Mock function implementations
-	pingFunc             func(ctx context.Context) (types.Ping, error)
-	closeFunc            func() error
-	networkListFunc      func(ctx context.Context, opts network.ListOptions) ([]network.Summary, error)
-	networkInspectFunc   func(ctx context.Context, networkID string, opts network.InspectOptions) (network.Inspect, error)
-	containerListFunc    func(ctx context.Context, opts container.ListOptions) ([]types.Container, error)
-	containerInspectFunc func(ctx context.Context, containerID string) (types.ContainerJSON, error)
+	pingFunc              func(ctx context.Context) (types.Ping, error)
+	closeFunc             func() error
+	networkListFunc       func(ctx context.Context, opts network.ListOptions) ([]network.Summary, error)
+	networkInspectFunc    func(ctx context.Context, networkID string, opts network.InspectOptions) (network.Inspect, error)
+	networkRemoveFunc     func(ctx context.Context, networkID string) error
+	networkConnectFunc    func(ctx context.Context, networkID, containerID string, config *network.EndpointSettings) error
+	networkDisconnectFunc func(ctx context.Context, networkID, containerID string, force bool) error
+	containerListFunc     func(ctx context.Context, opts container.ListOptions) ([]types.Container, error)
+	containerInspectFunc  func(ctx context.Context, containerID string) (types.ContainerJSON, error)
+	eventsFunc            func(ctx context.Context, opts events.ListOptions) (<-chan events.Message, <-chan error)
+	execCreateFunc        func(ctx context.Context, containerID string, opts container.ExecOptions) (types.IDResponse, error)
+	execAttachFunc        func(ctx context.Context, execID string, opts container.ExecAttachOptions) (types.HijackedResponse, error)
+	execInspectFunc       func(ctx context.Context, execID string) (container.ExecInspect, error)
 }
 
 // Ping implements the Ping method of the Docker API client.
@@ -57,6 +65,30 @@ func (m *mockAPIClient) NetworkInspect(ctx context.Context, networkID string, op
 	return network.Inspect{}, nil
 }
 
+// NetworkRemove implements the NetworkRemove method of the Docker API client.
+func (m *mockAPIClient) NetworkRemove(ctx context.Context, networkID string) error {
+	if m.networkRemoveFunc != nil {
+		return m.networkRemoveFunc(ctx, networkID)
+	}
+	return nil
+}
+
+// NetworkConnect implements the NetworkConnect method of the Docker API client.
+func (m *mockAPIClient) NetworkConnect(ctx context.Context, networkID, containerID string, config *network.EndpointSettings) error {
+	if m.networkConnectFunc != nil {
+		return m.networkConnectFunc(ctx, networkID, containerID, config)
+	}
+	return nil
+}
+
+// NetworkDisconnect implements the NetworkDisconnect method of the Docker API client.
+func (m *mockAPIClient) NetworkDisconnect(ctx context.Context, networkID, containerID string, force bool) error {
+	if m.networkDisconnectFunc != nil {
+		return m.networkDisconnectFunc(ctx, networkID, containerID, force)
+	}
+	return nil
+}
+
 // ContainerList implements the ContainerList method of the Docker API client.
 func (m *mockAPIClient) ContainerList(ctx context.Context, opts container.ListOptions) ([]types.Container, error) {
 	if m.containerListFunc != nil {
@@ -73,6 +105,38 @@ func (m *mockAPIClient) ContainerInspect(ctx context.Context, containerID string
 	return types.ContainerJSON{}, nil
 }
 
+// Events implements the Events method of the Docker API client.
+func (m *mockAPIClient) Events(ctx context.Context, opts events.ListOptions) (<-chan events.Message, <-chan error) {
+	if m.eventsFunc != nil {
+		return m.eventsFunc(ctx, opts)
+	}
+	return make(chan events.Message), make(chan error)
+}
+
+// ContainerExecCreate implements the ContainerExecCreate method of the Docker API client.
+func (m *mockAPIClient) ContainerExecCreate(ctx context.Context, containerID string, opts container.ExecOptions) (types.IDResponse, error) {
+	if m.execCreateFunc != nil {
+		return m.execCreateFunc(ctx, containerID, opts)
+	}
+	return types.IDResponse{}, nil
+}
+
+// ContainerExecAttach implements the ContainerExecAttach method of the Docker API client.
+func (m *mockAPIClient) ContainerExecAttach(ctx context.Context, execID string, opts container.ExecAttachOptions) (types.HijackedResponse, error) {
+	if m.execAttachFunc != nil {
+		return m.execAttachFunc(ctx, execID, opts)
+	}
+	return types.HijackedResponse{}, nil
+}
+
+// ContainerExecInspect implements the ContainerExecInspect method of the Docker API client.
+func (m *mockAPIClient) ContainerExecInspect(ctx context.Context, execID string) (container.ExecInspect, error) {
+	if m.execInspectFunc != nil {
+		return m.execInspectFunc(ctx, execID)
+	}
+	return container.ExecInspect{}, nil
+}
+
 // TestNewClient_WithMockClient tests client creation with a mock Docker client.
 func TestNewClient_WithMockClient(t *testing.T) {
 	mock := &mockAPIClient{}
@@ -171,3 +235,58 @@ func TestClient_APIClient(t *testing.T) {
 		t.Error("expected APIClient to return the mock")
 	}
 }
+
+// TestDiscoverHost tests choosing the first existing daemon socket.
+func TestDiscoverHost(t *testing.T) {
+	candidates := []string{dockerSocket, "/run/user/1000/podman/podman.sock", podmanRootfulSocket}
+
+	tests := []struct {
+		name     string
+		existing []string
+		want     string
+	}{
+		{"docker", []string{dockerSocket, podmanRootfulSocket}, "unix:///var/run/docker.sock"},
+		{"rootless podman", []string{"/run/user/1000/podman/podman.sock", podmanRootfulSocket},
+			"unix:///run/user/1000/podman/podman.sock"},
+		{"rootful podman", []string{podmanRootfulSocket}, "unix:///run/podman/podman.sock"},
+		{"none", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exists := func(path string) bool {
+				for _, p := range tt.existing {
+					if p == path {
+						return true
+					}
+				}
+				return false
+			}
+
+			if got := discoverHost(candidates, exists); got != tt.want {
+				t.Errorf("discoverHost() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSocketCandidates tests the order of the probed sockets.
+func TestSocketCandidates(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", "/run/user/1000")
+
+	want := []string{dockerSocket, "/run/user/1000/podman/podman.sock", podmanRootfulSocket}
+	got := socketCandidates()
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("candidate %d: expected %q, got %q", i, want[i], got[i])
+		}
+	}
+
+	t.Setenv("XDG_RUNTIME_DIR", "")
+	if got := socketCandidates(); len(got) != 2 {
+		t.Errorf("expected 2 candidates without XDG_RUNTIME_DIR, got %v", got)
+	}
+}
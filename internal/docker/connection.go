@@ -0,0 +1,157 @@
+// Package docker provides a wrapper around the Docker SDK client.
+// This file contains the explicit daemon connection settings.
+package docker
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/docker/client"
+	"github.com/docker/go-connections/tlsconfig"
+)
+
+// Default TLS file names in a certificate directory, as used by the Docker CLI.
+const (
+	caCertFile     = "ca.pem"
+	clientCertFile = "cert.pem"
+	clientKeyFile  = "key.pem"
+)
+
+// ConnectionConfig holds explicit daemon connection settings. Empty fields
+// fall back to the Docker CLI context, the DOCKER_* environment variables
+// and socket discovery, in that order.
+type ConnectionConfig struct {
+	// Host is the daemon address, such as unix:///var/run/docker.sock,
+	// tcp://build-1:2376 or ssh://deploy@build-1.
+	Host string
+
+	// TLSVerify enables TLS and verifies the daemon's certificate.
+	TLSVerify bool
+
+	// TLSCACert is the path of the CA certificate that signs the daemon's certificate.
+	TLSCACert string
+
+	// TLSCert is the path of the client certificate.
+	TLSCert string
+
+	// TLSKey is the path of the client key.
+	TLSKey string
+
+	// Context is the Docker CLI context to use when Host is not set. It takes
+	// precedence over DOCKER_HOST. Empty or "default" uses no context.
+	Context string
+}
+
+// WithConnection sets explicit daemon connection settings.
+func WithConnection(cfg ConnectionConfig) ClientOption {
+	return func(c *Client) {
+		c.conn = cfg
+	}
+}
+
+// usesTLS reports whether the connection is made over TLS. Certificates
+// without TLSVerify enable TLS without verifying the daemon's certificate.
+func (cfg ConnectionConfig) usesTLS() bool {
+	return cfg.TLSVerify || cfg.TLSCACert != "" || cfg.TLSCert != "" || cfg.TLSKey != ""
+}
+
+// resolve fills in the settings not given explicitly from the Docker CLI
+// context, DOCKER_HOST and socket discovery.
+func (cfg ConnectionConfig) resolve() (ConnectionConfig, error) {
+	if cfg.Host == "" && cfg.Context != "" {
+		endpoint, err := ContextConnection(ConfigDir(), cfg.Context)
+		if err != nil {
+			return cfg, err
+		}
+		cfg.Host = endpoint.Host
+		if cfg.TLSCACert == "" && cfg.TLSCert == "" && cfg.TLSKey == "" {
+			cfg.TLSCACert = endpoint.TLSCACert
+			cfg.TLSCert = endpoint.TLSCert
+			cfg.TLSKey = endpoint.TLSKey
+		}
+		cfg.TLSVerify = cfg.TLSVerify || endpoint.TLSVerify
+	}
+
+	if cfg.Host == "" {
+		cfg.Host = os.Getenv(client.EnvOverrideHost)
+	}
+
+	if cfg.Host == "" {
+		cfg.Host = discoverHost(socketCandidates(), socketExists)
+	}
+
+	if cfg.TLSVerify && cfg.TLSCACert == "" && cfg.TLSCert == "" && cfg.TLSKey == "" {
+		cfg.TLSCACert, cfg.TLSCert, cfg.TLSKey = defaultCertFiles()
+	}
+
+	return cfg, nil
+}
+
+// clientOpts returns the Docker SDK options for the resolved connection.
+// The DOCKER_* environment variables are applied first, so explicit settings
+// take precedence.
+func (cfg ConnectionConfig) clientOpts() ([]client.Opt, error) {
+	opts := []client.Opt{
+		client.FromEnv,
+		client.WithAPIVersionNegotiation(),
+	}
+
+	if cfg.usesTLS() {
+		tlsConfig, err := tlsconfig.Client(tlsconfig.Options{
+			CAFile:             cfg.TLSCACert,
+			CertFile:           cfg.TLSCert,
+			KeyFile:            cfg.TLSKey,
+			InsecureSkipVerify: !cfg.TLSVerify,
+			ExclusiveRootPools: true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS configuration: %w", err)
+		}
+
+		// Replacing the HTTP client resets the transport, so a host is
+		// always applied after it.
+		opts = append(opts, client.WithHTTPClient(&http.Client{
+			Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		}))
+		if cfg.Host == "" {
+			cfg.Host = client.DefaultDockerHost
+		}
+	}
+
+	switch {
+	case strings.HasPrefix(cfg.Host, "ssh://"):
+		dialer, err := sshDialer(cfg.Host)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, client.WithHost(sshHost), client.WithDialContext(dialer))
+	case cfg.Host != "":
+		opts = append(opts, client.WithHost(cfg.Host))
+	}
+
+	return opts, nil
+}
+
+// defaultCertFiles returns the TLS files in DOCKER_CERT_PATH, or in the
+// Docker configuration directory, that exist.
+func defaultCertFiles() (string, string, string) {
+	dir := os.Getenv(client.EnvOverrideCertPath)
+	if dir == "" {
+		dir = ConfigDir()
+	}
+
+	return existingFile(dir, caCertFile), existingFile(dir, clientCertFile), existingFile(dir, clientKeyFile)
+}
+
+// existingFile returns the path of the named file in dir, or an empty string
+// if it does not exist.
+func existingFile(dir, name string) string {
+	path := filepath.Join(dir, name)
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
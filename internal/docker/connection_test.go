@@ -0,0 +1,127 @@
+// Package docker provides tests for the explicit connection settings.
+package docker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestNewClient_WithConnectionHost tests that an explicit host overrides DOCKER_HOST.
+func TestNewClient_WithConnectionHost(t *testing.T) {
+	t.Setenv("DOCKER_HOST", "unix:///tmp/ignored.sock")
+
+	c, err := NewClient(WithConnection(ConnectionConfig{Host: "tcp://build-1:2375"}))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer func() {
+		_ = c.Close()
+	}()
+
+	if got := c.APIClient().DaemonHost(); got != "tcp://build-1:2375" {
+		t.Errorf("expected host tcp://build-1:2375, got %s", got)
+	}
+}
+
+// TestNewClient_WithConnectionSSH tests that ssh:// hosts use the SSH dialer.
+func TestNewClient_WithConnectionSSH(t *testing.T) {
+	c, err := NewClient(WithConnection(ConnectionConfig{Host: "ssh://deploy@build-1:2222"}))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer func() {
+		_ = c.Close()
+	}()
+
+	if got := c.APIClient().DaemonHost(); got != sshHost {
+		t.Errorf("expected placeholder host %s, got %s", sshHost, got)
+	}
+}
+
+// TestNewClient_WithConnectionInvalid tests connection settings that cannot be used.
+func TestNewClient_WithConnectionInvalid(t *testing.T) {
+	tests := map[string]ConnectionConfig{
+		"missing CA":     {Host: "tcp://build-1:2376", TLSVerify: true, TLSCACert: "/nonexistent/ca.pem"},
+		"ssh path":       {Host: "ssh://build-1/var/run/docker.sock"},
+		"malformed host": {Host: "tcp://build-1:port"},
+	}
+
+	for name, cfg := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewClient(WithConnection(cfg)); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+// TestConnectionConfig_Resolve tests the precedence of the connection sources.
+func TestConnectionConfig_Resolve(t *testing.T) {
+	configDir := t.TempDir()
+	writeContext(t, configDir, "remote", "ssh://deploy@build-1", false, true)
+	t.Setenv("DOCKER_CONFIG", configDir)
+
+	t.Run("explicit host", func(t *testing.T) {
+		t.Setenv("DOCKER_HOST", "tcp://env:2375")
+
+		cfg, err := ConnectionConfig{Host: "tcp://flag:2375", Context: "remote"}.resolve()
+		if err != nil || cfg.Host != "tcp://flag:2375" {
+			t.Errorf("expected flag host, got %q (%v)", cfg.Host, err)
+		}
+	})
+
+	t.Run("context over DOCKER_HOST", func(t *testing.T) {
+		t.Setenv("DOCKER_HOST", "tcp://env:2375")
+
+		cfg, err := ConnectionConfig{Context: "remote"}.resolve()
+		if err != nil || cfg.Host != "ssh://deploy@build-1" {
+			t.Errorf("expected the context's endpoint, got %q (%v)", cfg.Host, err)
+		}
+	})
+
+	t.Run("DOCKER_HOST with default context", func(t *testing.T) {
+		t.Setenv("DOCKER_HOST", "tcp://env:2375")
+
+		cfg, err := ConnectionConfig{Context: DefaultContext}.resolve()
+		if err != nil || cfg.Host != "tcp://env:2375" {
+			t.Errorf("expected DOCKER_HOST, got %q (%v)", cfg.Host, err)
+		}
+	})
+
+	t.Run("context", func(t *testing.T) {
+		t.Setenv("DOCKER_HOST", "")
+
+		cfg, err := ConnectionConfig{Context: "remote"}.resolve()
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if cfg.Host != "ssh://deploy@build-1" || !cfg.TLSVerify || cfg.TLSCACert == "" {
+			t.Errorf("expected the context's endpoint, got %+v", cfg)
+		}
+	})
+
+	t.Run("unknown context", func(t *testing.T) {
+		t.Setenv("DOCKER_HOST", "")
+
+		if _, err := (ConnectionConfig{Context: "missing"}).resolve(); err == nil {
+			t.Error("expected error for unknown context")
+		}
+	})
+}
+
+// TestDefaultCertFiles tests finding the TLS files in DOCKER_CERT_PATH.
+func TestDefaultCertFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{caCertFile, clientCertFile} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("DOCKER_CERT_PATH", dir)
+
+	ca, cert, key := defaultCertFiles()
+	if ca != filepath.Join(dir, caCertFile) || cert != filepath.Join(dir, clientCertFile) || key != "" {
+		t.Errorf("unexpected files: %q %q %q", ca, cert, key)
+	}
+}
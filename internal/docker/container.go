@@ -5,10 +5,12 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
 
 	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
 )
@@ -66,28 +68,126 @@ func (c *Client) FetchContainerByID(ctx context.Context, containerID string) (ty
 	return containerJSON, nil
 }
 
+// FetchContainerEnv inspects a container and returns its environment
+// variables, which the container list does not include.
+func (c *Client) FetchContainerEnv(ctx context.Context, containerID string) (map[string]string, error) {
+	containerJSON, err := c.FetchContainerByID(ctx, containerID)
+	if err != nil {
+		return nil, err
+	}
+	if containerJSON.Config == nil {
+		return nil, nil
+	}
+
+	return ParseEnv(containerJSON.Config.Env), nil
+}
+
+// FetchContainerPortBindings inspects a container and returns the host ports
+// it is configured to publish. The container list reports published ports
+// only while a container runs, so this tells which host ports a stopped
+// container will claim when it starts.
+func (c *Client) FetchContainerPortBindings(ctx context.Context, containerID string) ([]models.PortInfo, error) {
+	containerJSON, err := c.FetchContainerByID(ctx, containerID)
+	if err != nil {
+		return nil, err
+	}
+	if containerJSON.ContainerJSONBase == nil || containerJSON.HostConfig == nil {
+		return nil, nil
+	}
+
+	return ConvertPortBindings(containerJSON.HostConfig.PortBindings), nil
+}
+
+// ConvertPortBindings converts a container's configured port bindings to
+// ports, sorted by host port, protocol and address. Bindings to a random host
+// port are left out, as they cannot conflict.
+func ConvertPortBindings(bindings nat.PortMap) []models.PortInfo {
+	var ports []models.PortInfo
+	for port, hostBindings := range bindings {
+		for _, b := range hostBindings {
+			hostPort, err := strconv.ParseUint(b.HostPort, 10, 16)
+			if err != nil || hostPort == 0 {
+				continue
+			}
+			ports = append(ports, models.PortInfo{
+				IP:          b.HostIP,
+				PrivatePort: uint16(port.Int()), // #nosec G115 -- nat.Port numbers are at most 65535
+				PublicPort:  uint16(hostPort),
+				Type:        port.Proto(),
+			})
+		}
+	}
+
+	sort.Slice(ports, func(i, j int) bool {
+		if ports[i].PublicPort != ports[j].PublicPort {
+			return ports[i].PublicPort < ports[j].PublicPort
+		}
+		if ports[i].Type != ports[j].Type {
+			return ports[i].Type < ports[j].Type
+		}
+		return ports[i].IP < ports[j].IP
+	})
+	return ports
+}
+
+// ParseEnv converts a list of "KEY=value" environment entries to a map.
+// Entries without "=" are set to an empty value.
+func ParseEnv(entries []string) map[string]string {
+	if len(entries) == 0 {
+		return nil
+	}
+	env := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		key, value, _ := strings.Cut(entry, "=")
+		env[key] = value
+	}
+	return env
+}
+
+// Compose labels read from containers and networks.
+const (
+	// composeServiceLabel holds the Compose service name of a container.
+	composeServiceLabel = "com.docker.compose.service"
+
+	// composeProjectLabel holds the Compose project name of a container or network.
+	composeProjectLabel = "com.docker.compose.project"
+)
+
+// podNameLabel holds the name of the pod a Podman container belongs to.
+// Podman names a pod's infra container after the pod's ID, not its name.
+const podNameLabel = "io.podman.annotations.pod-name"
+
 // BuildContainerMap creates a map of container names to ContainerInfo structs.
 // This provides quick lookup of container information by name.
+//
+// Containers sharing another container's network namespace through a
+// "container:" network mode, such as the members of a Podman pod, are given
+// the namespace owner's networks, since they share its endpoints, and are
+// grouped with it into a pod.
 func (c *Client) BuildContainerMap(containers []types.Container) map[string]*models.ContainerInfo {
 	containerMap := make(map[string]*models.ContainerInfo, len(containers))
 
 	for _, cont := range containers {
 		name := sanitizeContainerName(cont.Names)
-		ci := models.NewContainerInfo(name)
-
-		// Add all networks and their aliases
-		for netName, netSettings := range cont.NetworkSettings.Networks {
-			ci.AddNetwork(netName)
+		containerMap[name] = ConvertToContainerInfo(cont)
+	}
 
-			// Add aliases from network settings
-			if netSettings != nil {
-				for _, alias := range netSettings.Aliases {
-					ci.AddAlias(alias)
-				}
-			}
+	for _, cont := range containers {
+		owner := namespaceOwner(cont, containers)
+		if owner.ID == cont.ID {
+			continue
 		}
 
-		containerMap[name] = ci
+		member := containerMap[sanitizeContainerName(cont.Names)]
+		ownerInfo := containerMap[sanitizeContainerName(owner.Names)]
+		pod := podName(owner, cont, ownerInfo.Name)
+
+		member.NetworkNamespace = ownerInfo.Name
+		member.Pod = pod
+		ownerInfo.Pod = pod
+		for _, netName := range ownerInfo.Networks {
+			member.AddNetwork(netName)
+		}
 	}
 
 	return containerMap
@@ -99,7 +199,8 @@ func (c *Client) BuildContainerMap(containers []types.Container) map[string]*mod
 //
 // The returned map has network names as keys and slices of ContainerInfo
 // as values. Each ContainerInfo contains the container's name, aliases,
-// and the networks it belongs to.
+// and the networks it belongs to. Pod members are listed on the networks of
+// their pod.
 func (c *Client) BuildNetworkToContainersMap(containers []types.Container) map[string][]models.ContainerInfo {
 	// First build the container map to get complete ContainerInfo objects
 	containerMap := c.BuildContainerMap(containers)
@@ -111,7 +212,7 @@ func (c *Client) BuildNetworkToContainersMap(containers []types.Container) map[s
 		name := sanitizeContainerName(cont.Names)
 		ci := containerMap[name]
 
-		for netName := range cont.NetworkSettings.Networks {
+		for _, netName := range ci.Networks {
 			// Dereference the pointer to store a copy in the map
 			networkToContainers[netName] = append(networkToContainers[netName], *ci)
 		}
@@ -128,18 +229,88 @@ func (c *Client) BuildNetworkToContainersMap(containers []types.Container) map[s
 	return networkToContainers
 }
 
+// podName returns the name of the pod whose network namespace owner shares
+// with member: the Podman pod name of either container, or ownerName for a
+// plain "container:" network mode.
+func podName(owner, member types.Container, ownerName string) string {
+	for _, cont := range []types.Container{owner, member} {
+		if name := cont.Labels[podNameLabel]; name != "" {
+			return name
+		}
+	}
+	return ownerName
+}
+
+// BuildNetworkAddressMap creates a mapping from network names to the IP
+// addresses allocated to container endpoints on each network. Both IPv4 and
+// global IPv6 addresses are included. This is used to calculate how much of a
+// network's IPAM pool is in use.
+func (c *Client) BuildNetworkAddressMap(containers []types.Container) map[string][]string {
+	addresses := make(map[string][]string)
+
+	for _, cont := range containers {
+		if cont.NetworkSettings == nil {
+			continue
+		}
+
+		for netName, netSettings := range cont.NetworkSettings.Networks {
+			if netSettings == nil {
+				continue
+			}
+			if netSettings.IPAddress != "" {
+				addresses[netName] = append(addresses[netName], netSettings.IPAddress)
+			}
+			if netSettings.GlobalIPv6Address != "" {
+				addresses[netName] = append(addresses[netName], netSettings.GlobalIPv6Address)
+			}
+		}
+	}
+
+	for netName, list := range addresses {
+		sort.Strings(list)
+		addresses[netName] = list
+	}
+
+	return addresses
+}
+
 // ConvertToContainerInfo converts a Docker types.Container to our internal
 // ContainerInfo model. This decouples the output package from Docker API types.
 func ConvertToContainerInfo(cont types.Container) *models.ContainerInfo {
 	name := sanitizeContainerName(cont.Names)
 	ci := models.NewContainerInfo(name)
+	ci.ID = cont.ID
+	ci.Service = cont.Labels[composeServiceLabel]
+	ci.Project = cont.Labels[composeProjectLabel]
+	ci.State = cont.State
+	ci.NetworkMode = cont.HostConfig.NetworkMode
+	ci.Image = cont.Image
+	if len(cont.Labels) > 0 {
+		ci.Labels = make(map[string]string, len(cont.Labels))
+		for k, v := range cont.Labels {
+			ci.Labels[k] = v
+		}
+	}
 
+	for _, p := range cont.Ports {
+		ci.Ports = append(ci.Ports, models.PortInfo{
+			IP:          p.IP,
+			PrivatePort: p.PrivatePort,
+			PublicPort:  p.PublicPort,
+			Type:        p.Type,
+		})
+	}
+
+	// Add all networks and their per-network aliases and DNS names
 	for netName, netSettings := range cont.NetworkSettings.Networks {
 		ci.AddNetwork(netName)
 
 		if netSettings != nil {
 			for _, alias := range netSettings.Aliases {
-				ci.AddAlias(alias)
+				ci.AddNetworkAlias(netName, alias)
+			}
+			for _, dnsName := range netSettings.DNSNames {
+				ci.AddNetworkDNSName(netName, dnsName)
 			}
 		}
 	}
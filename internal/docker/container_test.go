@@ -4,11 +4,16 @@ package docker
 import (
 	"context"
 	"errors"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
 )
 
 // createTestContainer creates a test container with proper types.
@@ -276,6 +281,63 @@ func TestClient_BuildContainerMap_Empty(t *testing.T) {
 	}
 }
 
+// TestClient_BuildContainerMap_Pod tests that containers sharing a pod's
+// network namespace are grouped with the pod's infra container, under the
+// pod's name.
+func TestClient_BuildContainerMap_Pod(t *testing.T) {
+	infra := createTestContainer("3f2b1c9d8e7a-infra", map[string][]string{"shop": nil})
+	infra.Labels = map[string]string{podNameLabel: "shop"}
+	app := createTestContainer("shop-app", nil)
+	app.HostConfig.NetworkMode = "container:id_3f2b1c9d8e7a-infra"
+	other := createTestContainer("other", map[string][]string{"podman": nil})
+
+	c := &Client{}
+	containerMap := c.BuildContainerMap([]types.Container{infra, app, other})
+
+	member := containerMap["shop-app"]
+	if member.NetworkNamespace != "3f2b1c9d8e7a-infra" || member.Pod != "shop" {
+		t.Errorf("expected shop-app in pod shop owned by 3f2b1c9d8e7a-infra, got %q/%q",
+			member.Pod, member.NetworkNamespace)
+	}
+	if !member.HasNetwork("shop") {
+		t.Error("expected shop-app to inherit the pod's network 'shop'")
+	}
+
+	if owner := containerMap["3f2b1c9d8e7a-infra"]; owner.Pod != "shop" || owner.NetworkNamespace != "" {
+		t.Errorf("expected 3f2b1c9d8e7a-infra to own pod shop, got %q/%q", owner.Pod, owner.NetworkNamespace)
+	}
+	if containerMap["other"].Pod != "" {
+		t.Error("expected other not to be in a pod")
+	}
+
+	netMap := c.BuildNetworkToContainersMap([]types.Container{infra, app, other})
+	if len(netMap["shop"]) != 2 {
+		t.Errorf("expected both pod containers on network 'shop', got %d", len(netMap["shop"]))
+	}
+}
+
+// TestPodName tests naming the pod of a shared network namespace.
+func TestPodName(t *testing.T) {
+	labeled := types.Container{Labels: map[string]string{podNameLabel: "shop"}}
+	plain := types.Container{}
+
+	tests := []struct {
+		name          string
+		owner, member types.Container
+		want          string
+	}{
+		{"infra label", labeled, plain, "shop"},
+		{"member label", plain, labeled, "shop"},
+		{"container network mode", plain, plain, "vpn"},
+	}
+
+	for _, tt := range tests {
+		if got := podName(tt.owner, tt.member, "vpn"); got != tt.want {
+			t.Errorf("%s: podName() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
 // TestClient_BuildNetworkToContainersMap tests building network-to-containers mapping.
 func TestClient_BuildNetworkToContainersMap(t *testing.T) {
 	containers := []types.Container{
@@ -350,6 +412,39 @@ func TestClient_BuildNetworkToContainersMap_Empty(t *testing.T) {
 	}
 }
 
+// TestClient_BuildNetworkAddressMap tests collecting endpoint addresses per network.
+func TestClient_BuildNetworkAddressMap(t *testing.T) {
+	web := createTestContainer("web", map[string][]string{"frontend": {}, "backend": {}})
+	web.NetworkSettings.Networks["frontend"].IPAddress = "10.0.0.3"
+	web.NetworkSettings.Networks["frontend"].GlobalIPv6Address = "fd00::3"
+	web.NetworkSettings.Networks["backend"].IPAddress = "10.1.0.2"
+
+	api := createTestContainer("api", map[string][]string{"frontend": {}})
+	api.NetworkSettings.Networks["frontend"].IPAddress = "10.0.0.2"
+
+	stopped := createTestContainer("stopped", map[string][]string{"frontend": {}})
+
+	mock := &mockAPIClient{}
+	c, err := NewClient(WithDockerClient(mock))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	addresses := c.BuildNetworkAddressMap([]types.Container{web, api, stopped, {Names: []string{"/nil"}}})
+
+	frontend := addresses["frontend"]
+	if len(frontend) != 3 {
+		t.Fatalf("expected 3 frontend addresses, got %v", frontend)
+	}
+	if frontend[0] != "10.0.0.2" || frontend[1] != "10.0.0.3" || frontend[2] != "fd00::3" {
+		t.Errorf("expected sorted frontend addresses, got %v", frontend)
+	}
+
+	if len(addresses["backend"]) != 1 {
+		t.Errorf("expected 1 backend address, got %v", addresses["backend"])
+	}
+}
+
 // TestConvertToContainerInfo tests conversion of Docker container to internal model.
 func TestConvertToContainerInfo(t *testing.T) {
 	cont := createTestContainer("web", map[string][]string{
@@ -384,6 +479,192 @@ func TestConvertToContainerInfo(t *testing.T) {
 	}
 }
 
+// TestConvertToContainerInfo_DNSNames tests conversion of per-network DNS names.
+func TestConvertToContainerInfo_DNSNames(t *testing.T) {
+	cont := createTestContainer("web", map[string][]string{
+		"frontend": {"www"},
+		"backend":  nil,
+	})
+	cont.NetworkSettings.Networks["frontend"].DNSNames = []string{"web", "www", "id_web"}
+	cont.NetworkSettings.Networks["backend"].DNSNames = []string{"web", "id_web"}
+
+	info := ConvertToContainerInfo(cont)
+
+	if info.ID != "id_web" {
+		t.Errorf("expected ID id_web, got %q", info.ID)
+	}
+	if got := strings.Join(info.NamesOn("frontend"), ","); got != "id_web,www" {
+		t.Errorf("expected frontend names id_web,www, got %s", got)
+	}
+	if got := strings.Join(info.NamesOn("backend"), ","); got != "id_web" {
+		t.Errorf("expected backend names id_web, got %s", got)
+	}
+}
+
+// TestConvertToContainerInfo_PortsAndNetworkMode tests conversion of ports and the network mode.
+func TestConvertToContainerInfo_PortsAndNetworkMode(t *testing.T) {
+	cont := createTestContainer("db", map[string][]string{"backend": {}})
+	cont.HostConfig.NetworkMode = "backend"
+	cont.State = "exited"
+	cont.Labels = map[string]string{"com.docker.compose.project": "shop"}
+	cont.Ports = []types.Port{
+		{IP: "0.0.0.0", PrivatePort: 5432, PublicPort: 15432, Type: "tcp"},
+	}
+
+	info := ConvertToContainerInfo(cont)
+
+	if info.NetworkMode != "backend" {
+		t.Errorf("expected network mode 'backend', got '%s'", info.NetworkMode)
+	}
+
+	if info.State != "exited" || info.Project != "shop" {
+		t.Errorf("expected state 'exited' and project 'shop', got '%s' and '%s'", info.State, info.Project)
+	}
+
+	if len(info.Ports) != 1 {
+		t.Fatalf("expected 1 port, got %d", len(info.Ports))
+	}
+
+	if p := info.Ports[0]; p.PrivatePort != 5432 || p.PublicPort != 15432 || !p.IsPublic() {
+		t.Errorf("unexpected port: %+v", p)
+	}
+}
+
+// TestConvertToContainerInfo_ImageAndLabels tests that the image and labels are copied.
+func TestConvertToContainerInfo_ImageAndLabels(t *testing.T) {
+	cont := createTestContainer("proxy", map[string][]string{"frontend": {}})
+	cont.Image = "traefik:v3.0"
+	cont.Labels = map[string]string{"traefik.enable": "true"}
+
+	info := ConvertToContainerInfo(cont)
+	cont.Labels["traefik.enable"] = "false"
+
+	if info.Image != "traefik:v3.0" {
+		t.Errorf("expected image 'traefik:v3.0', got '%s'", info.Image)
+	}
+	if info.Labels["traefik.enable"] != "true" {
+		t.Errorf("expected labels to be copied, got %v", info.Labels)
+	}
+}
+
+// TestClient_FetchContainerEnv tests reading a container's environment.
+func TestClient_FetchContainerEnv(t *testing.T) {
+	mock := &mockAPIClient{
+		containerInspectFunc: func(ctx context.Context, containerID string) (types.ContainerJSON, error) {
+			return types.ContainerJSON{
+				Config: &container.Config{Env: []string{"VIRTUAL_HOST=shop.example.com", "PATH=/bin", "EMPTY"}},
+			}, nil
+		},
+	}
+
+	c, err := NewClient(WithDockerClient(mock))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	env, err := c.FetchContainerEnv(context.Background(), "abc123")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if env["VIRTUAL_HOST"] != "shop.example.com" || env["PATH"] != "/bin" {
+		t.Errorf("unexpected environment: %v", env)
+	}
+	if value, ok := env["EMPTY"]; !ok || value != "" {
+		t.Errorf("expected EMPTY to be set to an empty value, got %q (%v)", value, ok)
+	}
+}
+
+// TestClient_FetchContainerEnv_Error tests error handling when inspection fails.
+func TestClient_FetchContainerEnv_Error(t *testing.T) {
+	mock := &mockAPIClient{
+		containerInspectFunc: func(ctx context.Context, containerID string) (types.ContainerJSON, error) {
+			return types.ContainerJSON{}, errors.New("container not found")
+		},
+	}
+
+	c, err := NewClient(WithDockerClient(mock))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if _, err := c.FetchContainerEnv(context.Background(), "nonexistent"); err == nil {
+		t.Error("expected error, got nil")
+	}
+}
+
+// TestClient_FetchContainerPortBindings tests reading a stopped container's port bindings.
+func TestClient_FetchContainerPortBindings(t *testing.T) {
+	mock := &mockAPIClient{
+		containerInspectFunc: func(ctx context.Context, containerID string) (types.ContainerJSON, error) {
+			return types.ContainerJSON{
+				ContainerJSONBase: &types.ContainerJSONBase{
+					HostConfig: &container.HostConfig{PortBindings: nat.PortMap{
+						"80/tcp": {{HostIP: "127.0.0.1", HostPort: "8080"}},
+					}},
+				},
+			}, nil
+		},
+	}
+
+	c, err := NewClient(WithDockerClient(mock))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	ports, err := c.FetchContainerPortBindings(context.Background(), "abc123")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := []models.PortInfo{{IP: "127.0.0.1", PrivatePort: 80, PublicPort: 8080, Type: "tcp"}}
+	if !reflect.DeepEqual(ports, want) {
+		t.Errorf("unexpected port bindings: %+v", ports)
+	}
+}
+
+// TestClient_FetchContainerPortBindings_Error tests error handling when inspection fails.
+func TestClient_FetchContainerPortBindings_Error(t *testing.T) {
+	mock := &mockAPIClient{
+		containerInspectFunc: func(ctx context.Context, containerID string) (types.ContainerJSON, error) {
+			return types.ContainerJSON{}, errors.New("container not found")
+		},
+	}
+
+	c, err := NewClient(WithDockerClient(mock))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if _, err := c.FetchContainerPortBindings(context.Background(), "nonexistent"); err == nil {
+		t.Error("expected error, got nil")
+	}
+}
+
+// TestConvertPortBindings tests converting and sorting port bindings.
+func TestConvertPortBindings(t *testing.T) {
+	bindings := nat.PortMap{
+		"443/tcp":  {{HostIP: "0.0.0.0", HostPort: "443"}, {HostIP: "::", HostPort: "443"}},
+		"53/udp":   {{HostPort: "53"}},
+		"80/tcp":   {{HostIP: "0.0.0.0", HostPort: "8080"}},
+		"9000/tcp": {{HostIP: "0.0.0.0", HostPort: ""}},
+	}
+
+	want := []models.PortInfo{
+		{IP: "", PrivatePort: 53, PublicPort: 53, Type: "udp"},
+		{IP: "0.0.0.0", PrivatePort: 443, PublicPort: 443, Type: "tcp"},
+		{IP: "::", PrivatePort: 443, PublicPort: 443, Type: "tcp"},
+		{IP: "0.0.0.0", PrivatePort: 80, PublicPort: 8080, Type: "tcp"},
+	}
+	if got := ConvertPortBindings(bindings); !reflect.DeepEqual(got, want) {
+		t.Errorf("ConvertPortBindings =\n%+v\nwant\n%+v", got, want)
+	}
+
+	if got := ConvertPortBindings(nil); got != nil {
+		t.Errorf("expected no ports for no bindings, got %+v", got)
+	}
+}
+
 // TestConvertContainersToContainerInfos tests bulk conversion of containers.
 func TestConvertContainersToContainerInfos(t *testing.T) {
 	containers := []types.Container{
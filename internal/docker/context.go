@@ -0,0 +1,128 @@
+// Package docker provides a wrapper around the Docker SDK client.
+// This file reads the Docker CLI context store.
+package docker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/docker/docker/client"
+)
+
+// DefaultContext is the name of the Docker CLI context that uses the
+// DOCKER_* environment variables and the local daemon.
+const DefaultContext = "default"
+
+// Docker CLI environment variables.
+const (
+	// envConfigDir overrides the Docker CLI configuration directory.
+	envConfigDir = "DOCKER_CONFIG"
+
+	// envContext overrides the current Docker CLI context.
+	envContext = "DOCKER_CONTEXT"
+)
+
+// cliConfig is the part of the Docker CLI config.json read here.
+type cliConfig struct {
+	CurrentContext string `json:"currentContext"`
+}
+
+// contextMeta is the part of a context's meta.json read here.
+type contextMeta struct {
+	Name      string `json:"Name"`
+	Endpoints map[string]struct {
+		Host          string `json:"Host"`
+		SkipTLSVerify bool   `json:"SkipTLSVerify"`
+	} `json:"Endpoints"`
+}
+
+// ConfigDir returns the Docker CLI configuration directory: DOCKER_CONFIG,
+// or ~/.docker.
+func ConfigDir() string {
+	if dir := os.Getenv(envConfigDir); dir != "" {
+		return dir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".docker"
+	}
+	return filepath.Join(home, ".docker")
+}
+
+// CurrentContext returns the Docker CLI context in use: DOCKER_CONTEXT, the
+// currentContext of config.json in configDir, or DefaultContext. Like the
+// Docker CLI, DOCKER_HOST selects DefaultContext over config.json.
+func CurrentContext(configDir string) (string, error) {
+	if name := os.Getenv(envContext); name != "" {
+		return name, nil
+	}
+	if os.Getenv(client.EnvOverrideHost) != "" {
+		return DefaultContext, nil
+	}
+
+	data, err := os.ReadFile(filepath.Join(configDir, "config.json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return DefaultContext, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read Docker CLI config: %w", err)
+	}
+
+	var cfg cliConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return "", fmt.Errorf("failed to parse Docker CLI config: %w", err)
+	}
+	if cfg.CurrentContext == "" {
+		return DefaultContext, nil
+	}
+
+	return cfg.CurrentContext, nil
+}
+
+// ContextConnection returns the connection settings of the docker endpoint
+// of a Docker CLI context in configDir. The default context has no settings
+// of its own and returns an empty configuration.
+//
+// Contexts are stored under contexts/meta/<sha256 of name>/meta.json, with
+// their TLS material under contexts/tls/<sha256 of name>/docker/.
+func ContextConnection(configDir, name string) (ConnectionConfig, error) {
+	if name == "" || name == DefaultContext {
+		return ConnectionConfig{}, nil
+	}
+
+	sum := sha256.Sum256([]byte(name))
+	id := hex.EncodeToString(sum[:])
+
+	data, err := os.ReadFile(filepath.Join(configDir, "contexts", "meta", id, "meta.json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return ConnectionConfig{}, fmt.Errorf("docker context %q not found", name)
+	}
+	if err != nil {
+		return ConnectionConfig{}, fmt.Errorf("failed to read docker context %q: %w", name, err)
+	}
+
+	var meta contextMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return ConnectionConfig{}, fmt.Errorf("failed to parse docker context %q: %w", name, err)
+	}
+
+	endpoint, ok := meta.Endpoints["docker"]
+	if !ok {
+		return ConnectionConfig{}, fmt.Errorf("docker context %q has no docker endpoint", name)
+	}
+
+	cfg := ConnectionConfig{Host: endpoint.Host}
+
+	tlsDir := filepath.Join(configDir, "contexts", "tls", id, "docker")
+	cfg.TLSCACert = existingFile(tlsDir, caCertFile)
+	cfg.TLSCert = existingFile(tlsDir, clientCertFile)
+	cfg.TLSKey = existingFile(tlsDir, clientKeyFile)
+	cfg.TLSVerify = cfg.usesTLS() && !endpoint.SkipTLSVerify
+
+	return cfg, nil
+}
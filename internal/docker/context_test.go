@@ -0,0 +1,131 @@
+// Package docker provides tests for reading the Docker CLI context store.
+package docker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeContext writes a Docker CLI context to configDir, optionally with a CA
+// certificate.
+func writeContext(t *testing.T, configDir, name, host string, skipVerify, withCA bool) {
+	t.Helper()
+
+	sum := sha256.Sum256([]byte(name))
+	id := hex.EncodeToString(sum[:])
+
+	metaDir := filepath.Join(configDir, "contexts", "meta", id)
+	if err := os.MkdirAll(metaDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	meta := fmt.Sprintf(`{"Name":%q,"Metadata":{},"Endpoints":{"docker":{"Host":%q,"SkipTLSVerify":%t}}}`,
+		name, host, skipVerify)
+	if err := os.WriteFile(filepath.Join(metaDir, "meta.json"), []byte(meta), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if withCA {
+		tlsDir := filepath.Join(configDir, "contexts", "tls", id, "docker")
+		if err := os.MkdirAll(tlsDir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(tlsDir, caCertFile), nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// TestConfigDir tests the DOCKER_CONFIG override.
+func TestConfigDir(t *testing.T) {
+	t.Setenv("DOCKER_CONFIG", "/etc/docker-cli")
+
+	if got := ConfigDir(); got != "/etc/docker-cli" {
+		t.Errorf("expected /etc/docker-cli, got %s", got)
+	}
+}
+
+// TestCurrentContext tests choosing the current context.
+func TestCurrentContext(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("DOCKER_CONTEXT", "")
+	t.Setenv("DOCKER_HOST", "")
+
+	name, err := CurrentContext(dir)
+	if err != nil || name != DefaultContext {
+		t.Errorf("expected default without config.json, got %q (%v)", name, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "config.json"), []byte(`{"currentContext":"remote"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	name, err = CurrentContext(dir)
+	if err != nil || name != "remote" {
+		t.Errorf("expected remote from config.json, got %q (%v)", name, err)
+	}
+
+	t.Setenv("DOCKER_HOST", "tcp://env:2375")
+	name, err = CurrentContext(dir)
+	if err != nil || name != DefaultContext {
+		t.Errorf("expected default with DOCKER_HOST, got %q (%v)", name, err)
+	}
+
+	t.Setenv("DOCKER_CONTEXT", "staging")
+	name, err = CurrentContext(dir)
+	if err != nil || name != "staging" {
+		t.Errorf("expected staging from DOCKER_CONTEXT, got %q (%v)", name, err)
+	}
+}
+
+// TestCurrentContext_InvalidConfig tests a malformed config.json.
+func TestCurrentContext_InvalidConfig(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("DOCKER_CONTEXT", "")
+	t.Setenv("DOCKER_HOST", "")
+	if err := os.WriteFile(filepath.Join(dir, "config.json"), []byte(`{`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := CurrentContext(dir); err == nil {
+		t.Error("expected error for malformed config.json")
+	}
+}
+
+// TestContextConnection tests reading a context's docker endpoint.
+func TestContextConnection(t *testing.T) {
+	dir := t.TempDir()
+	writeContext(t, dir, "plain", "tcp://build-1:2375", false, false)
+	writeContext(t, dir, "tls", "tcp://build-1:2376", false, true)
+	writeContext(t, dir, "insecure", "tcp://build-1:2376", true, true)
+
+	tests := []struct {
+		name      string
+		host      string
+		tlsVerify bool
+		tls       bool
+	}{
+		{"default", "", false, false},
+		{"plain", "tcp://build-1:2375", false, false},
+		{"tls", "tcp://build-1:2376", true, true},
+		{"insecure", "tcp://build-1:2376", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := ContextConnection(dir, tt.name)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if cfg.Host != tt.host || cfg.TLSVerify != tt.tlsVerify || cfg.usesTLS() != tt.tls {
+				t.Errorf("unexpected configuration: %+v", cfg)
+			}
+		})
+	}
+
+	if _, err := ContextConnection(dir, "missing"); err == nil {
+		t.Error("expected error for unknown context")
+	}
+}
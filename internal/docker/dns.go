@@ -0,0 +1,275 @@
+// Package docker provides a wrapper around the Docker SDK client.
+// This file contains the logic for building a container's DNS view.
+package docker
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// BuildContainerDNS builds the DNS view of the named container: every name it
+// can resolve on each of its networks and the containers those names resolve
+// to.
+//
+// On user-defined networks, Docker's embedded DNS server resolves the names
+// and aliases of running containers on the same network, including Compose
+// service names. On every network, the container's legacy links resolve to
+// their targets. Short container IDs are not listed. A container sharing
+// another container's network namespace sees that container's view.
+//
+// Returns an error if no container has the given name.
+func (c *Client) BuildContainerDNS(containers []types.Container, name string) (*models.ContainerDNS, error) {
+	byName := make(map[string]types.Container, len(containers))
+	for _, cont := range containers {
+		byName[sanitizeContainerName(cont.Names)] = cont
+	}
+
+	self, ok := byName[name]
+	if !ok {
+		return nil, fmt.Errorf("container %s not found", name)
+	}
+
+	owner := namespaceOwner(self, containers)
+	result := &models.ContainerDNS{Container: name}
+
+	if owner.NetworkSettings == nil {
+		return result, nil
+	}
+
+	netNames := make([]string, 0, len(owner.NetworkSettings.Networks))
+	for netName := range owner.NetworkSettings.Networks {
+		netNames = append(netNames, netName)
+	}
+	sort.Strings(netNames)
+
+	for _, netName := range netNames {
+		nd := models.NetworkDNS{
+			Network:     netName,
+			EmbeddedDNS: models.HasEmbeddedDNS(netName),
+		}
+
+		records := make(map[string]*models.DNSRecord)
+		if nd.EmbeddedDNS {
+			for _, cont := range containers {
+				addContainerRecords(records, cont, netName)
+			}
+		}
+
+		if ep := owner.NetworkSettings.Networks[netName]; ep != nil {
+			for _, link := range ep.Links {
+				target, alias := parseLink(link)
+				if cont, ok := byName[target]; ok {
+					addRecord(records, target, models.DNSSourceLink, cont, netName)
+					addRecord(records, alias, models.DNSSourceLink, cont, netName)
+				}
+			}
+		}
+
+		for _, rec := range records {
+			nd.Records = append(nd.Records, *rec)
+		}
+		sort.Slice(nd.Records, func(i, j int) bool {
+			return nd.Records[i].Name < nd.Records[j].Name
+		})
+
+		result.Networks = append(result.Networks, nd)
+	}
+
+	result.Conflicts = dnsConflicts(result.Networks)
+
+	return result, nil
+}
+
+// addContainerRecords adds the names a container registers on a network with
+// the embedded DNS server. Containers that are not running are skipped, as
+// Docker removes their DNS entries.
+func addContainerRecords(records map[string]*models.DNSRecord, cont types.Container, netName string) {
+	if cont.State != "running" && cont.State != "paused" {
+		return
+	}
+	if cont.NetworkSettings == nil {
+		return
+	}
+
+	ep, ok := cont.NetworkSettings.Networks[netName]
+	if !ok {
+		return
+	}
+
+	name := sanitizeContainerName(cont.Names)
+	addRecord(records, name, models.DNSSourceName, cont, netName)
+
+	if ep == nil {
+		return
+	}
+
+	service := cont.Labels[composeServiceLabel]
+	for _, alias := range append(append([]string{}, ep.Aliases...), ep.DNSNames...) {
+		switch {
+		case alias == name:
+			continue
+		case models.IsShortID(cont.ID, alias):
+			continue
+		case alias == service:
+			addRecord(records, alias, models.DNSSourceService, cont, netName)
+		default:
+			addRecord(records, alias, models.DNSSourceAlias, cont, netName)
+		}
+	}
+}
+
+// addRecord registers that name resolves to cont on the network, merging
+// sources and targets with any existing record for the name.
+func addRecord(records map[string]*models.DNSRecord, name, source string, cont types.Container, netName string) {
+	rec, ok := records[name]
+	if !ok {
+		rec = &models.DNSRecord{Name: name}
+		records[name] = rec
+	}
+
+	if !slices.Contains(rec.Sources, source) {
+		rec.Sources = append(rec.Sources, source)
+		sort.Strings(rec.Sources)
+	}
+
+	target := sanitizeContainerName(cont.Names)
+	for _, t := range rec.Targets {
+		if t.Container == target {
+			return
+		}
+	}
+
+	rec.Targets = append(rec.Targets, models.DNSTarget{
+		Container: target,
+		IPs:       endpointIPs(cont, netName),
+	})
+	sort.Slice(rec.Targets, func(i, j int) bool {
+		return rec.Targets[i].Container < rec.Targets[j].Container
+	})
+}
+
+// EndpointAddress returns the first address of the named container on a
+// network, or an empty string when it is not attached or has none.
+func EndpointAddress(containers []types.Container, name, netName string) string {
+	for _, cont := range containers {
+		if sanitizeContainerName(cont.Names) != name {
+			continue
+		}
+		if ips := endpointIPs(cont, netName); len(ips) > 0 {
+			return ips[0]
+		}
+	}
+	return ""
+}
+
+// endpointIPs returns a container's IPv4 and global IPv6 addresses on a network.
+func endpointIPs(cont types.Container, netName string) []string {
+	if cont.NetworkSettings == nil {
+		return nil
+	}
+
+	ep := cont.NetworkSettings.Networks[netName]
+	if ep == nil {
+		return nil
+	}
+
+	var ips []string
+	if ep.IPAddress != "" {
+		ips = append(ips, ep.IPAddress)
+	}
+	if ep.GlobalIPv6Address != "" {
+		ips = append(ips, ep.GlobalIPv6Address)
+	}
+	return ips
+}
+
+// dnsConflicts finds names that resolve to different containers on different
+// networks with embedded DNS.
+func dnsConflicts(networks []models.NetworkDNS) []models.DNSConflict {
+	targets := make(map[string]map[string][]string)
+
+	for _, nd := range networks {
+		if !nd.EmbeddedDNS {
+			continue
+		}
+		for _, rec := range nd.Records {
+			names := make([]string, len(rec.Targets))
+			for i, t := range rec.Targets {
+				names[i] = t.Container
+			}
+			if targets[rec.Name] == nil {
+				targets[rec.Name] = make(map[string][]string)
+			}
+			targets[rec.Name][nd.Network] = names
+		}
+	}
+
+	var conflicts []models.DNSConflict
+	for name, perNetwork := range targets {
+		if len(perNetwork) < 2 || !differ(perNetwork) {
+			continue
+		}
+		conflicts = append(conflicts, models.DNSConflict{Name: name, Networks: perNetwork})
+	}
+
+	sort.Slice(conflicts, func(i, j int) bool {
+		return conflicts[i].Name < conflicts[j].Name
+	})
+
+	return conflicts
+}
+
+// differ reports whether the container lists are not all identical.
+func differ(perNetwork map[string][]string) bool {
+	var first string
+	seen := false
+	for _, names := range perNetwork {
+		key := strings.Join(names, ",")
+		if !seen {
+			first, seen = key, true
+			continue
+		}
+		if key != first {
+			return true
+		}
+	}
+	return false
+}
+
+// namespaceOwner returns the container whose network namespace cont uses:
+// the target of a "container:" network mode, or cont itself.
+func namespaceOwner(cont types.Container, containers []types.Container) types.Container {
+	target, ok := strings.CutPrefix(cont.HostConfig.NetworkMode, "container:")
+	if !ok {
+		return cont
+	}
+
+	for _, other := range containers {
+		if other.ID == target || sanitizeContainerName(other.Names) == target {
+			return other
+		}
+	}
+
+	return cont
+}
+
+// parseLink splits a legacy link into its target container and alias. Both
+// the "target:alias" form and the "/target:/source/alias" form are accepted.
+func parseLink(link string) (string, string) {
+	target, alias, ok := strings.Cut(link, ":")
+	target = strings.TrimPrefix(target, "/")
+	if !ok {
+		return target, target
+	}
+
+	if i := strings.LastIndex(alias, "/"); i >= 0 {
+		alias = alias[i+1:]
+	}
+	return target, alias
+}
@@ -0,0 +1,203 @@
+// Package docker provides tests for building container DNS views.
+package docker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/network"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// dnsTestContainer creates a running container with the given endpoints.
+func dnsTestContainer(name string, labels map[string]string, endpoints map[string]*network.EndpointSettings) types.Container {
+	return types.Container{
+		ID:              name + "-0123456789abcdef",
+		Names:           []string{"/" + name},
+		State:           "running",
+		Labels:          labels,
+		NetworkSettings: &types.SummaryNetworkSettings{Networks: endpoints},
+	}
+}
+
+// dnsTestContainers returns containers exercising names, aliases, services,
+// links, round-robin names and cross-network conflicts.
+func dnsTestContainers() []types.Container {
+	stopped := dnsTestContainer("old-db", nil, map[string]*network.EndpointSettings{
+		"backend": {Aliases: []string{"db"}},
+	})
+	stopped.State = "exited"
+
+	sidecar := dnsTestContainer("sidecar", nil, map[string]*network.EndpointSettings{})
+	sidecar.HostConfig.NetworkMode = "container:api-0123456789abcdef"
+
+	return []types.Container{
+		dnsTestContainer("api", nil, map[string]*network.EndpointSettings{
+			"backend":  {IPAddress: "172.20.0.2", Aliases: []string{"api", "api-01234567"}},
+			"frontend": {IPAddress: "172.21.0.2"},
+			"bridge":   {IPAddress: "172.17.0.2", Links: []string{"/legacy:/api/old"}},
+		}),
+		dnsTestContainer("postgres", map[string]string{composeServiceLabel: "db"}, map[string]*network.EndpointSettings{
+			"backend": {IPAddress: "172.20.0.3", Aliases: []string{"db", "postgres-012"}, DNSNames: []string{"postgres", "database"}},
+		}),
+		dnsTestContainer("cache-1", nil, map[string]*network.EndpointSettings{
+			"backend": {IPAddress: "172.20.0.5", Aliases: []string{"cache"}},
+		}),
+		dnsTestContainer("cache-2", nil, map[string]*network.EndpointSettings{
+			"backend": {IPAddress: "172.20.0.4", Aliases: []string{"cache"}},
+		}),
+		dnsTestContainer("web", nil, map[string]*network.EndpointSettings{
+			"frontend": {IPAddress: "172.21.0.3", Aliases: []string{"cache"}},
+		}),
+		dnsTestContainer("legacy", nil, map[string]*network.EndpointSettings{
+			"bridge": {IPAddress: "172.17.0.3"},
+		}),
+		stopped,
+		sidecar,
+	}
+}
+
+// findRecord returns the named record on a network of the DNS view.
+func findRecord(t *testing.T, dns *models.ContainerDNS, netName, name string) *models.DNSRecord {
+	t.Helper()
+	for _, nd := range dns.Networks {
+		if nd.Network != netName {
+			continue
+		}
+		for i := range nd.Records {
+			if nd.Records[i].Name == name {
+				return &nd.Records[i]
+			}
+		}
+	}
+	return nil
+}
+
+// TestClient_BuildContainerDNS tests the names resolvable from a container.
+func TestClient_BuildContainerDNS(t *testing.T) {
+	c := &Client{}
+
+	dns, err := c.BuildContainerDNS(dnsTestContainers(), "api")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var networks []string
+	for _, nd := range dns.Networks {
+		networks = append(networks, nd.Network)
+		if nd.Network == "bridge" && nd.EmbeddedDNS {
+			t.Error("expected no embedded DNS on the default bridge network")
+		}
+	}
+	if want := []string{"backend", "bridge", "frontend"}; !reflect.DeepEqual(networks, want) {
+		t.Errorf("expected networks %v, got %v", want, networks)
+	}
+
+	db := findRecord(t, dns, "backend", "db")
+	if db == nil {
+		t.Fatal("expected db record on backend")
+	}
+	if want := []string{models.DNSSourceService}; !reflect.DeepEqual(db.Sources, want) {
+		t.Errorf("expected sources %v, got %v", want, db.Sources)
+	}
+	if len(db.Targets) != 1 || db.Targets[0].Container != "postgres" || db.Targets[0].IPs[0] != "172.20.0.3" {
+		t.Errorf("expected db to resolve to postgres only, got %+v", db.Targets)
+	}
+
+	if findRecord(t, dns, "backend", "database") == nil {
+		t.Error("expected DNSNames to be resolvable")
+	}
+	if findRecord(t, dns, "backend", "postgres-012") != nil || findRecord(t, dns, "backend", "old-db") != nil {
+		t.Error("expected short IDs and stopped containers to be skipped")
+	}
+
+	cache := findRecord(t, dns, "backend", "cache")
+	if cache == nil || !cache.IsAmbiguous() {
+		t.Fatalf("expected cache to be round-robin, got %+v", cache)
+	}
+
+	old := findRecord(t, dns, "bridge", "old")
+	if old == nil || old.Targets[0].Container != "legacy" || old.Sources[0] != models.DNSSourceLink {
+		t.Errorf("expected link alias on bridge, got %+v", old)
+	}
+	if findRecord(t, dns, "bridge", "api") != nil {
+		t.Error("expected container names not to resolve on the default bridge")
+	}
+
+	if len(dns.Conflicts) != 1 || dns.Conflicts[0].Name != "cache" {
+		t.Fatalf("expected a cache conflict, got %+v", dns.Conflicts)
+	}
+	if got := dns.Conflicts[0].Networks["frontend"]; !reflect.DeepEqual(got, []string{"web"}) {
+		t.Errorf("expected cache to resolve to web on frontend, got %v", got)
+	}
+}
+
+// TestClient_BuildContainerDNS_SharedNamespace tests containers using another
+// container's network namespace.
+func TestClient_BuildContainerDNS_SharedNamespace(t *testing.T) {
+	c := &Client{}
+
+	dns, err := c.BuildContainerDNS(dnsTestContainers(), "sidecar")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if dns.Container != "sidecar" || len(dns.Networks) != 3 {
+		t.Errorf("expected sidecar to see api's three networks, got %+v", dns.Networks)
+	}
+}
+
+// TestClient_BuildContainerDNS_NotFound tests an unknown container name.
+func TestClient_BuildContainerDNS_NotFound(t *testing.T) {
+	c := &Client{}
+
+	if _, err := c.BuildContainerDNS(dnsTestContainers(), "missing"); err == nil {
+		t.Error("expected error for unknown container")
+	}
+}
+
+// TestParseLink tests both legacy link formats.
+func TestParseLink(t *testing.T) {
+	tests := []struct {
+		link, target, alias string
+	}{
+		{"db:database", "db", "database"},
+		{"/db:/web/database", "db", "database"},
+		{"db", "db", "db"},
+	}
+
+	for _, tt := range tests {
+		target, alias := parseLink(tt.link)
+		if target != tt.target || alias != tt.alias {
+			t.Errorf("parseLink(%q) = %q, %q; want %q, %q", tt.link, target, alias, tt.target, tt.alias)
+		}
+	}
+}
+
+// TestEndpointAddress tests looking up a container's address on a network.
+func TestEndpointAddress(t *testing.T) {
+	containers := dnsTestContainers()
+
+	tests := []struct {
+		name      string
+		container string
+		network   string
+		expected  string
+	}{
+		{"attached", "legacy", "bridge", "172.17.0.3"},
+		{"other network", "api", "frontend", "172.21.0.2"},
+		{"not attached", "legacy", "backend", ""},
+		{"no address", "old-db", "backend", ""},
+		{"unknown container", "ghost", "bridge", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EndpointAddress(containers, tt.container, tt.network); got != tt.expected {
+				t.Errorf("EndpointAddress(%s, %s) = %q, want %q", tt.container, tt.network, got, tt.expected)
+			}
+		})
+	}
+}
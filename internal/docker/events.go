@@ -0,0 +1,31 @@
+// Package docker provides Docker client wrapper functionality.
+package docker
+
+import (
+	"context"
+
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+)
+
+// topologyEventTypes are the Docker event types that can change the network topology.
+var topologyEventTypes = []events.Type{
+	events.ContainerEventType,
+	events.NetworkEventType,
+}
+
+// WatchEvents subscribes to Docker daemon events that can change the network
+// topology, such as containers starting or stopping and containers being
+// connected to or disconnected from networks.
+//
+// It returns a channel of event messages and a channel that receives a single
+// error when the subscription ends. Both channels stop delivering when the
+// context is cancelled.
+func (c *Client) WatchEvents(ctx context.Context) (<-chan events.Message, <-chan error) {
+	args := filters.NewArgs()
+	for _, t := range topologyEventTypes {
+		args.Add("type", string(t))
+	}
+
+	return c.cli.Events(ctx, events.ListOptions{Filters: args})
+}
@@ -0,0 +1,46 @@
+// Package docker provides tests for the Docker events wrapper.
+package docker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/docker/api/types/events"
+)
+
+// TestClient_WatchEvents tests that only topology-related events are requested.
+func TestClient_WatchEvents(t *testing.T) {
+	var gotOpts events.ListOptions
+	messages := make(chan events.Message, 1)
+	messages <- events.Message{Type: events.NetworkEventType, Action: events.ActionConnect}
+
+	mock := &mockAPIClient{
+		eventsFunc: func(ctx context.Context, opts events.ListOptions) (<-chan events.Message, <-chan error) {
+			gotOpts = opts
+			return messages, make(chan error)
+		},
+	}
+
+	c, err := NewClient(WithDockerClient(mock))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	msgs, _ := c.WatchEvents(context.Background())
+
+	types := gotOpts.Filters.Get("type")
+	if len(types) != 2 {
+		t.Fatalf("expected 2 type filters, got %v", types)
+	}
+	if types[0] != string(events.ContainerEventType) && types[1] != string(events.ContainerEventType) {
+		t.Errorf("expected filter for container events, got %v", types)
+	}
+	if types[0] != string(events.NetworkEventType) && types[1] != string(events.NetworkEventType) {
+		t.Errorf("expected filter for network events, got %v", types)
+	}
+
+	msg := <-msgs
+	if msg.Action != events.ActionConnect {
+		t.Errorf("expected connect event, got %q", msg.Action)
+	}
+}
@@ -0,0 +1,70 @@
+// Package docker provides Docker client wrapper functionality.
+package docker
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// execPollInterval is how often a finished exec is inspected until the
+// daemon reports its exit code.
+const execPollInterval = 20 * time.Millisecond
+
+// ExecCommand runs a command inside a running container, without a shell or
+// TTY, and returns its exit code and combined standard output and error. The
+// command is abandoned when the context ends, although the process may keep
+// running inside the container.
+func (c *Client) ExecCommand(ctx context.Context, containerID string, cmd []string) (int, string, error) {
+	exec, err := c.cli.ContainerExecCreate(ctx, containerID, container.ExecOptions{
+		Cmd:          cmd,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to create exec in container %s: %w", containerID, err)
+	}
+
+	resp, err := c.cli.ContainerExecAttach(ctx, exec.ID, container.ExecAttachOptions{})
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to start exec in container %s: %w", containerID, err)
+	}
+	defer resp.Close()
+
+	var out bytes.Buffer
+	done := make(chan error, 1)
+	go func() {
+		_, err := stdcopy.StdCopy(&out, &out, resp.Reader)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return 0, "", fmt.Errorf("failed to read exec output in container %s: %w", containerID, err)
+		}
+	case <-ctx.Done():
+		resp.Close()
+		return 0, "", ctx.Err()
+	}
+
+	for {
+		inspect, err := c.cli.ContainerExecInspect(ctx, exec.ID)
+		if err != nil {
+			return 0, "", fmt.Errorf("failed to inspect exec in container %s: %w", containerID, err)
+		}
+		if !inspect.Running {
+			return inspect.ExitCode, out.String(), nil
+		}
+
+		select {
+		case <-time.After(execPollInterval):
+		case <-ctx.Done():
+			return 0, "", ctx.Err()
+		}
+	}
+}
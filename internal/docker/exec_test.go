@@ -0,0 +1,132 @@
+// Package docker provides tests for running commands in containers.
+package docker
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// hijackedOutput returns an attached exec stream that delivers the given
+// standard output and error, multiplexed as the daemon does, and then ends.
+func hijackedOutput(t *testing.T, stdout, stderr string) types.HijackedResponse {
+	t.Helper()
+	server, conn := net.Pipe()
+	go func() {
+		defer server.Close()
+		if stdout != "" {
+			_, _ = stdcopy.NewStdWriter(server, stdcopy.Stdout).Write([]byte(stdout))
+		}
+		if stderr != "" {
+			_, _ = stdcopy.NewStdWriter(server, stdcopy.Stderr).Write([]byte(stderr))
+		}
+	}()
+	return types.NewHijackedResponse(conn, "")
+}
+
+// TestClient_ExecCommand tests running a command and collecting its result.
+func TestClient_ExecCommand(t *testing.T) {
+	var inspections atomic.Int32
+	mock := &mockAPIClient{
+		execCreateFunc: func(ctx context.Context, containerID string, opts container.ExecOptions) (types.IDResponse, error) {
+			if containerID != "api" {
+				t.Errorf("expected container api, got %s", containerID)
+			}
+			if !reflect.DeepEqual(opts.Cmd, []string{"getent", "hosts", "db"}) {
+				t.Errorf("unexpected command %q", opts.Cmd)
+			}
+			if !opts.AttachStdout || !opts.AttachStderr || opts.Tty {
+				t.Error("expected stdout and stderr attached without a TTY")
+			}
+			return types.IDResponse{ID: "exec-1"}, nil
+		},
+		execAttachFunc: func(ctx context.Context, execID string, opts container.ExecAttachOptions) (types.HijackedResponse, error) {
+			return hijackedOutput(t, "172.18.0.3 db\n", "warning\n"), nil
+		},
+		execInspectFunc: func(ctx context.Context, execID string) (container.ExecInspect, error) {
+			if execID != "exec-1" {
+				t.Errorf("expected exec-1, got %s", execID)
+			}
+			// The daemon may report the exec as running briefly after its
+			// output ends.
+			if inspections.Add(1) == 1 {
+				return container.ExecInspect{Running: true}, nil
+			}
+			return container.ExecInspect{ExitCode: 2}, nil
+		},
+	}
+
+	c, err := NewClient(WithDockerClient(mock))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	code, out, err := c.ExecCommand(context.Background(), "api", []string{"getent", "hosts", "db"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if code != 2 {
+		t.Errorf("expected exit code 2, got %d", code)
+	}
+	if out != "172.18.0.3 db\nwarning\n" {
+		t.Errorf("unexpected output %q", out)
+	}
+}
+
+// TestClient_ExecCommand_CreateError tests that create failures are wrapped.
+func TestClient_ExecCommand_CreateError(t *testing.T) {
+	mock := &mockAPIClient{
+		execCreateFunc: func(ctx context.Context, containerID string, opts container.ExecOptions) (types.IDResponse, error) {
+			return types.IDResponse{}, errors.New("container is not running")
+		},
+	}
+
+	c, err := NewClient(WithDockerClient(mock))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	_, _, err = c.ExecCommand(context.Background(), "api", []string{"true"})
+	if err == nil || !strings.Contains(err.Error(), "failed to create exec in container api") {
+		t.Errorf("expected wrapped create error, got %v", err)
+	}
+}
+
+// TestClient_ExecCommand_ContextCanceled tests that a hanging command is
+// abandoned when the context ends.
+func TestClient_ExecCommand_ContextCanceled(t *testing.T) {
+	mock := &mockAPIClient{
+		execCreateFunc: func(ctx context.Context, containerID string, opts container.ExecOptions) (types.IDResponse, error) {
+			return types.IDResponse{ID: "exec-1"}, nil
+		},
+		execAttachFunc: func(ctx context.Context, execID string, opts container.ExecAttachOptions) (types.HijackedResponse, error) {
+			// The server side never writes, like a command that hangs.
+			server, conn := net.Pipe()
+			t.Cleanup(func() { _ = server.Close() })
+			return types.HijackedResponse{Conn: conn, Reader: bufio.NewReader(conn)}, nil
+		},
+	}
+
+	c, err := NewClient(WithDockerClient(mock))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, _, err = c.ExecCommand(ctx, "api", []string{"sleep", "60"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
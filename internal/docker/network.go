@@ -68,10 +68,83 @@ func (c *Client) FetchNetworkByName(ctx context.Context, name string) (network.I
 	return net, nil
 }
 
+// RemoveNetwork removes a Docker network by ID or name. The daemon refuses to
+// remove networks with active endpoints.
+func (c *Client) RemoveNetwork(ctx context.Context, networkID string) error {
+	if err := c.cli.NetworkRemove(ctx, networkID); err != nil {
+		return fmt.Errorf("failed to remove Docker network %s: %w", networkID, err)
+	}
+
+	return nil
+}
+
+// ConnectOptions configures the endpoint created by ConnectNetwork.
+type ConnectOptions struct {
+	// Aliases are additional network-scoped names for the container.
+	Aliases []string
+
+	// IPv4Address is a static IPv4 address for the endpoint; empty for automatic allocation.
+	IPv4Address string
+
+	// IPv6Address is a static IPv6 address for the endpoint; empty for automatic allocation.
+	IPv6Address string
+}
+
+// ConnectNetwork connects a container to a network. Both may be given by ID
+// or name. Pass nil options for an endpoint without aliases or static addresses.
+func (c *Client) ConnectNetwork(ctx context.Context, networkID, containerID string, opts *ConnectOptions) error {
+	settings := &network.EndpointSettings{}
+	if opts != nil {
+		settings.Aliases = opts.Aliases
+		if opts.IPv4Address != "" || opts.IPv6Address != "" {
+			settings.IPAMConfig = &network.EndpointIPAMConfig{
+				IPv4Address: opts.IPv4Address,
+				IPv6Address: opts.IPv6Address,
+			}
+		}
+	}
+
+	if err := c.cli.NetworkConnect(ctx, networkID, containerID, settings); err != nil {
+		return fmt.Errorf("failed to connect container %s to network %s: %w", containerID, networkID, err)
+	}
+
+	return nil
+}
+
+// DisconnectNetwork disconnects a container from a network. Both may be given
+// by ID or name. With force, the container is disconnected even if it is not
+// running.
+func (c *Client) DisconnectNetwork(ctx context.Context, networkID, containerID string, force bool) error {
+	if err := c.cli.NetworkDisconnect(ctx, networkID, containerID, force); err != nil {
+		return fmt.Errorf("failed to disconnect container %s from network %s: %w", containerID, networkID, err)
+	}
+
+	return nil
+}
+
 // ConvertToNetworkInfo converts a Docker network.Summary to our internal NetworkInfo model.
 // This decouples the output package from Docker API types.
 func ConvertToNetworkInfo(net network.Summary) *models.NetworkInfo {
-	return models.NewNetworkInfo(net.Name, net.Driver)
+	ni := models.NewNetworkInfo(net.Name, net.Driver)
+	ni.ID = net.ID
+	ni.Internal = net.Internal
+	ni.Options = net.Options
+	ni.Project = net.Labels[composeProjectLabel]
+
+	for _, cfg := range net.IPAM.Config {
+		subnet := models.SubnetInfo{
+			Subnet:  cfg.Subnet,
+			IPRange: cfg.IPRange,
+			Gateway: cfg.Gateway,
+		}
+		for _, addr := range cfg.AuxAddress {
+			subnet.AuxAddresses = append(subnet.AuxAddresses, addr)
+		}
+		sort.Strings(subnet.AuxAddresses)
+		ni.Subnets = append(ni.Subnets, subnet)
+	}
+
+	return ni
 }
 
 // ConvertNetworksToNetworkInfos converts a slice of Docker network summaries
@@ -211,15 +211,144 @@ func TestClient_FetchNetworkByName_Error(t *testing.T) {
 	}
 }
 
+// TestClient_RemoveNetwork tests network removal and its error handling.
+func TestClient_RemoveNetwork(t *testing.T) {
+	var removed string
+	mock := &mockAPIClient{
+		networkRemoveFunc: func(ctx context.Context, networkID string) error {
+			if networkID == "busy" {
+				return errors.New("network has active endpoints")
+			}
+			removed = networkID
+			return nil
+		},
+	}
+
+	c, err := NewClient(WithDockerClient(mock))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if err := c.RemoveNetwork(context.Background(), "abc123"); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if removed != "abc123" {
+		t.Errorf("expected network 'abc123' to be removed, got '%s'", removed)
+	}
+
+	if err := c.RemoveNetwork(context.Background(), "busy"); err == nil {
+		t.Error("expected error, got nil")
+	}
+}
+
+// TestClient_ConnectNetwork tests connecting a container with aliases and a static address.
+func TestClient_ConnectNetwork(t *testing.T) {
+	var got *network.EndpointSettings
+	mock := &mockAPIClient{
+		networkConnectFunc: func(ctx context.Context, networkID, containerID string, config *network.EndpointSettings) error {
+			if networkID != "backend" || containerID != "api" {
+				t.Errorf("unexpected arguments: %s, %s", networkID, containerID)
+			}
+			got = config
+			return nil
+		},
+	}
+
+	c, err := NewClient(WithDockerClient(mock))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	err = c.ConnectNetwork(context.Background(), "backend", "api", &ConnectOptions{
+		Aliases:     []string{"api.internal"},
+		IPv4Address: "172.20.0.10",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(got.Aliases) != 1 || got.Aliases[0] != "api.internal" {
+		t.Errorf("unexpected aliases: %v", got.Aliases)
+	}
+	if got.IPAMConfig == nil || got.IPAMConfig.IPv4Address != "172.20.0.10" {
+		t.Errorf("unexpected IPAM config: %+v", got.IPAMConfig)
+	}
+
+	if err := c.ConnectNetwork(context.Background(), "backend", "api", nil); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got.IPAMConfig != nil || len(got.Aliases) != 0 {
+		t.Errorf("expected empty endpoint settings, got %+v", got)
+	}
+}
+
+// TestClient_ConnectNetwork_Error tests error handling when connecting fails.
+func TestClient_ConnectNetwork_Error(t *testing.T) {
+	mock := &mockAPIClient{
+		networkConnectFunc: func(ctx context.Context, networkID, containerID string, config *network.EndpointSettings) error {
+			return errors.New("container already connected")
+		},
+	}
+
+	c, err := NewClient(WithDockerClient(mock))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if err := c.ConnectNetwork(context.Background(), "backend", "api", nil); err == nil {
+		t.Error("expected error, got nil")
+	}
+}
+
+// TestClient_DisconnectNetwork tests disconnecting a container and its error handling.
+func TestClient_DisconnectNetwork(t *testing.T) {
+	mock := &mockAPIClient{
+		networkDisconnectFunc: func(ctx context.Context, networkID, containerID string, force bool) error {
+			if !force {
+				return errors.New("container is not running")
+			}
+			return nil
+		},
+	}
+
+	c, err := NewClient(WithDockerClient(mock))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if err := c.DisconnectNetwork(context.Background(), "backend", "api", true); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if err := c.DisconnectNetwork(context.Background(), "backend", "api", false); err == nil {
+		t.Error("expected error, got nil")
+	}
+}
+
 // TestConvertToNetworkInfo tests conversion of Docker network summary to internal model.
 func TestConvertToNetworkInfo(t *testing.T) {
 	summary := network.Summary{
-		Name:   "test_net",
-		Driver: "bridge",
+		ID:       "abc123",
+		Name:     "test_net",
+		Driver:   "bridge",
+		Internal: true,
+		Labels:   map[string]string{"com.docker.compose.project": "shop"},
+		Options:  map[string]string{"com.docker.network.bridge.enable_icc": "false"},
 	}
 
 	info := ConvertToNetworkInfo(summary)
 
+	if info.ICCEnabled() {
+		t.Error("expected inter-container communication to be disabled")
+	}
+
+	if info.ID != "abc123" || info.Project != "shop" {
+		t.Errorf("expected ID 'abc123' and project 'shop', got '%s' and '%s'", info.ID, info.Project)
+	}
+
+	if !info.Internal {
+		t.Error("expected network to be internal")
+	}
+
 	if info.Name != "test_net" {
 		t.Errorf("expected name 'test_net', got '%s'", info.Name)
 	}
@@ -229,6 +358,39 @@ func TestConvertToNetworkInfo(t *testing.T) {
 	}
 }
 
+// TestConvertToNetworkInfo_IPAM tests conversion of IPAM configuration to subnets.
+func TestConvertToNetworkInfo_IPAM(t *testing.T) {
+	summary := network.Summary{
+		Name:   "test_net",
+		Driver: "bridge",
+		IPAM: network.IPAM{
+			Config: []network.IPAMConfig{
+				{
+					Subnet:     "172.20.0.0/24",
+					IPRange:    "172.20.0.128/25",
+					Gateway:    "172.20.0.1",
+					AuxAddress: map[string]string{"router": "172.20.0.3", "dns": "172.20.0.2"},
+				},
+			},
+		},
+	}
+
+	info := ConvertToNetworkInfo(summary)
+
+	if len(info.Subnets) != 1 {
+		t.Fatalf("expected 1 subnet, got %d", len(info.Subnets))
+	}
+
+	subnet := info.Subnets[0]
+	if subnet.Subnet != "172.20.0.0/24" || subnet.IPRange != "172.20.0.128/25" || subnet.Gateway != "172.20.0.1" {
+		t.Errorf("unexpected subnet: %+v", subnet)
+	}
+
+	if len(subnet.AuxAddresses) != 2 || subnet.AuxAddresses[0] != "172.20.0.2" {
+		t.Errorf("expected sorted auxiliary addresses, got %v", subnet.AuxAddresses)
+	}
+}
+
 // TestConvertNetworksToNetworkInfos tests bulk conversion of network summaries.
 func TestConvertNetworksToNetworkInfos(t *testing.T) {
 	summaries := []network.Summary{
@@ -0,0 +1,178 @@
+// Package docker provides a wrapper around the Docker SDK client.
+// This file contains the SSH transport for ssh:// hosts.
+package docker
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// sshHost is the placeholder host of clients that reach the daemon over SSH.
+// Requests are written to the ssh command rather than sent to this address.
+const sshHost = "http://docker.example.com"
+
+// sshArgs returns the ssh arguments that run "docker system dial-stdio" on
+// the host of an ssh://[user@]host[:port] address. The remote command
+// bridges its standard input and output to the remote daemon's socket.
+func sshArgs(host string) ([]string, error) {
+	u, err := url.Parse(host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SSH host %q: %w", host, err)
+	}
+	if u.Scheme != "ssh" || u.Hostname() == "" {
+		return nil, fmt.Errorf("invalid SSH host %q: must be ssh://[user@]host[:port]", host)
+	}
+	if u.Path != "" && u.Path != "/" {
+		return nil, fmt.Errorf("invalid SSH host %q: paths are not supported", host)
+	}
+
+	var args []string
+	if u.User != nil {
+		args = append(args, "-l", u.User.Username())
+	}
+	if port := u.Port(); port != "" {
+		args = append(args, "-p", port)
+	}
+	return append(args, "--", u.Hostname(), "docker", "system", "dial-stdio"), nil
+}
+
+// sshDialer returns a dialer that connects to the daemon of an ssh:// host
+// through the ssh command, so the user's SSH configuration and agent apply.
+func sshDialer(host string) (func(ctx context.Context, network, addr string) (net.Conn, error), error) {
+	args, err := sshArgs(host)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(_ context.Context, _, _ string) (net.Conn, error) {
+		return dialCommand("ssh", args...)
+	}, nil
+}
+
+// commandConn is a net.Conn over the standard input and output of a command.
+type commandConn struct {
+	name   string
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout io.ReadCloser
+	stderr *syncBuffer
+
+	waitOnce  sync.Once
+	closeOnce sync.Once
+}
+
+// dialCommand starts a command and returns a connection to it.
+func dialCommand(name string, args ...string) (net.Conn, error) {
+	cmd := exec.Command(name, args...)
+	c := &commandConn{name: name, cmd: cmd, stderr: &syncBuffer{}}
+	cmd.Stderr = c.stderr
+
+	var err error
+	if c.stdin, err = cmd.StdinPipe(); err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", name, err)
+	}
+	if c.stdout, err = cmd.StdoutPipe(); err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", name, err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start %s: %w", name, err)
+	}
+
+	return c, nil
+}
+
+// Read reads from the command's standard output. When the command exits,
+// the error includes what it wrote to standard error, such as an SSH
+// authentication failure.
+func (c *commandConn) Read(p []byte) (int, error) {
+	n, err := c.stdout.Read(p)
+	if err != nil {
+		// Standard error is complete once the command has exited.
+		if errors.Is(err, io.EOF) {
+			c.wait()
+		}
+		if msg := strings.TrimSpace(c.stderr.String()); msg != "" {
+			return n, fmt.Errorf("%s: %s: %w", c.name, msg, err)
+		}
+	}
+	return n, err
+}
+
+// Write writes to the command's standard input.
+func (c *commandConn) Write(p []byte) (int, error) {
+	return c.stdin.Write(p)
+}
+
+// Close stops the command.
+func (c *commandConn) Close() error {
+	c.closeOnce.Do(func() {
+		_ = c.stdin.Close()
+		_ = c.cmd.Process.Kill()
+		c.wait()
+	})
+	return nil
+}
+
+// wait waits for the command to exit.
+func (c *commandConn) wait() {
+	c.waitOnce.Do(func() {
+		_ = c.cmd.Wait()
+	})
+}
+
+// LocalAddr returns a placeholder address.
+func (c *commandConn) LocalAddr() net.Addr {
+	return commandAddr(c.name)
+}
+
+// RemoteAddr returns a placeholder address.
+func (c *commandConn) RemoteAddr() net.Addr {
+	return commandAddr(c.name)
+}
+
+// SetDeadline is a no-op; the HTTP client cancels requests by closing the connection.
+func (c *commandConn) SetDeadline(time.Time) error { return nil }
+
+// SetReadDeadline is a no-op.
+func (c *commandConn) SetReadDeadline(time.Time) error { return nil }
+
+// SetWriteDeadline is a no-op.
+func (c *commandConn) SetWriteDeadline(time.Time) error { return nil }
+
+// commandAddr is the net.Addr of a command connection.
+type commandAddr string
+
+// Network returns "command".
+func (a commandAddr) Network() string { return "command" }
+
+// String returns the command name.
+func (a commandAddr) String() string { return string(a) }
+
+// syncBuffer is a bytes.Buffer safe for concurrent writes and reads.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+// Write appends to the buffer.
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// String returns the buffer contents.
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
@@ -0,0 +1,91 @@
+// Package docker provides tests for the SSH transport.
+package docker
+
+import (
+	"io"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestSSHArgs tests building the ssh command line from a host.
+func TestSSHArgs(t *testing.T) {
+	tests := []struct {
+		host string
+		want []string
+	}{
+		{"ssh://build-1", []string{"--", "build-1", "docker", "system", "dial-stdio"}},
+		{"ssh://deploy@build-1:2222", []string{"-l", "deploy", "-p", "2222", "--", "build-1", "docker", "system", "dial-stdio"}},
+		{"ssh://[fd00::1]/", []string{"--", "fd00::1", "docker", "system", "dial-stdio"}},
+	}
+
+	for _, tt := range tests {
+		got, err := sshArgs(tt.host)
+		if err != nil {
+			t.Errorf("sshArgs(%q): unexpected error %v", tt.host, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("sshArgs(%q) = %v, want %v", tt.host, got, tt.want)
+		}
+	}
+}
+
+// TestSSHArgs_Invalid tests hosts that cannot be reached over SSH.
+func TestSSHArgs_Invalid(t *testing.T) {
+	for _, host := range []string{"ssh://", "tcp://build-1", "ssh://build-1/run/docker.sock"} {
+		if _, err := sshArgs(host); err == nil {
+			t.Errorf("sshArgs(%q): expected error", host)
+		}
+	}
+}
+
+// TestDialCommand tests a connection over a command's standard input and output.
+func TestDialCommand(t *testing.T) {
+	if _, err := exec.LookPath("cat"); err != nil {
+		t.Skip("cat not available")
+	}
+
+	conn, err := dialCommand("cat")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	if _, err := conn.Write([]byte("ping")); err != nil {
+		t.Fatalf("failed to write: %v", err)
+	}
+	buf := make([]byte, 4)
+	if _, err := io.ReadFull(conn, buf); err != nil {
+		t.Fatalf("failed to read: %v", err)
+	}
+	if string(buf) != "ping" {
+		t.Errorf("expected ping, got %q", buf)
+	}
+	if conn.RemoteAddr().String() != "cat" {
+		t.Errorf("unexpected remote address %s", conn.RemoteAddr())
+	}
+}
+
+// TestDialCommand_Stderr tests that a failing command's error output is reported.
+func TestDialCommand_Stderr(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+
+	conn, err := dialCommand("sh", "-c", "echo 'Permission denied (publickey)' >&2")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	_, err = io.ReadAll(conn)
+	if err == nil || !strings.Contains(err.Error(), "Permission denied") {
+		t.Errorf("expected the command's error output, got %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+// Package graph analyzes Docker network topology as a container/network graph.
+// This file contains the report formats.
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Report formats.
+const (
+	// FormatText renders the statistics as trees.
+	FormatText = "text"
+
+	// FormatJSON renders the statistics as a JSON document.
+	FormatJSON = "json"
+)
+
+// Formats lists the supported report formats.
+var Formats = []string{FormatText, FormatJSON}
+
+// ValidateFormat returns an error if format is not a supported report format.
+func ValidateFormat(format string) error {
+	for _, f := range Formats {
+		if f == format {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown format %q: must be one of %s", format, strings.Join(Formats, ", "))
+}
+
+// WriteJSON renders the statistics as an indented JSON document.
+func WriteJSON(w io.Writer, stats *Stats) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(stats); err != nil {
+		return fmt.Errorf("failed to encode graph statistics: %w", err)
+	}
+	return nil
+}
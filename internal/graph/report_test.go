@@ -0,0 +1,36 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestValidateFormat(t *testing.T) {
+	for _, f := range Formats {
+		if err := ValidateFormat(f); err != nil {
+			t.Errorf("expected %q to be valid, got %v", f, err)
+		}
+	}
+	if err := ValidateFormat("xml"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, Analyze(testContainers())); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decoded Stats
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if len(decoded.Components) != 3 || len(decoded.ArticulationPoints) != 2 {
+		t.Errorf("unexpected document: %+v", decoded)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte(`"articulation_points": [`)) {
+		t.Errorf("expected snake_case keys, got:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,279 @@
+// Package graph analyzes Docker network topology as a bipartite graph of
+// containers and networks, where each container is linked to every network
+// it is attached to. It finds isolated islands, the multi-homed containers
+// that hold the topology together, and the degree of every node.
+package graph
+
+import (
+	"sort"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// Stats is the result of analyzing the container/network graph.
+type Stats struct {
+	// Components are the connected components, or islands, of the graph,
+	// largest first.
+	Components []Component `json:"components"`
+
+	// ArticulationPoints are the containers whose removal would split their
+	// island, sorted by the number of pieces left, then by name.
+	ArticulationPoints []ArticulationPoint `json:"articulation_points"`
+
+	// Containers holds the degree of every container, highest first.
+	Containers []Degree `json:"containers"`
+
+	// Networks holds the degree of every network, highest first.
+	Networks []Degree `json:"networks"`
+}
+
+// Component is a set of containers and networks connected to each other and
+// to nothing else.
+type Component struct {
+	// Containers are the component's containers, sorted by name.
+	Containers []string `json:"containers"`
+
+	// Networks are the component's networks, sorted by name.
+	Networks []string `json:"networks"`
+}
+
+// ArticulationPoint is a container whose removal would partition its island.
+type ArticulationPoint struct {
+	// Container is the container's name.
+	Container string `json:"container"`
+
+	// Networks are the networks the container joins, sorted by name.
+	Networks []string `json:"networks"`
+
+	// Pieces is the number of parts its island would split into without it.
+	Pieces int `json:"pieces"`
+}
+
+// Degree is the number of links of a container or network: the networks a
+// container is attached to, or the containers attached to a network.
+type Degree struct {
+	// Name is the container or network name.
+	Name string `json:"name"`
+
+	// Degree is the number of links.
+	Degree int `json:"degree"`
+}
+
+// node is a container or network in the graph.
+type node struct {
+	name      string
+	container bool
+	links     []int
+}
+
+// Analyze builds the container/network graph and computes its statistics.
+// The "none" network is left out, as it connects nothing; networks without
+// containers do not appear.
+func Analyze(containers map[string]*models.ContainerInfo) *Stats {
+	nodes := buildGraph(containers)
+
+	stats := &Stats{
+		Components:         components(nodes),
+		ArticulationPoints: articulationPoints(nodes),
+		Containers:         []Degree{},
+		Networks:           []Degree{},
+	}
+
+	for _, n := range nodes {
+		d := Degree{Name: n.name, Degree: len(n.links)}
+		if n.container {
+			stats.Containers = append(stats.Containers, d)
+		} else {
+			stats.Networks = append(stats.Networks, d)
+		}
+	}
+	sortDegrees(stats.Containers)
+	sortDegrees(stats.Networks)
+
+	return stats
+}
+
+// buildGraph builds the nodes of the graph, containers first, in name order.
+func buildGraph(containers map[string]*models.ContainerInfo) []node {
+	names := make([]string, 0, len(containers))
+	for name := range containers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var networkNames []string
+	seen := make(map[string]bool)
+	for _, name := range names {
+		for _, net := range containers[name].Networks {
+			if net != models.NoneNetwork && !seen[net] {
+				seen[net] = true
+				networkNames = append(networkNames, net)
+			}
+		}
+	}
+	sort.Strings(networkNames)
+
+	nodes := make([]node, 0, len(names)+len(networkNames))
+	for _, name := range names {
+		nodes = append(nodes, node{name: name, container: true})
+	}
+	networkIndex := make(map[string]int, len(networkNames))
+	for _, net := range networkNames {
+		networkIndex[net] = len(nodes)
+		nodes = append(nodes, node{name: net})
+	}
+
+	for i, name := range names {
+		for _, net := range containers[name].SortedNetworks() {
+			j, ok := networkIndex[net]
+			if !ok {
+				continue
+			}
+			nodes[i].links = append(nodes[i].links, j)
+			nodes[j].links = append(nodes[j].links, i)
+		}
+	}
+
+	return nodes
+}
+
+// components finds the connected components of the graph, largest first.
+func components(nodes []node) []Component {
+	visited := make([]bool, len(nodes))
+	result := []Component{}
+
+	for start := range nodes {
+		if visited[start] {
+			continue
+		}
+
+		var comp Component
+		stack := []int{start}
+		visited[start] = true
+		for len(stack) > 0 {
+			v := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+
+			if nodes[v].container {
+				comp.Containers = append(comp.Containers, nodes[v].name)
+			} else {
+				comp.Networks = append(comp.Networks, nodes[v].name)
+			}
+
+			for _, w := range nodes[v].links {
+				if !visited[w] {
+					visited[w] = true
+					stack = append(stack, w)
+				}
+			}
+		}
+
+		sort.Strings(comp.Containers)
+		sort.Strings(comp.Networks)
+		if comp.Containers == nil {
+			comp.Containers = []string{}
+		}
+		if comp.Networks == nil {
+			comp.Networks = []string{}
+		}
+		result = append(result, comp)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		si := len(result[i].Containers) + len(result[i].Networks)
+		sj := len(result[j].Containers) + len(result[j].Networks)
+		if si != sj {
+			return si > sj
+		}
+		return firstName(result[i]) < firstName(result[j])
+	})
+
+	return result
+}
+
+// firstName returns the first container, or network, name of a component.
+func firstName(c Component) string {
+	if len(c.Containers) > 0 {
+		return c.Containers[0]
+	}
+	if len(c.Networks) > 0 {
+		return c.Networks[0]
+	}
+	return ""
+}
+
+// articulationPoints finds the containers whose removal disconnects the
+// graph, using Tarjan's depth-first search. Networks that are articulation
+// points are not reported: every network with a container attached to
+// nothing else is one.
+func articulationPoints(nodes []node) []ArticulationPoint {
+	disc := make([]int, len(nodes))
+	low := make([]int, len(nodes))
+	pieces := make([]int, len(nodes))
+	timer := 0
+
+	var visit func(v, parent int)
+	visit = func(v, parent int) {
+		timer++
+		disc[v], low[v] = timer, timer
+
+		children := 0
+		for _, w := range nodes[v].links {
+			if disc[w] == 0 {
+				children++
+				visit(w, v)
+				low[v] = min(low[v], low[w])
+				if parent >= 0 && low[w] >= disc[v] {
+					pieces[v]++
+				}
+			} else if w != parent {
+				low[v] = min(low[v], disc[w])
+			}
+		}
+
+		switch {
+		case parent < 0 && children > 1:
+			pieces[v] = children
+		case parent >= 0 && pieces[v] > 0:
+			// The separated subtrees plus the rest of the island.
+			pieces[v]++
+		}
+	}
+
+	for v := range nodes {
+		if disc[v] == 0 {
+			visit(v, -1)
+		}
+	}
+
+	result := []ArticulationPoint{}
+	for v, n := range nodes {
+		if !n.container || pieces[v] < 2 {
+			continue
+		}
+		networks := make([]string, len(n.links))
+		for i, w := range n.links {
+			networks[i] = nodes[w].name
+		}
+		result = append(result, ArticulationPoint{Container: n.name, Networks: networks, Pieces: pieces[v]})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Pieces != result[j].Pieces {
+			return result[i].Pieces > result[j].Pieces
+		}
+		return result[i].Container < result[j].Container
+	})
+
+	return result
+}
+
+// sortDegrees sorts degrees highest first, then by name.
+func sortDegrees(degrees []Degree) {
+	sort.SliceStable(degrees, func(i, j int) bool {
+		if degrees[i].Degree != degrees[j].Degree {
+			return degrees[i].Degree > degrees[j].Degree
+		}
+		return degrees[i].Name < degrees[j].Name
+	})
+}
@@ -0,0 +1,101 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// testContainers returns a topology with two islands: web, api and db linked
+// by api, and a monitoring island. The backup container links the backend
+// and storage networks, and cron sits on the none network.
+func testContainers() map[string]*models.ContainerInfo {
+	return map[string]*models.ContainerInfo{
+		"web":        {Name: "web", Networks: []string{"frontend"}},
+		"api":        {Name: "api", Networks: []string{"frontend", "backend"}},
+		"db":         {Name: "db", Networks: []string{"backend"}},
+		"backup":     {Name: "backup", Networks: []string{"backend", "storage"}},
+		"nas":        {Name: "nas", Networks: []string{"storage"}},
+		"prometheus": {Name: "prometheus", Networks: []string{"monitoring"}},
+		"cron":       {Name: "cron", Networks: []string{"none"}},
+	}
+}
+
+func TestAnalyzeComponents(t *testing.T) {
+	stats := Analyze(testContainers())
+
+	want := []Component{
+		{Containers: []string{"api", "backup", "db", "nas", "web"}, Networks: []string{"backend", "frontend", "storage"}},
+		{Containers: []string{"prometheus"}, Networks: []string{"monitoring"}},
+		{Containers: []string{"cron"}, Networks: []string{}},
+	}
+	if !reflect.DeepEqual(stats.Components, want) {
+		t.Errorf("Components =\n%+v\nwant\n%+v", stats.Components, want)
+	}
+}
+
+func TestAnalyzeArticulationPoints(t *testing.T) {
+	stats := Analyze(testContainers())
+
+	want := []ArticulationPoint{
+		{Container: "api", Networks: []string{"backend", "frontend"}, Pieces: 2},
+		{Container: "backup", Networks: []string{"backend", "storage"}, Pieces: 2},
+	}
+	if !reflect.DeepEqual(stats.ArticulationPoints, want) {
+		t.Errorf("ArticulationPoints =\n%+v\nwant\n%+v", stats.ArticulationPoints, want)
+	}
+}
+
+func TestAnalyzeArticulationPointsWithRedundantPath(t *testing.T) {
+	containers := map[string]*models.ContainerInfo{
+		"a":   {Name: "a", Networks: []string{"one", "two"}},
+		"b":   {Name: "b", Networks: []string{"one", "two"}},
+		"hub": {Name: "hub", Networks: []string{"one", "two", "three"}},
+		"c":   {Name: "c", Networks: []string{"three"}},
+		"d":   {Name: "d", Networks: []string{"four", "five", "six"}},
+		"e":   {Name: "e", Networks: []string{"four"}},
+		"f":   {Name: "f", Networks: []string{"five"}},
+		"g":   {Name: "g", Networks: []string{"six"}},
+	}
+
+	stats := Analyze(containers)
+
+	want := []ArticulationPoint{
+		{Container: "d", Networks: []string{"five", "four", "six"}, Pieces: 3},
+		{Container: "hub", Networks: []string{"one", "three", "two"}, Pieces: 2},
+	}
+	if !reflect.DeepEqual(stats.ArticulationPoints, want) {
+		t.Errorf("ArticulationPoints =\n%+v\nwant\n%+v", stats.ArticulationPoints, want)
+	}
+}
+
+func TestAnalyzeDegrees(t *testing.T) {
+	stats := Analyze(testContainers())
+
+	wantContainers := []Degree{
+		{Name: "api", Degree: 2}, {Name: "backup", Degree: 2},
+		{Name: "db", Degree: 1}, {Name: "nas", Degree: 1}, {Name: "prometheus", Degree: 1}, {Name: "web", Degree: 1},
+		{Name: "cron", Degree: 0},
+	}
+	if !reflect.DeepEqual(stats.Containers, wantContainers) {
+		t.Errorf("Containers =\n%+v\nwant\n%+v", stats.Containers, wantContainers)
+	}
+
+	wantNetworks := []Degree{
+		{Name: "backend", Degree: 3},
+		{Name: "frontend", Degree: 2}, {Name: "storage", Degree: 2},
+		{Name: "monitoring", Degree: 1},
+	}
+	if !reflect.DeepEqual(stats.Networks, wantNetworks) {
+		t.Errorf("Networks =\n%+v\nwant\n%+v", stats.Networks, wantNetworks)
+	}
+}
+
+func TestAnalyzeEmpty(t *testing.T) {
+	stats := Analyze(nil)
+
+	if stats.Components == nil || stats.ArticulationPoints == nil || stats.Containers == nil || stats.Networks == nil {
+		t.Errorf("expected empty, non-nil lists, got %+v", stats)
+	}
+}
@@ -0,0 +1,175 @@
+// Package ingress builds the inbound traffic chain of a host.
+// This file links proxies, their routes and their backends.
+package ingress
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// Report is the inbound traffic chain of a host.
+type Report struct {
+	// Proxies are the reverse proxy containers, sorted by name.
+	Proxies []Proxy
+
+	// Unserved are routes for a kind of proxy that is not running, sorted
+	// by backend.
+	Unserved []Hop
+
+	// Direct are the other containers that publish ports on the host,
+	// bypassing any proxy, sorted by name.
+	Direct []Exposure
+}
+
+// Proxy is a reverse proxy container and the routes it serves.
+type Proxy struct {
+	// Name is the proxy container's name.
+	Name string
+
+	// Kind is the kind of proxy.
+	Kind Kind
+
+	// Ports are the host ports the proxy publishes.
+	Ports []models.PortInfo
+
+	// Hops are the routes the proxy serves, sorted by backend and rule.
+	Hops []Hop
+}
+
+// Hop is a route from a proxy to its backend.
+type Hop struct {
+	Route
+
+	// Networks are the shared networks the proxy can reach the backend on,
+	// sorted by name.
+	Networks []string
+
+	// Problem explains why the proxy cannot reach the backend; it is empty
+	// when the route works.
+	Problem string
+}
+
+// OK reports whether the proxy can reach the route's backend.
+func (h Hop) OK() bool {
+	return h.Problem == ""
+}
+
+// Exposure is a container publishing host ports without a proxy.
+type Exposure struct {
+	// Container is the container's name.
+	Container string
+
+	// Ports are the published ports.
+	Ports []models.PortInfo
+}
+
+// Build links every reverse proxy in the topology to the routes its
+// backends declare and checks that each proxy shares a network with each of
+// its backends. Networks that disable inter-container communication do not
+// count as shared; networks may be nil to treat every network as open.
+func Build(containers map[string]*models.ContainerInfo, networks map[string]*models.NetworkInfo) *Report {
+	report := &Report{}
+
+	names := make([]string, 0, len(containers))
+	for name := range containers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	proxiesByKind := make(map[Kind][]*Proxy)
+	for _, name := range names {
+		c := containers[name]
+		if kind, ok := ProxyKind(c); ok {
+			report.Proxies = append(report.Proxies, Proxy{Name: name, Kind: kind, Ports: publishedPorts(c)})
+			continue
+		}
+		if ports := publishedPorts(c); len(ports) > 0 {
+			report.Direct = append(report.Direct, Exposure{Container: name, Ports: ports})
+		}
+	}
+	for i := range report.Proxies {
+		p := &report.Proxies[i]
+		proxiesByKind[p.Kind] = append(proxiesByKind[p.Kind], p)
+	}
+
+	for _, name := range names {
+		for _, route := range RoutesFor(containers[name]) {
+			proxies := proxiesByKind[route.Kind]
+			if len(proxies) == 0 {
+				report.Unserved = append(report.Unserved, Hop{
+					Route:   route,
+					Problem: fmt.Sprintf("no %s proxy is running", route.Kind),
+				})
+				continue
+			}
+			for _, p := range proxies {
+				p.Hops = append(p.Hops, link(containers[p.Name], containers[name], route, networks))
+			}
+		}
+	}
+
+	for i := range report.Proxies {
+		hops := report.Proxies[i].Hops
+		sort.SliceStable(hops, func(a, b int) bool {
+			if hops[a].Backend != hops[b].Backend {
+				return hops[a].Backend < hops[b].Backend
+			}
+			return hops[a].Rule < hops[b].Rule
+		})
+	}
+
+	return report
+}
+
+// link checks whether a proxy can reach a route's backend.
+func link(proxy, backend *models.ContainerInfo, route Route, networks map[string]*models.NetworkInfo) Hop {
+	hop := Hop{Route: route}
+
+	if proxy.Name == backend.Name {
+		return hop
+	}
+
+	var blocked []string
+	for _, net := range backend.SortedNetworks() {
+		if !proxy.HasNetwork(net) || net == "none" {
+			continue
+		}
+		if n := networks[net]; n != nil && !n.ICCEnabled() {
+			blocked = append(blocked, net)
+			continue
+		}
+		hop.Networks = append(hop.Networks, net)
+	}
+
+	switch {
+	case !backend.IsActive():
+		hop.Problem = fmt.Sprintf("backend is %s", backend.State)
+	case route.Network != "" && !backend.HasNetwork(route.Network):
+		hop.Problem = fmt.Sprintf("backend is not on %s, the network the route names", route.Network)
+	case route.Network != "" && !slices.Contains(hop.Networks, route.Network):
+		hop.Problem = fmt.Sprintf("%s does not reach the backend on %s, the network the route names", proxy.Name, route.Network)
+	case len(hop.Networks) == 0 && len(blocked) > 0:
+		hop.Problem = fmt.Sprintf("shared network %s disables inter-container communication", blocked[0])
+	case len(hop.Networks) == 0:
+		hop.Problem = fmt.Sprintf("no network shared with %s", proxy.Name)
+	}
+
+	return hop
+}
+
+// publishedPorts returns the container's ports published on the host.
+func publishedPorts(c *models.ContainerInfo) []models.PortInfo {
+	var ports []models.PortInfo
+	for _, p := range c.Ports {
+		if p.IsPublished() {
+			ports = append(ports, p)
+		}
+	}
+	sort.SliceStable(ports, func(i, j int) bool {
+		return ports[i].PublicPort < ports[j].PublicPort
+	})
+	return ports
+}
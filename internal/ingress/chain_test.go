@@ -0,0 +1,136 @@
+package ingress
+
+import (
+	"reflect"
+	"testing"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// testContainers returns a topology with a Traefik proxy and backends that
+// exercise every kind of problem.
+func testContainers() map[string]*models.ContainerInfo {
+	return map[string]*models.ContainerInfo{
+		"traefik": {
+			Name:     "traefik",
+			Image:    "traefik:v3.0",
+			Networks: []string{"frontend", "isolated"},
+			Ports: []models.PortInfo{
+				{IP: "0.0.0.0", PrivatePort: 443, PublicPort: 443, Type: "tcp"},
+				{IP: "0.0.0.0", PrivatePort: 80, PublicPort: 80, Type: "tcp"},
+				{PrivatePort: 8080, Type: "tcp"},
+			},
+		},
+		"shop": {
+			Name:     "shop",
+			Networks: []string{"frontend", "backend"},
+			Labels:   map[string]string{"traefik.http.routers.shop.rule": "Host(`shop.example.com`)"},
+			Ports:    []models.PortInfo{{PrivatePort: 8080, Type: "tcp"}},
+		},
+		"api": {
+			Name:     "api",
+			Networks: []string{"backend"},
+			Labels:   map[string]string{"traefik.http.routers.api.rule": "Host(`api.example.com`)"},
+		},
+		"stopped": {
+			Name:     "stopped",
+			State:    "exited",
+			Networks: []string{"frontend"},
+			Labels:   map[string]string{"traefik.http.routers.old.rule": "Host(`old.example.com`)"},
+		},
+		"pinned": {
+			Name:     "pinned",
+			Networks: []string{"frontend", "backend"},
+			Labels: map[string]string{
+				"traefik.http.routers.pinned.rule": "Host(`pinned.example.com`)",
+				"traefik.docker.network":           "backend",
+			},
+		},
+		"vault": {
+			Name:     "vault",
+			Networks: []string{"isolated"},
+			Labels:   map[string]string{"traefik.http.routers.vault.rule": "Host(`vault.example.com`)"},
+		},
+		"blog": {
+			Name:     "blog",
+			Networks: []string{"frontend"},
+			Labels:   map[string]string{"caddy": "blog.example.com", "caddy.reverse_proxy": "{{upstreams 2368}}"},
+		},
+		"db": {
+			Name:     "db",
+			Networks: []string{"backend"},
+			Ports:    []models.PortInfo{{IP: "127.0.0.1", PrivatePort: 5432, PublicPort: 5432, Type: "tcp"}},
+		},
+	}
+}
+
+func TestBuild(t *testing.T) {
+	networks := map[string]*models.NetworkInfo{
+		"isolated": {Name: "isolated", Driver: "bridge", Options: map[string]string{models.BridgeICCOption: "false"}},
+	}
+
+	report := Build(testContainers(), networks)
+
+	if len(report.Proxies) != 1 {
+		t.Fatalf("expected 1 proxy, got %d", len(report.Proxies))
+	}
+	proxy := report.Proxies[0]
+	if proxy.Name != "traefik" || proxy.Kind != KindTraefik {
+		t.Errorf("unexpected proxy: %+v", proxy)
+	}
+	if len(proxy.Ports) != 2 || proxy.Ports[0].PublicPort != 80 {
+		t.Errorf("expected published ports 80 and 443, got %+v", proxy.Ports)
+	}
+
+	problems := make(map[string]string)
+	for _, hop := range proxy.Hops {
+		problems[hop.Backend] = hop.Problem
+	}
+	want := map[string]string{
+		"api":     "no network shared with traefik",
+		"pinned":  "traefik does not reach the backend on backend, the network the route names",
+		"shop":    "",
+		"stopped": "backend is exited",
+		"vault":   "shared network isolated disables inter-container communication",
+	}
+	if !reflect.DeepEqual(problems, want) {
+		t.Errorf("problems =\n%v\nwant\n%v", problems, want)
+	}
+
+	if shop := proxy.Hops[2]; shop.Backend != "shop" || !reflect.DeepEqual(shop.Networks, []string{"frontend"}) || !shop.OK() {
+		t.Errorf("unexpected shop hop: %+v", shop)
+	}
+
+	if len(report.Unserved) != 1 || report.Unserved[0].Backend != "blog" ||
+		report.Unserved[0].Problem != "no caddy proxy is running" {
+		t.Errorf("unexpected unserved routes: %+v", report.Unserved)
+	}
+
+	if len(report.Direct) != 1 || report.Direct[0].Container != "db" {
+		t.Errorf("unexpected direct exposures: %+v", report.Direct)
+	}
+}
+
+func TestBuild_ProxyRoutingToItself(t *testing.T) {
+	containers := map[string]*models.ContainerInfo{
+		"traefik": {
+			Name:   "traefik",
+			Image:  "traefik",
+			Labels: map[string]string{"traefik.http.routers.dashboard.rule": "Host(`traefik.example.com`)"},
+		},
+	}
+
+	report := Build(containers, nil)
+
+	if hops := report.Proxies[0].Hops; len(hops) != 1 || !hops[0].OK() {
+		t.Errorf("expected the dashboard route to work, got %+v", hops)
+	}
+}
+
+func TestBuild_Empty(t *testing.T) {
+	report := Build(nil, nil)
+
+	if len(report.Proxies) != 0 || len(report.Unserved) != 0 || len(report.Direct) != 0 {
+		t.Errorf("expected an empty report, got %+v", report)
+	}
+}
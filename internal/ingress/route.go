@@ -0,0 +1,275 @@
+// Package ingress builds the inbound traffic chain of a host: the host
+// ports published by reverse proxies, the routes they serve according to
+// their backends' routing labels, and whether each proxy can reach its
+// backends over a shared network.
+package ingress
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// Kind identifies a reverse proxy that routes to containers by their labels
+// or environment.
+type Kind string
+
+// Supported reverse proxies.
+const (
+	// KindTraefik is Traefik with its Docker provider, routing by
+	// traefik.http.routers.* and traefik.tcp.routers.* labels.
+	KindTraefik Kind = "traefik"
+
+	// KindNginxProxy is nginx-proxy, routing by the VIRTUAL_HOST and
+	// VIRTUAL_PORT environment variables.
+	KindNginxProxy Kind = "nginx-proxy"
+
+	// KindCaddy is caddy-docker-proxy, routing by caddy and caddy_N labels.
+	KindCaddy Kind = "caddy"
+)
+
+// proxyImages maps the repository names of proxy images, without registry
+// or namespace, to their kind.
+var proxyImages = map[string]Kind{
+	"traefik":            KindTraefik,
+	"nginx-proxy":        KindNginxProxy,
+	"caddy-docker-proxy": KindCaddy,
+}
+
+// Traefik labels.
+const (
+	traefikPrefix         = "traefik."
+	traefikEnableLabel    = "traefik.enable"
+	traefikNetworkLabel   = "traefik.docker.network"
+	traefikServerPortPath = ".loadbalancer.server.port"
+)
+
+// nginx-proxy environment variables.
+const (
+	nginxVirtualHostEnv = "VIRTUAL_HOST"
+	nginxVirtualPortEnv = "VIRTUAL_PORT"
+
+	// nginxDefaultPort is the port nginx-proxy uses when the backend
+	// exposes several ports and VIRTUAL_PORT is not set.
+	nginxDefaultPort = "80"
+)
+
+// caddyLabel matches the site labels of caddy-docker-proxy: "caddy", or
+// "caddy_N" for containers serving several sites.
+var caddyLabel = regexp.MustCompile(`^caddy(_\d+)?$`)
+
+// caddyUpstreams matches the port in "{{upstreams 8080}}" or
+// "{{upstreams http 8080}}".
+var caddyUpstreams = regexp.MustCompile(`\{\{\s*upstreams(?:\s+\w+)?\s+(\d+)\s*\}\}`)
+
+// Route is a route a reverse proxy serves to a backend container.
+type Route struct {
+	// Kind is the kind of proxy that serves the route.
+	Kind Kind
+
+	// Name is the route's name, such as the Traefik router name or the
+	// caddy label; empty for nginx-proxy.
+	Name string
+
+	// Rule describes the requests routed, such as "Host(`shop.example.com`)"
+	// or "shop.example.com".
+	Rule string
+
+	// Backend is the name of the container the route forwards to.
+	Backend string
+
+	// Port is the backend port, or an empty string if it cannot be told.
+	Port string
+
+	// Network is the network the proxy is told to reach the backend on,
+	// such as the traefik.docker.network label; empty if not pinned.
+	Network string
+}
+
+// String formats the route as "rule -> backend:port".
+func (r Route) String() string {
+	target := r.Backend
+	if r.Port != "" {
+		target += ":" + r.Port
+	}
+	return fmt.Sprintf("%s -> %s", r.Rule, target)
+}
+
+// ProxyKind returns the kind of reverse proxy a container runs, judged by its
+// image, and whether it is one.
+func ProxyKind(c *models.ContainerInfo) (Kind, bool) {
+	kind, ok := proxyImages[imageRepository(c.Image)]
+	return kind, ok
+}
+
+// imageRepository returns the last path element of an image reference,
+// without tag or digest: "traefik" for "docker.io/library/traefik:v3.0".
+func imageRepository(image string) string {
+	image, _, _ = strings.Cut(image, "@")
+	if i := strings.LastIndex(image, "/"); i >= 0 {
+		image = image[i+1:]
+	}
+	image, _, _ = strings.Cut(image, ":")
+	return strings.ToLower(image)
+}
+
+// RoutesFor returns the routes a container declares for every supported
+// proxy through its labels and environment, sorted by kind and name.
+func RoutesFor(c *models.ContainerInfo) []Route {
+	var routes []Route
+	routes = append(routes, traefikRoutes(c)...)
+	routes = append(routes, nginxProxyRoutes(c)...)
+	routes = append(routes, caddyRoutes(c)...)
+
+	sort.SliceStable(routes, func(i, j int) bool {
+		if routes[i].Kind != routes[j].Kind {
+			return routes[i].Kind < routes[j].Kind
+		}
+		return routes[i].Name < routes[j].Name
+	})
+	return routes
+}
+
+// traefikRoutes returns the routers declared by traefik labels. A container
+// with traefik.enable=true and no routers gets Traefik's default router.
+func traefikRoutes(c *models.ContainerInfo) []Route {
+	if c.Labels[traefikEnableLabel] == "false" {
+		return nil
+	}
+
+	var routes []Route
+	for _, protocol := range []string{"http", "tcp"} {
+		prefix := traefikPrefix + protocol + ".routers."
+		for label, rule := range c.Labels {
+			rest, ok := strings.CutPrefix(label, prefix)
+			if !ok {
+				continue
+			}
+			name, ok := strings.CutSuffix(rest, ".rule")
+			if !ok || strings.Contains(name, ".") {
+				continue
+			}
+			routes = append(routes, Route{
+				Kind:    KindTraefik,
+				Name:    name,
+				Rule:    rule,
+				Backend: c.Name,
+				Port:    traefikPort(c, protocol, name),
+				Network: c.Labels[traefikNetworkLabel],
+			})
+		}
+	}
+
+	if len(routes) == 0 && c.Labels[traefikEnableLabel] == "true" {
+		routes = append(routes, Route{
+			Kind:    KindTraefik,
+			Name:    c.Name,
+			Rule:    "default rule",
+			Backend: c.Name,
+			Port:    traefikPort(c, "http", ""),
+			Network: c.Labels[traefikNetworkLabel],
+		})
+	}
+
+	return routes
+}
+
+// traefikPort returns the backend port of a router: the server port of the
+// service it names, the only service's server port, or the only exposed
+// port of the container.
+func traefikPort(c *models.ContainerInfo, protocol, router string) string {
+	services := traefikPrefix + protocol + ".services."
+
+	if service := c.Labels[traefikPrefix+protocol+".routers."+router+".service"]; service != "" {
+		if port := c.Labels[services+service+traefikServerPortPath]; port != "" {
+			return port
+		}
+	}
+
+	var ports []string
+	for label, port := range c.Labels {
+		if strings.HasPrefix(label, services) && strings.HasSuffix(label, traefikServerPortPath) {
+			ports = append(ports, port)
+		}
+	}
+	if len(ports) == 1 {
+		return ports[0]
+	}
+
+	return singleExposedPort(c)
+}
+
+// nginxProxyRoutes returns the route declared by VIRTUAL_HOST.
+func nginxProxyRoutes(c *models.ContainerInfo) []Route {
+	hosts := c.Env[nginxVirtualHostEnv]
+	if hosts == "" {
+		return nil
+	}
+
+	port := c.Env[nginxVirtualPortEnv]
+	if port == "" {
+		port = singleExposedPort(c)
+	}
+	if port == "" {
+		port = nginxDefaultPort
+	}
+
+	var names []string
+	for _, host := range strings.Split(hosts, ",") {
+		if host = strings.TrimSpace(host); host != "" {
+			names = append(names, host)
+		}
+	}
+
+	return []Route{{
+		Kind:    KindNginxProxy,
+		Rule:    strings.Join(names, ", "),
+		Backend: c.Name,
+		Port:    port,
+	}}
+}
+
+// caddyRoutes returns the sites declared by caddy labels that reverse proxy
+// to the container's upstreams.
+func caddyRoutes(c *models.ContainerInfo) []Route {
+	var routes []Route
+	for label, site := range c.Labels {
+		if !caddyLabel.MatchString(label) {
+			continue
+		}
+
+		match := caddyUpstreams.FindStringSubmatch(c.Labels[label+".reverse_proxy"])
+		if match == nil {
+			continue
+		}
+
+		routes = append(routes, Route{
+			Kind:    KindCaddy,
+			Name:    label,
+			Rule:    site,
+			Backend: c.Name,
+			Port:    match[1],
+		})
+	}
+	return routes
+}
+
+// singleExposedPort returns the container port when the container exposes
+// exactly one, or an empty string.
+func singleExposedPort(c *models.ContainerInfo) string {
+	ports := make(map[uint16]bool)
+	for _, p := range c.Ports {
+		ports[p.PrivatePort] = true
+	}
+	if len(ports) != 1 {
+		return ""
+	}
+	for port := range ports {
+		return strconv.Itoa(int(port))
+	}
+	return ""
+}
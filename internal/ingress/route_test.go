@@ -0,0 +1,158 @@
+package ingress
+
+import (
+	"reflect"
+	"testing"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+func TestProxyKind(t *testing.T) {
+	tests := []struct {
+		image string
+		kind  Kind
+		ok    bool
+	}{
+		{"traefik:v3.0", KindTraefik, true},
+		{"docker.io/library/traefik@sha256:abc", KindTraefik, true},
+		{"nginxproxy/nginx-proxy:1.6", KindNginxProxy, true},
+		{"jwilder/nginx-proxy", KindNginxProxy, true},
+		{"lucaslorentz/caddy-docker-proxy:ci-alpine", KindCaddy, true},
+		{"registry.example.com:5000/traefik", KindTraefik, true},
+		{"nginx:1.27", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			kind, ok := ProxyKind(&models.ContainerInfo{Image: tt.image})
+			if kind != tt.kind || ok != tt.ok {
+				t.Errorf("ProxyKind(%q) = %q, %v, want %q, %v", tt.image, kind, ok, tt.kind, tt.ok)
+			}
+		})
+	}
+}
+
+func TestRoutesFor_Traefik(t *testing.T) {
+	c := &models.ContainerInfo{
+		Name: "shop",
+		Labels: map[string]string{
+			"traefik.enable":                                          "true",
+			"traefik.docker.network":                                  "frontend",
+			"traefik.http.routers.shop.rule":                          "Host(`shop.example.com`)",
+			"traefik.http.routers.shop.service":                       "shop-svc",
+			"traefik.http.routers.admin.rule":                         "Host(`admin.example.com`)",
+			"traefik.http.routers.admin.tls.certresolver":             "le",
+			"traefik.http.services.shop-svc.loadbalancer.server.port": "8080",
+			"traefik.http.services.admin.loadbalancer.server.port":    "9090",
+			"traefik.tcp.routers.db.rule":                             "HostSNI(`*`)",
+		},
+		Ports: []models.PortInfo{{PrivatePort: 5432, Type: "tcp"}},
+	}
+
+	want := []Route{
+		{Kind: KindTraefik, Name: "admin", Rule: "Host(`admin.example.com`)", Backend: "shop", Port: "5432", Network: "frontend"},
+		{Kind: KindTraefik, Name: "db", Rule: "HostSNI(`*`)", Backend: "shop", Port: "5432", Network: "frontend"},
+		{Kind: KindTraefik, Name: "shop", Rule: "Host(`shop.example.com`)", Backend: "shop", Port: "8080", Network: "frontend"},
+	}
+	if got := RoutesFor(c); !reflect.DeepEqual(got, want) {
+		t.Errorf("RoutesFor() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestRoutesFor_TraefikDefaultAndDisabled(t *testing.T) {
+	enabled := &models.ContainerInfo{
+		Name:   "blog",
+		Labels: map[string]string{"traefik.enable": "true"},
+		Ports:  []models.PortInfo{{PrivatePort: 2368, Type: "tcp"}},
+	}
+	routes := RoutesFor(enabled)
+	if len(routes) != 1 || routes[0].Rule != "default rule" || routes[0].Port != "2368" {
+		t.Errorf("expected the default router, got %+v", routes)
+	}
+
+	disabled := &models.ContainerInfo{
+		Name: "blog",
+		Labels: map[string]string{
+			"traefik.enable":                 "false",
+			"traefik.http.routers.blog.rule": "Host(`blog.example.com`)",
+		},
+	}
+	if routes := RoutesFor(disabled); len(routes) != 0 {
+		t.Errorf("expected no routes for a disabled container, got %+v", routes)
+	}
+}
+
+func TestRoutesFor_NginxProxy(t *testing.T) {
+	tests := []struct {
+		name  string
+		env   map[string]string
+		ports []models.PortInfo
+		want  []Route
+	}{
+		{
+			name: "explicit port",
+			env:  map[string]string{"VIRTUAL_HOST": "shop.example.com, www.example.com", "VIRTUAL_PORT": "3000"},
+			want: []Route{{Kind: KindNginxProxy, Rule: "shop.example.com, www.example.com", Backend: "web", Port: "3000"}},
+		},
+		{
+			name:  "single exposed port",
+			env:   map[string]string{"VIRTUAL_HOST": "shop.example.com"},
+			ports: []models.PortInfo{{PrivatePort: 8080, Type: "tcp"}},
+			want:  []Route{{Kind: KindNginxProxy, Rule: "shop.example.com", Backend: "web", Port: "8080"}},
+		},
+		{
+			name:  "several exposed ports",
+			env:   map[string]string{"VIRTUAL_HOST": "shop.example.com"},
+			ports: []models.PortInfo{{PrivatePort: 8080, Type: "tcp"}, {PrivatePort: 9090, Type: "tcp"}},
+			want:  []Route{{Kind: KindNginxProxy, Rule: "shop.example.com", Backend: "web", Port: "80"}},
+		},
+		{
+			name: "no virtual host",
+			env:  map[string]string{"VIRTUAL_PORT": "3000"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &models.ContainerInfo{Name: "web", Env: tt.env, Ports: tt.ports}
+			if got := RoutesFor(c); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RoutesFor() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoutesFor_Caddy(t *testing.T) {
+	c := &models.ContainerInfo{
+		Name: "api",
+		Labels: map[string]string{
+			"caddy":                 "api.example.com",
+			"caddy.reverse_proxy":   "{{upstreams 8080}}",
+			"caddy_1":               "metrics.example.com",
+			"caddy_1.reverse_proxy": "{{upstreams http 9100}}",
+			"caddy_2":               "static.example.com",
+			"caddy_2.file_server":   "",
+		},
+	}
+
+	want := []Route{
+		{Kind: KindCaddy, Name: "caddy", Rule: "api.example.com", Backend: "api", Port: "8080"},
+		{Kind: KindCaddy, Name: "caddy_1", Rule: "metrics.example.com", Backend: "api", Port: "9100"},
+	}
+	if got := RoutesFor(c); !reflect.DeepEqual(got, want) {
+		t.Errorf("RoutesFor() = %+v, want %+v", got, want)
+	}
+}
+
+func TestRoute_String(t *testing.T) {
+	r := Route{Rule: "shop.example.com", Backend: "web", Port: "8080"}
+	if got := r.String(); got != "shop.example.com -> web:8080" {
+		t.Errorf("String() = %q", got)
+	}
+
+	r.Port = ""
+	if got := r.String(); got != "shop.example.com -> web" {
+		t.Errorf("String() = %q", got)
+	}
+}
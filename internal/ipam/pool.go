@@ -0,0 +1,202 @@
+// Package ipam calculates IP address pool utilization for Docker networks.
+// It compares the IPAM pools configured on each network with the addresses
+// allocated to container endpoints, so that nearly exhausted pools can be
+// reported before Docker fails with "no available IPv4 addresses".
+package ipam
+
+import (
+	"math"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// DefaultWarnThreshold is the utilization above which a pool is considered
+// close to exhaustion.
+const DefaultWarnThreshold = 0.8
+
+// PoolUsage describes how much of a single IPAM pool is in use.
+type PoolUsage struct {
+	// Network is the name of the network the pool belongs to.
+	Network string
+
+	// Subnet is the pool's subnet CIDR.
+	Subnet string
+
+	// IPRange is the sub-range used for dynamic allocation, if configured.
+	IPRange string
+
+	// Size is the number of addresses that can be assigned to container
+	// endpoints. Reserved addresses (network, broadcast, gateway and
+	// auxiliary addresses) are excluded. Very large IPv6 pools saturate
+	// at math.MaxUint64.
+	Size uint64
+
+	// Used is the number of container endpoint addresses that fall inside the pool.
+	Used uint64
+}
+
+// Available returns the number of addresses still free in the pool.
+// Saturated pools remain saturated.
+func (p PoolUsage) Available() uint64 {
+	if p.Size == math.MaxUint64 {
+		return p.Size
+	}
+	if p.Used >= p.Size {
+		return 0
+	}
+	return p.Size - p.Used
+}
+
+// Utilization returns the fraction of the pool in use, between 0 and 1.
+// A pool with no usable addresses is reported as fully utilized.
+func (p PoolUsage) Utilization() float64 {
+	if p.Size == 0 {
+		return 1
+	}
+	if p.Used >= p.Size {
+		return 1
+	}
+	return float64(p.Used) / float64(p.Size)
+}
+
+// NearlyExhausted reports whether the pool's utilization is at or above the threshold.
+func (p PoolUsage) NearlyExhausted(threshold float64) bool {
+	return p.Utilization() >= threshold
+}
+
+// Pool returns the CIDR that addresses are allocated from: the IP range when
+// one is configured, otherwise the subnet.
+func (p PoolUsage) Pool() string {
+	if p.IPRange != "" {
+		return p.IPRange
+	}
+	return p.Subnet
+}
+
+// NetworkUsage calculates the utilization of every IPAM pool configured on a
+// network. The allocated slice holds the endpoint addresses of the containers
+// attached to the network; addresses may optionally carry a prefix length.
+//
+// Pools whose subnet cannot be parsed are skipped.
+func NetworkUsage(net models.NetworkInfo, allocated []string) []PoolUsage {
+	addrs := parseAddrs(allocated)
+
+	var result []PoolUsage
+	for _, s := range net.Subnets {
+		usage, ok := subnetUsage(net.Name, s, addrs)
+		if !ok {
+			continue
+		}
+		result = append(result, usage)
+	}
+
+	return result
+}
+
+// FormatCount formats an address count for display. Saturated counts of very
+// large IPv6 pools are shown as "2^64+" rather than as an exact number.
+func FormatCount(n uint64) string {
+	if n == math.MaxUint64 {
+		return "2^64+"
+	}
+	return strconv.FormatUint(n, 10)
+}
+
+// subnetUsage calculates the utilization of a single subnet.
+func subnetUsage(network string, s models.SubnetInfo, addrs []netip.Addr) (PoolUsage, bool) {
+	subnet, err := netip.ParsePrefix(s.Subnet)
+	if err != nil {
+		return PoolUsage{}, false
+	}
+	subnet = subnet.Masked()
+
+	pool := subnet
+	if s.IPRange != "" {
+		if r, err := netip.ParsePrefix(s.IPRange); err == nil {
+			pool = r.Masked()
+		}
+	}
+
+	usage := PoolUsage{
+		Network: network,
+		Subnet:  s.Subnet,
+		IPRange: s.IPRange,
+		Size:    prefixSize(pool),
+	}
+
+	// Collect the addresses Docker never hands out to endpoints.
+	reserved := make(map[netip.Addr]bool)
+	hostBits := subnet.Addr().BitLen() - subnet.Bits()
+	if hostBits >= 2 {
+		reserved[subnet.Addr()] = true
+		if subnet.Addr().Is4() {
+			reserved[lastAddr(subnet)] = true
+		}
+	}
+	for _, raw := range append([]string{s.Gateway}, s.AuxAddresses...) {
+		if addr, ok := parseAddr(raw); ok {
+			reserved[addr] = true
+		}
+	}
+
+	for addr := range reserved {
+		if pool.Contains(addr) && usage.Size > 0 && usage.Size != math.MaxUint64 {
+			usage.Size--
+		}
+	}
+
+	for _, addr := range addrs {
+		if pool.Contains(addr) && !reserved[addr] {
+			usage.Used++
+		}
+	}
+
+	return usage, true
+}
+
+// prefixSize returns the number of addresses in a prefix, saturating at
+// math.MaxUint64 for prefixes with 64 or more host bits.
+func prefixSize(p netip.Prefix) uint64 {
+	hostBits := p.Addr().BitLen() - p.Bits()
+	if hostBits >= 64 {
+		return math.MaxUint64
+	}
+	return uint64(1) << uint(hostBits)
+}
+
+// lastAddr returns the highest address in an IPv4 prefix (its broadcast address).
+func lastAddr(p netip.Prefix) netip.Addr {
+	b := p.Addr().As4()
+	v := uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+	v |= math.MaxUint32 >> uint(p.Bits())
+	return netip.AddrFrom4([4]byte{byte(v >> 24), byte(v >> 16), byte(v >> 8), byte(v)})
+}
+
+// parseAddrs parses a list of endpoint addresses, ignoring invalid entries.
+func parseAddrs(raw []string) []netip.Addr {
+	result := make([]netip.Addr, 0, len(raw))
+	for _, r := range raw {
+		if addr, ok := parseAddr(r); ok {
+			result = append(result, addr)
+		}
+	}
+	return result
+}
+
+// parseAddr parses an address that may carry a "/prefix" suffix.
+func parseAddr(raw string) (netip.Addr, bool) {
+	if raw == "" {
+		return netip.Addr{}, false
+	}
+	if i := strings.IndexByte(raw, '/'); i >= 0 {
+		raw = raw[:i]
+	}
+	addr, err := netip.ParseAddr(raw)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return addr.Unmap(), true
+}
@@ -0,0 +1,164 @@
+package ipam
+
+import (
+	"math"
+	"testing"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+func TestNetworkUsage(t *testing.T) {
+	tests := []struct {
+		name      string
+		subnet    models.SubnetInfo
+		allocated []string
+		wantSize  uint64
+		wantUsed  uint64
+	}{
+		{
+			name:      "small /28 with gateway",
+			subnet:    models.SubnetInfo{Subnet: "172.20.0.0/28", Gateway: "172.20.0.1"},
+			allocated: []string{"172.20.0.2", "172.20.0.3"},
+			wantSize:  13,
+			wantUsed:  2,
+		},
+		{
+			name:      "addresses with prefix length",
+			subnet:    models.SubnetInfo{Subnet: "10.0.0.0/24", Gateway: "10.0.0.1"},
+			allocated: []string{"10.0.0.5/24", "10.0.0.6/24"},
+			wantSize:  253,
+			wantUsed:  2,
+		},
+		{
+			name:      "addresses outside the pool are ignored",
+			subnet:    models.SubnetInfo{Subnet: "10.0.0.0/24", Gateway: "10.0.0.1"},
+			allocated: []string{"10.0.1.5", "not-an-ip", ""},
+			wantSize:  253,
+			wantUsed:  0,
+		},
+		{
+			name: "ip range restricts the pool",
+			subnet: models.SubnetInfo{
+				Subnet:  "10.0.0.0/24",
+				IPRange: "10.0.0.16/28",
+				Gateway: "10.0.0.1",
+			},
+			allocated: []string{"10.0.0.17", "10.0.0.2"},
+			wantSize:  16,
+			wantUsed:  1,
+		},
+		{
+			name: "auxiliary addresses are reserved",
+			subnet: models.SubnetInfo{
+				Subnet:       "192.168.10.0/29",
+				Gateway:      "192.168.10.1",
+				AuxAddresses: []string{"192.168.10.2", "192.168.10.3"},
+			},
+			wantSize: 3,
+		},
+		{
+			name:     "large ipv6 pool saturates",
+			subnet:   models.SubnetInfo{Subnet: "fd00::/64", Gateway: "fd00::1"},
+			wantSize: math.MaxUint64,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			net := models.NetworkInfo{Name: "test_net", Subnets: []models.SubnetInfo{tt.subnet}}
+
+			usage := NetworkUsage(net, tt.allocated)
+			if len(usage) != 1 {
+				t.Fatalf("expected 1 pool, got %d", len(usage))
+			}
+			if usage[0].Network != "test_net" {
+				t.Errorf("Network = %q, want %q", usage[0].Network, "test_net")
+			}
+			if usage[0].Size != tt.wantSize {
+				t.Errorf("Size = %d, want %d", usage[0].Size, tt.wantSize)
+			}
+			if usage[0].Used != tt.wantUsed {
+				t.Errorf("Used = %d, want %d", usage[0].Used, tt.wantUsed)
+			}
+		})
+	}
+}
+
+func TestNetworkUsage_SkipsInvalidSubnets(t *testing.T) {
+	net := models.NetworkInfo{
+		Name: "test_net",
+		Subnets: []models.SubnetInfo{
+			{Subnet: "bogus"},
+			{Subnet: "10.1.0.0/16"},
+		},
+	}
+
+	usage := NetworkUsage(net, nil)
+	if len(usage) != 1 {
+		t.Fatalf("expected 1 pool, got %d", len(usage))
+	}
+	if usage[0].Subnet != "10.1.0.0/16" {
+		t.Errorf("Subnet = %q, want %q", usage[0].Subnet, "10.1.0.0/16")
+	}
+}
+
+func TestNetworkUsage_NoSubnets(t *testing.T) {
+	usage := NetworkUsage(models.NetworkInfo{Name: "host"}, []string{"10.0.0.1"})
+	if len(usage) != 0 {
+		t.Errorf("expected no pools, got %d", len(usage))
+	}
+}
+
+func TestPoolUsage_AvailableAndUtilization(t *testing.T) {
+	tests := []struct {
+		name      string
+		usage     PoolUsage
+		wantAvail uint64
+		wantUtil  float64
+	}{
+		{name: "half used", usage: PoolUsage{Size: 10, Used: 5}, wantAvail: 5, wantUtil: 0.5},
+		{name: "empty", usage: PoolUsage{Size: 10}, wantAvail: 10, wantUtil: 0},
+		{name: "over allocated", usage: PoolUsage{Size: 2, Used: 3}, wantAvail: 0, wantUtil: 1},
+		{name: "zero size", usage: PoolUsage{}, wantAvail: 0, wantUtil: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.usage.Available(); got != tt.wantAvail {
+				t.Errorf("Available() = %d, want %d", got, tt.wantAvail)
+			}
+			if got := tt.usage.Utilization(); got != tt.wantUtil {
+				t.Errorf("Utilization() = %v, want %v", got, tt.wantUtil)
+			}
+		})
+	}
+}
+
+func TestPoolUsage_Pool(t *testing.T) {
+	if got := (PoolUsage{Subnet: "10.0.0.0/24"}).Pool(); got != "10.0.0.0/24" {
+		t.Errorf("Pool() = %q, want subnet", got)
+	}
+	if got := (PoolUsage{Subnet: "10.0.0.0/24", IPRange: "10.0.0.0/28"}).Pool(); got != "10.0.0.0/28" {
+		t.Errorf("Pool() = %q, want ip range", got)
+	}
+}
+
+func TestPoolUsage_NearlyExhausted(t *testing.T) {
+	usage := PoolUsage{Size: 10, Used: 8}
+
+	if !usage.NearlyExhausted(DefaultWarnThreshold) {
+		t.Error("80% used pool should be nearly exhausted at the default threshold")
+	}
+	if usage.NearlyExhausted(0.9) {
+		t.Error("80% used pool should not be nearly exhausted at a 90% threshold")
+	}
+}
+
+func TestFormatCount(t *testing.T) {
+	if got := FormatCount(13); got != "13" {
+		t.Errorf("FormatCount(13) = %q, want %q", got, "13")
+	}
+	if got := FormatCount(math.MaxUint64); got != "2^64+" {
+		t.Errorf("FormatCount(MaxUint64) = %q, want %q", got, "2^64+")
+	}
+}
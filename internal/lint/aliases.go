@@ -0,0 +1,238 @@
+// Package lint checks Docker network topology for misconfigurations.
+package lint
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// Alias rule IDs.
+const (
+	// RuleAliasDuplicate flags an alias used by several containers on one network.
+	RuleAliasDuplicate = "alias-duplicate"
+
+	// RuleAliasShadowsContainer flags an alias equal to another container's name.
+	RuleAliasShadowsContainer = "alias-shadows-container"
+
+	// RuleAliasAmbiguous flags a name that means different containers on the
+	// different networks of a multi-homed container.
+	RuleAliasAmbiguous = "alias-ambiguous"
+)
+
+// aliasRules returns the alias collision and ambiguity rules. They work on
+// the per-network aliases collected by docker.Client.BuildContainerMap, and
+// ignore aliases equal to the container's own name.
+func aliasRules() []Rule {
+	return []Rule{
+		{
+			ID:       RuleAliasDuplicate,
+			Severity: SeverityError,
+			Description: "an alias is used by more than one container on the same network, " +
+				"other than replicas of one Compose service",
+			Check: checkAliasDuplicates,
+		},
+		{
+			ID:          RuleAliasShadowsContainer,
+			Severity:    SeverityError,
+			Description: "an alias is equal to the name of another container on the same network",
+			Check:       checkAliasShadows,
+		},
+		{
+			ID:       RuleAliasAmbiguous,
+			Severity: SeverityWarning,
+			Description: "a name resolves to different containers on the different networks " +
+				"of a multi-homed container",
+			Check: checkAliasAmbiguity,
+		},
+	}
+}
+
+// checkAliasDuplicates flags aliases used by several containers on one
+// network. Replicas of one Compose service share aliases for round-robin
+// load balancing and are not flagged.
+func checkAliasDuplicates(topo *Topology) []Finding {
+	var findings []Finding
+	resolvers := buildResolvers(topo.Containers)
+
+	for _, netName := range slices.Sorted(maps.Keys(resolvers)) {
+		r := resolvers[netName]
+		for _, alias := range slices.Sorted(maps.Keys(r.aliases)) {
+			holders := r.aliases[alias]
+			if len(holders) < 2 || replicasOfOneService(holders, topo.Containers) {
+				continue
+			}
+
+			findings = append(findings, Finding{
+				Message: fmt.Sprintf("alias %q on network %s is used by %d containers: %s",
+					alias, netName, len(holders), strings.Join(holders, ", ")),
+				Network: netName,
+				Alias:   alias,
+			})
+		}
+	}
+
+	return findings
+}
+
+// checkAliasShadows flags aliases equal to the name of another container on
+// the same network.
+func checkAliasShadows(topo *Topology) []Finding {
+	var findings []Finding
+	resolvers := buildResolvers(topo.Containers)
+
+	for _, netName := range slices.Sorted(maps.Keys(resolvers)) {
+		r := resolvers[netName]
+		for _, alias := range slices.Sorted(maps.Keys(r.aliases)) {
+			if !r.names[alias] {
+				continue
+			}
+			for _, holder := range r.aliases[alias] {
+				findings = append(findings, Finding{
+					Message: fmt.Sprintf("alias %q of %s on network %s shadows the container named %s",
+						alias, holder, netName, alias),
+					Container: holder,
+					Network:   netName,
+					Alias:     alias,
+				})
+			}
+		}
+	}
+
+	return findings
+}
+
+// checkAliasAmbiguity flags names that resolve to different containers on the
+// different networks of a multi-homed container, so the container reached
+// depends on which network answers first.
+func checkAliasAmbiguity(topo *Topology) []Finding {
+	var findings []Finding
+	resolvers := buildResolvers(topo.Containers)
+
+	for _, name := range slices.Sorted(maps.Keys(topo.Containers)) {
+		findings = append(findings, ambiguousNames(topo.Containers[name], resolvers)...)
+	}
+
+	return findings
+}
+
+// resolver holds the names registered on a single network.
+type resolver struct {
+	// names is the set of container names on the network.
+	names map[string]bool
+
+	// aliases maps each alias to the sorted names of the containers holding it.
+	aliases map[string][]string
+}
+
+// resolve returns the sorted containers a name resolves to on the network.
+func (r *resolver) resolve(name string) []string {
+	targets := append([]string(nil), r.aliases[name]...)
+	if r.names[name] && !slices.Contains(targets, name) {
+		targets = append(targets, name)
+		sort.Strings(targets)
+	}
+	return targets
+}
+
+// buildResolvers collects the container names and aliases on each network.
+func buildResolvers(containerMap map[string]*models.ContainerInfo) map[string]*resolver {
+	resolvers := make(map[string]*resolver)
+
+	get := func(netName string) *resolver {
+		r, ok := resolvers[netName]
+		if !ok {
+			r = &resolver{names: map[string]bool{}, aliases: map[string][]string{}}
+			resolvers[netName] = r
+		}
+		return r
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(containerMap)) {
+		c := containerMap[name]
+		for _, netName := range c.Networks {
+			r := get(netName)
+			r.names[c.Name] = true
+
+			for _, alias := range c.AliasesOn(netName) {
+				if alias == c.Name || slices.Contains(r.aliases[alias], c.Name) {
+					continue
+				}
+				r.aliases[alias] = append(r.aliases[alias], c.Name)
+			}
+		}
+	}
+
+	return resolvers
+}
+
+// ambiguousNames reports the names that resolve to different containers on
+// the different networks of a multi-homed container.
+func ambiguousNames(c *models.ContainerInfo, resolvers map[string]*resolver) []Finding {
+	networks := c.SortedNetworks()
+	if len(networks) < 2 {
+		return nil
+	}
+
+	// Collect every name visible on any of the container's networks.
+	names := map[string]bool{}
+	for _, netName := range networks {
+		r := resolvers[netName]
+		for name := range r.names {
+			names[name] = true
+		}
+		for alias := range r.aliases {
+			names[alias] = true
+		}
+	}
+
+	var findings []Finding
+	for _, name := range slices.Sorted(maps.Keys(names)) {
+		var parts []string
+		distinct := map[string]bool{}
+
+		for _, netName := range networks {
+			targets := resolvers[netName].resolve(name)
+			if len(targets) == 0 {
+				continue
+			}
+			key := strings.Join(targets, ", ")
+			distinct[key] = true
+			parts = append(parts, fmt.Sprintf("%s on %s", key, netName))
+		}
+
+		if len(distinct) < 2 {
+			continue
+		}
+
+		findings = append(findings, Finding{
+			Message: fmt.Sprintf("name %q is ambiguous for %s: resolves to %s",
+				name, c.Name, strings.Join(parts, "; ")),
+			Container: c.Name,
+			Alias:     name,
+		})
+	}
+
+	return findings
+}
+
+// replicasOfOneService reports whether all the named containers are replicas
+// of the same Compose service.
+func replicasOfOneService(names []string, containerMap map[string]*models.ContainerInfo) bool {
+	service := ""
+	for _, name := range names {
+		c := containerMap[name]
+		if c == nil || c.Service == "" {
+			return false
+		}
+		if service != "" && c.Service != service {
+			return false
+		}
+		service = c.Service
+	}
+	return true
+}
@@ -0,0 +1,120 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// aliasContainer creates a container with the given per-network aliases.
+func aliasContainer(name, service string, networkAliases map[string][]string) *models.ContainerInfo {
+	c := models.NewContainerInfo(name)
+	c.Service = service
+	for netName, aliases := range networkAliases {
+		c.AddNetwork(netName)
+		for _, alias := range aliases {
+			c.AddNetworkAlias(netName, alias)
+		}
+	}
+	return c
+}
+
+// checkAliases runs the alias rules over the containers.
+func checkAliases(t *testing.T, containerMap map[string]*models.ContainerInfo) []Finding {
+	t.Helper()
+
+	r := NewRegistry()
+	for _, rule := range aliasRules() {
+		if err := r.Register(rule); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	findings, err := r.Run(&Topology{Containers: containerMap}, Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return findings
+}
+
+// findingsFor returns the findings produced by a rule.
+func findingsFor(findings []Finding, rule string) []Finding {
+	var result []Finding
+	for _, f := range findings {
+		if f.Rule == rule {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+func TestCheckAliasesDuplicate(t *testing.T) {
+	containerMap := map[string]*models.ContainerInfo{
+		"postgres": aliasContainer("postgres", "", map[string][]string{"backend": {"postgres", "db"}}),
+		"mysql":    aliasContainer("mysql", "", map[string][]string{"backend": {"db"}}),
+		"web-1":    aliasContainer("web-1", "web", map[string][]string{"backend": {"web"}}),
+		"web-2":    aliasContainer("web-2", "web", map[string][]string{"backend": {"web"}}),
+	}
+
+	dups := findingsFor(checkAliases(t, containerMap), RuleAliasDuplicate)
+	if len(dups) != 1 {
+		t.Fatalf("expected 1 duplicate finding, got %+v", dups)
+	}
+
+	f := dups[0]
+	if f.Alias != "db" || f.Network != "backend" || f.Severity != SeverityError {
+		t.Errorf("unexpected finding: %+v", f)
+	}
+	if !strings.Contains(f.Message, "mysql, postgres") {
+		t.Errorf("expected holders in message, got %q", f.Message)
+	}
+}
+
+func TestCheckAliasesShadowsContainer(t *testing.T) {
+	containerMap := map[string]*models.ContainerInfo{
+		"db":    aliasContainer("db", "", map[string][]string{"backend": {"db"}}),
+		"cache": aliasContainer("cache", "", map[string][]string{"backend": {"db"}}),
+		"other": aliasContainer("other", "", map[string][]string{"frontend": {"db"}}),
+	}
+
+	shadows := findingsFor(checkAliases(t, containerMap), RuleAliasShadowsContainer)
+	if len(shadows) != 1 {
+		t.Fatalf("expected 1 shadow finding, got %+v", shadows)
+	}
+	if shadows[0].Container != "cache" || shadows[0].Network != "backend" {
+		t.Errorf("unexpected finding: %+v", shadows[0])
+	}
+}
+
+func TestCheckAliasesAmbiguous(t *testing.T) {
+	containerMap := map[string]*models.ContainerInfo{
+		"proxy": aliasContainer("proxy", "", map[string][]string{"frontend": nil, "backend": nil}),
+		"nginx": aliasContainer("nginx", "", map[string][]string{"frontend": {"web"}}),
+		"api":   aliasContainer("api", "", map[string][]string{"backend": {"web"}}),
+	}
+
+	ambiguous := findingsFor(checkAliases(t, containerMap), RuleAliasAmbiguous)
+	if len(ambiguous) != 1 {
+		t.Fatalf("expected 1 ambiguity finding, got %+v", ambiguous)
+	}
+
+	f := ambiguous[0]
+	if f.Container != "proxy" || f.Alias != "web" || f.Severity != SeverityWarning {
+		t.Errorf("unexpected finding: %+v", f)
+	}
+	if !strings.Contains(f.Message, "api on backend; nginx on frontend") {
+		t.Errorf("unexpected message: %q", f.Message)
+	}
+}
+
+func TestCheckAliasesClean(t *testing.T) {
+	containerMap := map[string]*models.ContainerInfo{
+		"api": aliasContainer("api", "", map[string][]string{"frontend": {"api"}, "backend": {"api", "api.internal"}}),
+		"db":  aliasContainer("db", "", map[string][]string{"backend": {"postgres"}}),
+	}
+
+	if findings := checkAliases(t, containerMap); len(findings) != 0 {
+		t.Errorf("expected no findings, got %+v", findings)
+	}
+}
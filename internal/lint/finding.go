@@ -0,0 +1,105 @@
+// Package lint checks Docker network topology for misconfigurations.
+// Checks produce findings with a rule ID and severity, which can be
+// rendered as text, JSON, SARIF or JUnit XML.
+package lint
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Severity is the importance of a finding.
+type Severity string
+
+// Severity levels, from most to least severe.
+const (
+	// SeverityError marks a misconfiguration that breaks connectivity or discovery.
+	SeverityError Severity = "error"
+
+	// SeverityWarning marks a configuration that is likely to cause problems.
+	SeverityWarning Severity = "warning"
+
+	// SeverityInfo marks a configuration worth reviewing.
+	SeverityInfo Severity = "info"
+)
+
+// rank orders severities; higher is more severe.
+func (s Severity) rank() int {
+	switch s {
+	case SeverityError:
+		return 3
+	case SeverityWarning:
+		return 2
+	case SeverityInfo:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// ParseSeverity parses a severity name.
+func ParseSeverity(name string) (Severity, error) {
+	s := Severity(name)
+	if s.rank() == 0 {
+		return "", fmt.Errorf("unknown severity %q: must be error, warning or info", name)
+	}
+	return s, nil
+}
+
+// AtLeast reports whether s is at least as severe as other.
+func (s Severity) AtLeast(other Severity) bool {
+	return s.rank() >= other.rank()
+}
+
+// Finding is a single problem detected in the topology.
+type Finding struct {
+	// Rule is the ID of the rule that produced the finding.
+	Rule string `json:"rule"`
+
+	// Severity is the importance of the finding.
+	Severity Severity `json:"severity"`
+
+	// Message describes the problem.
+	Message string `json:"message"`
+
+	// Container is the container concerned, if any.
+	Container string `json:"container,omitempty"`
+
+	// Network is the network concerned, if any.
+	Network string `json:"network,omitempty"`
+
+	// Alias is the alias concerned, if any.
+	Alias string `json:"alias,omitempty"`
+}
+
+// SortFindings sorts findings by severity (most severe first), then rule,
+// network, container and alias.
+func SortFindings(findings []Finding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Severity != b.Severity {
+			return a.Severity.rank() > b.Severity.rank()
+		}
+		if a.Rule != b.Rule {
+			return a.Rule < b.Rule
+		}
+		if a.Network != b.Network {
+			return a.Network < b.Network
+		}
+		if a.Container != b.Container {
+			return a.Container < b.Container
+		}
+		return a.Alias < b.Alias
+	})
+}
+
+// CountAtLeast returns the number of findings at least as severe as min.
+func CountAtLeast(findings []Finding, minSeverity Severity) int {
+	count := 0
+	for _, f := range findings {
+		if f.Severity.AtLeast(minSeverity) {
+			count++
+		}
+	}
+	return count
+}
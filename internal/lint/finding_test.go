@@ -0,0 +1,47 @@
+package lint
+
+import "testing"
+
+func TestParseSeverity(t *testing.T) {
+	for _, name := range []string{"error", "warning", "info"} {
+		s, err := ParseSeverity(name)
+		if err != nil || string(s) != name {
+			t.Errorf("ParseSeverity(%q) = %q, %v", name, s, err)
+		}
+	}
+
+	if _, err := ParseSeverity("fatal"); err == nil {
+		t.Error("expected error for unknown severity")
+	}
+}
+
+func TestSeverityAtLeast(t *testing.T) {
+	if !SeverityError.AtLeast(SeverityWarning) || !SeverityWarning.AtLeast(SeverityWarning) {
+		t.Error("expected error and warning to be at least warning")
+	}
+	if SeverityInfo.AtLeast(SeverityWarning) {
+		t.Error("expected info to be less severe than warning")
+	}
+}
+
+func TestSortFindings(t *testing.T) {
+	findings := []Finding{
+		{Rule: "b", Severity: SeverityWarning},
+		{Rule: "z", Severity: SeverityError, Network: "net2"},
+		{Rule: "a", Severity: SeverityInfo},
+		{Rule: "z", Severity: SeverityError, Network: "net1"},
+	}
+
+	SortFindings(findings)
+
+	want := []string{"z/net1", "z/net2", "b/", "a/"}
+	for i, f := range findings {
+		if got := f.Rule + "/" + f.Network; got != want[i] {
+			t.Errorf("position %d: got %s, want %s", i, got, want[i])
+		}
+	}
+
+	if n := CountAtLeast(findings, SeverityWarning); n != 3 {
+		t.Errorf("expected 3 findings at or above warning, got %d", n)
+	}
+}
@@ -0,0 +1,108 @@
+// Package lint checks Docker network topology for misconfigurations.
+package lint
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// junitSuiteName is the name of the JUnit test suite written by WriteJUnit.
+const junitSuiteName = "docker-network-viz lint"
+
+// junitTestSuites is the root element of a JUnit XML report.
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+// junitTestSuite holds the test cases of one rule.
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+// junitTestCase is a single finding, or a passing check for a rule without
+// findings. Findings below the failure threshold pass and are reported in
+// SystemOut.
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+// junitFailure describes a finding.
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit renders findings as JUnit XML with one test suite per rule. Each
+// finding is a test case named after the container, network or alias it
+// concerns. Findings at least as severe as failOn fail, with the severity as
+// the failure type; less severe ones pass and carry the finding as system
+// output, so the report agrees with the exit status of lint --fail-on. An
+// empty failOn fails every finding. Rules without findings get a single
+// passing test case, so CI reports show which checks ran.
+func WriteJUnit(w io.Writer, rules []Rule, findings []Finding, failOn Severity) error {
+	byRule := make(map[string][]Finding)
+	var order []string
+	for _, rule := range rules {
+		order = append(order, rule.ID)
+		byRule[rule.ID] = nil
+	}
+	for _, f := range findings {
+		if _, ok := byRule[f.Rule]; !ok {
+			order = append(order, f.Rule)
+		}
+		byRule[f.Rule] = append(byRule[f.Rule], f)
+	}
+
+	report := junitTestSuites{Name: junitSuiteName}
+	for _, id := range order {
+		suite := junitTestSuite{Name: id}
+
+		for _, f := range byRule[id] {
+			tc := junitTestCase{Name: subject(f), ClassName: id}
+			text := fmt.Sprintf("%s %s: %s", f.Severity, f.Rule, f.Message)
+			if f.Severity.AtLeast(failOn) {
+				tc.Failure = &junitFailure{Message: f.Message, Type: string(f.Severity), Text: text}
+				suite.Failures++
+			} else {
+				tc.SystemOut = text
+			}
+			suite.TestCases = append(suite.TestCases, tc)
+		}
+		if len(suite.TestCases) == 0 {
+			suite.TestCases = []junitTestCase{{Name: id, ClassName: id}}
+		}
+
+		suite.Tests = len(suite.TestCases)
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Suites = append(report.Suites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("failed to write JUnit report: %w", err)
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return fmt.Errorf("failed to encode JUnit report: %w", err)
+	}
+
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return fmt.Errorf("failed to write JUnit report: %w", err)
+	}
+
+	return nil
+}
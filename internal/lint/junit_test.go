@@ -0,0 +1,90 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestWriteJUnit(t *testing.T) {
+	buf := new(bytes.Buffer)
+	if err := Write(buf, FormatJUnit, reportRules(), reportFindings(), ReportOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.HasPrefix(buf.String(), xml.Header) {
+		t.Errorf("expected XML header, got:\n%s", buf.String())
+	}
+
+	var report junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("invalid XML: %v", err)
+	}
+
+	if report.Tests != 3 || report.Failures != 2 || len(report.Suites) != 3 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+
+	dup := report.Suites[1]
+	if dup.Name != RuleAliasDuplicate || dup.Failures != 1 || len(dup.TestCases) != 1 {
+		t.Fatalf("unexpected suite: %+v", dup)
+	}
+	tc := dup.TestCases[0]
+	if tc.Name != "network backend, alias db" || tc.ClassName != RuleAliasDuplicate {
+		t.Errorf("unexpected test case: %+v", tc)
+	}
+	if tc.Failure == nil || tc.Failure.Type != "error" || tc.Failure.Message != `alias "db" is duplicated` {
+		t.Errorf("unexpected failure: %+v", tc.Failure)
+	}
+
+	host := report.Suites[2]
+	if host.Name != RuleHostNetwork || host.Failures != 0 || len(host.TestCases) != 1 || host.TestCases[0].Failure != nil {
+		t.Errorf("expected a passing test case for a rule without findings, got %+v", host)
+	}
+}
+
+func TestWriteJUnit_FindingsWithoutRules(t *testing.T) {
+	buf := new(bytes.Buffer)
+	if err := WriteJUnit(buf, nil, reportFindings(), ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var report junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("invalid XML: %v", err)
+	}
+
+	if report.Tests != 2 || report.Failures != 2 {
+		t.Errorf("unexpected report: %+v", report)
+	}
+}
+
+func TestWriteJUnit_FailOn(t *testing.T) {
+	buf := new(bytes.Buffer)
+	if err := Write(buf, FormatJUnit, reportRules(), reportFindings(), ReportOptions{FailOn: SeverityError}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var report junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("invalid XML: %v", err)
+	}
+
+	if report.Tests != 3 || report.Failures != 1 {
+		t.Fatalf("expected only the error to fail, got %+v", report)
+	}
+
+	ambiguous := report.Suites[0]
+	if ambiguous.Name != RuleAliasAmbiguous || ambiguous.Failures != 0 || len(ambiguous.TestCases) != 1 {
+		t.Fatalf("unexpected suite: %+v", ambiguous)
+	}
+	if tc := ambiguous.TestCases[0]; tc.Failure != nil || !strings.HasPrefix(tc.SystemOut, "warning alias-ambiguous: ") {
+		t.Errorf("expected a passing test case with the warning as output, got %+v", tc)
+	}
+
+	dup := report.Suites[1]
+	if dup.Failures != 1 || dup.TestCases[0].Failure == nil || dup.TestCases[0].SystemOut != "" {
+		t.Errorf("expected the error to fail, got %+v", dup)
+	}
+}
@@ -0,0 +1,179 @@
+// Package lint checks Docker network topology for misconfigurations.
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Report formats.
+const (
+	// FormatText renders findings as human-readable lines.
+	FormatText = "text"
+
+	// FormatJSON renders findings as a JSON document.
+	FormatJSON = "json"
+
+	// FormatSARIF renders findings as a SARIF 2.1.0 log for code-scanning tools.
+	FormatSARIF = "sarif"
+
+	// FormatJUnit renders findings as JUnit XML for CI test reports.
+	FormatJUnit = "junit"
+)
+
+// Formats lists the supported report formats.
+var Formats = []string{FormatText, FormatJSON, FormatSARIF, FormatJUnit}
+
+// Summary counts findings by severity.
+type Summary struct {
+	// Errors is the number of error findings.
+	Errors int `json:"errors"`
+
+	// Warnings is the number of warning findings.
+	Warnings int `json:"warnings"`
+
+	// Infos is the number of info findings.
+	Infos int `json:"infos"`
+}
+
+// Summarize counts findings by severity.
+func Summarize(findings []Finding) Summary {
+	var s Summary
+	for _, f := range findings {
+		switch f.Severity {
+		case SeverityError:
+			s.Errors++
+		case SeverityWarning:
+			s.Warnings++
+		case SeverityInfo:
+			s.Infos++
+		}
+	}
+	return s
+}
+
+// String returns a summary such as "3 findings (1 error, 2 warnings, 0 infos)".
+func (s Summary) String() string {
+	total := s.Errors + s.Warnings + s.Infos
+	return fmt.Sprintf("%s (%s, %s, %s)",
+		plural(total, "finding"),
+		plural(s.Errors, "error"),
+		plural(s.Warnings, "warning"),
+		plural(s.Infos, "info"))
+}
+
+// ValidateFormat returns an error if format is not a supported report format.
+func ValidateFormat(format string) error {
+	for _, f := range Formats {
+		if f == format {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown format %q: must be one of %s", format, strings.Join(Formats, ", "))
+}
+
+// ReportOptions describes where the linted topology came from and which
+// findings fail.
+type ReportOptions struct {
+	// Source is the Compose file the topology was loaded from, as a
+	// slash-separated path relative to SourceRoot. It is empty for a live
+	// daemon, whose findings have no file to point at.
+	Source string
+
+	// SourceRoot is the absolute directory Source is relative to, such as
+	// the root of the repository the Compose file is in.
+	SourceRoot string
+
+	// FailOn is the lowest severity reported as a failure by the JUnit
+	// format. Empty reports every finding as a failure.
+	FailOn Severity
+}
+
+// Write renders findings in the given format. The rules that were run are
+// used by the SARIF and JUnit formats to describe each rule, including those
+// without findings. The SARIF format places findings in opts.Source, and
+// the JUnit format fails findings at least as severe as opts.FailOn; see
+// WriteSARIF and WriteJUnit.
+func Write(w io.Writer, format string, rules []Rule, findings []Finding, opts ReportOptions) error {
+	switch format {
+	case FormatText:
+		return WriteText(w, findings)
+	case FormatJSON:
+		return WriteJSON(w, findings)
+	case FormatSARIF:
+		return WriteSARIF(w, rules, findings, opts)
+	case FormatJUnit:
+		return WriteJUnit(w, rules, findings, opts.FailOn)
+	default:
+		return ValidateFormat(format)
+	}
+}
+
+// WriteText renders findings as one line each, followed by a summary line:
+//
+//	error   alias-duplicate: alias "db" on network backend is used by 2 containers: mysql, postgres
+//	1 finding (1 error, 0 warnings, 0 infos)
+func WriteText(w io.Writer, findings []Finding) error {
+	for _, f := range findings {
+		if _, err := fmt.Fprintf(w, "%-7s %s: %s\n", f.Severity, f.Rule, f.Message); err != nil {
+			return fmt.Errorf("failed to write findings: %w", err)
+		}
+	}
+
+	if _, err := fmt.Fprintln(w, Summarize(findings)); err != nil {
+		return fmt.Errorf("failed to write findings: %w", err)
+	}
+
+	return nil
+}
+
+// jsonReport is the document written by WriteJSON.
+type jsonReport struct {
+	Findings []Finding `json:"findings"`
+	Summary  Summary   `json:"summary"`
+}
+
+// WriteJSON renders findings and their summary as an indented JSON document.
+func WriteJSON(w io.Writer, findings []Finding) error {
+	report := jsonReport{Findings: findings, Summary: Summarize(findings)}
+	if report.Findings == nil {
+		report.Findings = []Finding{}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return fmt.Errorf("failed to encode findings: %w", err)
+	}
+
+	return nil
+}
+
+// subject describes the container, network and alias a finding concerns,
+// for example "container web, network backend".
+func subject(f Finding) string {
+	var parts []string
+	if f.Container != "" {
+		parts = append(parts, "container "+f.Container)
+	}
+	if f.Network != "" {
+		parts = append(parts, "network "+f.Network)
+	}
+	if f.Alias != "" {
+		parts = append(parts, "alias "+f.Alias)
+	}
+	if len(parts) == 0 {
+		return "topology"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// plural formats a count with a singular or plural noun.
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
@@ -0,0 +1,79 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// reportFindings returns findings of each severity.
+func reportFindings() []Finding {
+	return []Finding{
+		{Rule: RuleAliasDuplicate, Severity: SeverityError, Message: `alias "db" is duplicated`, Network: "backend", Alias: "db"},
+		{Rule: RuleAliasAmbiguous, Severity: SeverityWarning, Message: `name "web" is ambiguous`, Container: "proxy"},
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	buf := new(bytes.Buffer)
+	if err := Write(buf, FormatText, nil, reportFindings(), ReportOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "error   alias-duplicate: alias \"db\" is duplicated\n" +
+		"warning alias-ambiguous: name \"web\" is ambiguous\n" +
+		"2 findings (1 error, 1 warning, 0 infos)\n"
+	if buf.String() != want {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	buf := new(bytes.Buffer)
+	if err := Write(buf, FormatJSON, nil, reportFindings(), ReportOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var report jsonReport
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(report.Findings) != 2 || report.Summary.Errors != 1 || report.Summary.Warnings != 1 {
+		t.Errorf("unexpected report: %+v", report)
+	}
+	if !strings.Contains(buf.String(), `"severity": "error"`) {
+		t.Errorf("expected severity names in JSON, got:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := WriteJSON(buf, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"findings": []`) {
+		t.Errorf("expected empty findings array, got:\n%s", buf.String())
+	}
+}
+
+func TestWriteUnknownFormat(t *testing.T) {
+	if err := Write(new(bytes.Buffer), "xml", nil, nil, ReportOptions{}); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
+
+func TestSubject(t *testing.T) {
+	tests := []struct {
+		finding Finding
+		want    string
+	}{
+		{Finding{Container: "web", Network: "backend"}, "container web, network backend"},
+		{Finding{Alias: "db"}, "alias db"},
+		{Finding{}, "topology"},
+	}
+
+	for _, tt := range tests {
+		if got := subject(tt.finding); got != tt.want {
+			t.Errorf("subject(%+v) = %q, want %q", tt.finding, got, tt.want)
+		}
+	}
+}
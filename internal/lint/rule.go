@@ -0,0 +1,191 @@
+// Package lint checks Docker network topology for misconfigurations.
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// Topology is the state that rules check.
+type Topology struct {
+	// Networks holds all networks.
+	Networks []*models.NetworkInfo
+
+	// Containers maps container names to their network information.
+	Containers map[string]*models.ContainerInfo
+
+	// NetworkToContainers maps network names to the containers attached to them.
+	NetworkToContainers map[string][]models.ContainerInfo
+}
+
+// CheckFunc inspects the topology and returns its findings. The Rule and
+// Severity of the returned findings are filled in by the registry.
+type CheckFunc func(topo *Topology) []Finding
+
+// Rule is a single named check.
+type Rule struct {
+	// ID uniquely identifies the rule, for example "default-bridge".
+	ID string
+
+	// Severity is the default severity of the rule's findings.
+	Severity Severity
+
+	// Description explains what the rule flags.
+	Description string
+
+	// Check runs the rule.
+	Check CheckFunc
+}
+
+// RuleConfig overrides the defaults of a single rule.
+type RuleConfig struct {
+	// Enabled enables or disables the rule; nil keeps the default (enabled).
+	Enabled *bool
+
+	// Severity overrides the rule's severity; empty keeps the default.
+	Severity Severity
+}
+
+// Config selects and tunes the rules to run.
+type Config struct {
+	// Rules holds per-rule overrides keyed by rule ID.
+	Rules map[string]RuleConfig
+
+	// Disable lists rule IDs to skip, in addition to those disabled in Rules.
+	Disable []string
+}
+
+// Registry holds the rules available to the linter.
+type Registry struct {
+	rules map[string]Rule
+}
+
+// NewRegistry creates an empty rule registry.
+func NewRegistry() *Registry {
+	return &Registry{rules: make(map[string]Rule)}
+}
+
+// Register adds a rule to the registry. Returns an error if the rule is
+// incomplete or its ID is already registered.
+func (r *Registry) Register(rule Rule) error {
+	if rule.ID == "" || rule.Check == nil {
+		return fmt.Errorf("rule must have an ID and a check")
+	}
+	if rule.Severity.rank() == 0 {
+		return fmt.Errorf("rule %s has invalid severity %q", rule.ID, rule.Severity)
+	}
+	if _, ok := r.rules[rule.ID]; ok {
+		return fmt.Errorf("rule %s is already registered", rule.ID)
+	}
+
+	r.rules[rule.ID] = rule
+	return nil
+}
+
+// Rules returns the registered rules sorted by ID.
+func (r *Registry) Rules() []Rule {
+	rules := make([]Rule, 0, len(r.rules))
+	for _, rule := range r.rules {
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].ID < rules[j].ID
+	})
+	return rules
+}
+
+// Enabled returns the rules selected by the configuration, sorted by ID, with
+// their severity overrides applied. Returns an error if the configuration
+// refers to an unknown rule or an invalid severity, so typos do not silently
+// disable checks.
+func (r *Registry) Enabled(cfg Config) ([]Rule, error) {
+	if err := r.validate(cfg); err != nil {
+		return nil, err
+	}
+
+	disabled := make(map[string]bool, len(cfg.Disable))
+	for _, id := range cfg.Disable {
+		disabled[id] = true
+	}
+
+	var rules []Rule
+	for _, rule := range r.Rules() {
+		override := cfg.Rules[rule.ID]
+		if disabled[rule.ID] || (override.Enabled != nil && !*override.Enabled) {
+			continue
+		}
+		if override.Severity != "" {
+			rule.Severity = override.Severity
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// Run runs the rules selected by the configuration over the topology and
+// returns their findings, sorted by severity. See Enabled for the
+// configuration errors it reports.
+func (r *Registry) Run(topo *Topology, cfg Config) ([]Finding, error) {
+	rules, err := r.Enabled(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return RunRules(rules, topo), nil
+}
+
+// RunRules runs the given rules over the topology and returns their findings,
+// sorted by severity.
+func RunRules(rules []Rule, topo *Topology) []Finding {
+	var findings []Finding
+	for _, rule := range rules {
+		for _, f := range rule.Check(topo) {
+			f.Rule = rule.ID
+			f.Severity = rule.Severity
+			findings = append(findings, f)
+		}
+	}
+
+	SortFindings(findings)
+	return findings
+}
+
+// validate checks that the configuration only refers to registered rules.
+func (r *Registry) validate(cfg Config) error {
+	var unknown []string
+	for _, id := range cfg.Disable {
+		if _, ok := r.rules[id]; !ok {
+			unknown = append(unknown, id)
+		}
+	}
+	for id, override := range cfg.Rules {
+		if _, ok := r.rules[id]; !ok {
+			unknown = append(unknown, id)
+			continue
+		}
+		if override.Severity != "" && override.Severity.rank() == 0 {
+			return fmt.Errorf("rule %s has invalid severity %q", id, override.Severity)
+		}
+	}
+
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("unknown lint rule(s): %s", strings.Join(unknown, ", "))
+	}
+
+	return nil
+}
+
+// DefaultRegistry returns a registry with all built-in rules.
+func DefaultRegistry() *Registry {
+	r := NewRegistry()
+	for _, rule := range builtinRules() {
+		if err := r.Register(rule); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
@@ -0,0 +1,142 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+)
+
+// staticRule returns a rule that always reports one finding.
+func staticRule(id string, severity Severity) Rule {
+	return Rule{
+		ID:       id,
+		Severity: severity,
+		Check: func(*Topology) []Finding {
+			return []Finding{{Message: id + " fired"}}
+		},
+	}
+}
+
+func TestRegistryRegister(t *testing.T) {
+	r := NewRegistry()
+
+	if err := r.Register(staticRule("b", SeverityInfo)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := r.Register(staticRule("a", SeverityError)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := r.Register(staticRule("a", SeverityError)); err == nil {
+		t.Error("expected error for duplicate rule")
+	}
+	if err := r.Register(Rule{ID: "c", Severity: SeverityError}); err == nil {
+		t.Error("expected error for rule without check")
+	}
+	if err := r.Register(staticRule("d", "fatal")); err == nil {
+		t.Error("expected error for invalid severity")
+	}
+
+	rules := r.Rules()
+	if len(rules) != 2 || rules[0].ID != "a" || rules[1].ID != "b" {
+		t.Errorf("expected rules sorted by ID, got %+v", rules)
+	}
+}
+
+func TestRegistryRun(t *testing.T) {
+	r := NewRegistry()
+	for _, rule := range []Rule{
+		staticRule("low", SeverityInfo),
+		staticRule("high", SeverityError),
+		staticRule("off", SeverityWarning),
+		staticRule("skipped", SeverityWarning),
+	} {
+		if err := r.Register(rule); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	disabled := false
+	findings, err := r.Run(&Topology{}, Config{
+		Rules: map[string]RuleConfig{
+			"low": {Severity: SeverityWarning},
+			"off": {Enabled: &disabled},
+		},
+		Disable: []string{"skipped"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(findings) != 2 {
+		t.Fatalf("expected 2 findings, got %+v", findings)
+	}
+	if findings[0].Rule != "high" || findings[0].Severity != SeverityError {
+		t.Errorf("unexpected first finding: %+v", findings[0])
+	}
+	if findings[1].Rule != "low" || findings[1].Severity != SeverityWarning {
+		t.Errorf("expected severity override, got %+v", findings[1])
+	}
+}
+
+func TestRegistryRunInvalidConfig(t *testing.T) {
+	r := NewRegistry()
+	if err := r.Register(staticRule("known", SeverityInfo)); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := r.Run(&Topology{}, Config{Disable: []string{"typo"}, Rules: map[string]RuleConfig{"other": {}}})
+	if err == nil || !strings.Contains(err.Error(), "other, typo") {
+		t.Errorf("expected unknown rule error, got %v", err)
+	}
+
+	_, err = r.Run(&Topology{}, Config{Rules: map[string]RuleConfig{"known": {Severity: "fatal"}}})
+	if err == nil {
+		t.Error("expected error for invalid severity override")
+	}
+}
+
+func TestDefaultRegistry(t *testing.T) {
+	ids := map[string]bool{}
+	for _, rule := range DefaultRegistry().Rules() {
+		if rule.Description == "" {
+			t.Errorf("rule %s should have a description", rule.ID)
+		}
+		ids[rule.ID] = true
+	}
+
+	for _, id := range []string{
+		RuleAliasDuplicate, RuleAliasShadowsContainer, RuleAliasAmbiguous,
+		RuleDefaultBridge, RuleNetworkUnused, RuleInternalBridged,
+		RuleDatabasePortPublished, RuleContainerNoNetwork, RuleHostNetwork,
+	} {
+		if !ids[id] {
+			t.Errorf("default registry should contain %s", id)
+		}
+	}
+}
+
+func TestRegistryEnabled(t *testing.T) {
+	r := NewRegistry()
+	for _, rule := range []Rule{staticRule("a", SeverityInfo), staticRule("b", SeverityInfo)} {
+		if err := r.Register(rule); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rules, err := r.Enabled(Config{
+		Rules:   map[string]RuleConfig{"b": {Severity: SeverityError}},
+		Disable: []string{"a"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(rules) != 1 || rules[0].ID != "b" || rules[0].Severity != SeverityError {
+		t.Errorf("unexpected rules: %+v", rules)
+	}
+
+	// The registered rule keeps its default severity.
+	if r.Rules()[1].Severity != SeverityInfo {
+		t.Error("Enabled should not modify registered rules")
+	}
+}
@@ -0,0 +1,252 @@
+// Package lint checks Docker network topology for misconfigurations.
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// Topology rule IDs.
+const (
+	// RuleDefaultBridge flags containers on the default bridge network.
+	RuleDefaultBridge = "default-bridge"
+
+	// RuleNetworkUnused flags user-defined networks without containers.
+	RuleNetworkUnused = "network-unused"
+
+	// RuleInternalBridged flags containers bridging internal and external networks.
+	RuleInternalBridged = "internal-bridged"
+
+	// RuleDatabasePortPublished flags database ports published on all interfaces.
+	RuleDatabasePortPublished = "database-port-published"
+
+	// RuleContainerNoNetwork flags containers without network connectivity.
+	RuleContainerNoNetwork = "container-no-network"
+
+	// RuleHostNetwork flags containers using the host's network stack.
+	RuleHostNetwork = "host-network"
+
+	// RuleHostPortConflict flags host ports published by several containers.
+	RuleHostPortConflict = "host-port-conflict"
+)
+
+// builtinRules returns every rule shipped with the linter.
+func builtinRules() []Rule {
+	rules := aliasRules()
+	return append(rules,
+		Rule{
+			ID:          RuleDefaultBridge,
+			Severity:    SeverityWarning,
+			Description: "a container is attached to the default bridge or podman network, which has no DNS-based discovery",
+			Check:       checkDefaultBridge,
+		},
+		Rule{
+			ID:          RuleNetworkUnused,
+			Severity:    SeverityInfo,
+			Description: "a user-defined network has no containers attached",
+			Check:       checkNetworkUnused,
+		},
+		Rule{
+			ID:          RuleInternalBridged,
+			Severity:    SeverityWarning,
+			Description: "a container is attached to both an internal and a non-internal network",
+			Check:       checkInternalBridged,
+		},
+		Rule{
+			ID:          RuleDatabasePortPublished,
+			Severity:    SeverityError,
+			Description: "a well-known database port is published on all host interfaces",
+			Check:       checkDatabasePortPublished,
+		},
+		Rule{
+			ID:          RuleContainerNoNetwork,
+			Severity:    SeverityWarning,
+			Description: "a container is not attached to any network",
+			Check:       checkContainerNoNetwork,
+		},
+		Rule{
+			ID:          RuleHostNetwork,
+			Severity:    SeverityInfo,
+			Description: "a container uses the host's network stack and bypasses network isolation",
+			Check:       checkHostNetwork,
+		},
+		Rule{
+			ID:          RuleHostPortConflict,
+			Severity:    SeverityError,
+			Description: "several containers publish the same host port on overlapping addresses",
+			Check:       checkHostPortConflict,
+		},
+	)
+}
+
+// checkDefaultBridge flags containers on the default bridge network of
+// Docker or Podman.
+func checkDefaultBridge(topo *Topology) []Finding {
+	var findings []Finding
+	for _, c := range models.SortedContainers(topo.Containers) {
+		for _, netName := range []string{models.BridgeNetwork, models.PodmanDefaultNetwork} {
+			if c.HasNetwork(netName) && c.NetworkNamespace == "" {
+				findings = append(findings, Finding{
+					Message:   fmt.Sprintf("container %s is attached to the default %s network", c.Name, netName),
+					Container: c.Name,
+					Network:   netName,
+				})
+			}
+		}
+	}
+	return findings
+}
+
+// checkNetworkUnused flags user-defined networks without containers.
+func checkNetworkUnused(topo *Topology) []Finding {
+	var findings []Finding
+	for _, net := range topo.Networks {
+		if models.IsPredefinedNetwork(net.Name) || len(topo.NetworkToContainers[net.Name]) > 0 {
+			continue
+		}
+		findings = append(findings, Finding{
+			Message: fmt.Sprintf("network %s has no containers", net.Name),
+			Network: net.Name,
+		})
+	}
+	return findings
+}
+
+// checkInternalBridged flags containers attached to both internal and
+// non-internal networks, which gives the internal network a path out.
+func checkInternalBridged(topo *Topology) []Finding {
+	internal := make(map[string]bool, len(topo.Networks))
+	for _, net := range topo.Networks {
+		internal[net.Name] = net.Internal
+	}
+
+	var findings []Finding
+	for _, c := range models.SortedContainers(topo.Containers) {
+		// Pod members share the owner's endpoints; the owner is reported.
+		if c.NetworkNamespace != "" {
+			continue
+		}
+
+		var internalNets, externalNets []string
+		for _, netName := range c.SortedNetworks() {
+			switch {
+			case netName == models.NoneNetwork:
+			case internal[netName]:
+				internalNets = append(internalNets, netName)
+			default:
+				externalNets = append(externalNets, netName)
+			}
+		}
+
+		if len(internalNets) == 0 || len(externalNets) == 0 {
+			continue
+		}
+
+		findings = append(findings, Finding{
+			Message: fmt.Sprintf("container %s bridges internal network(s) %s and non-internal network(s) %s",
+				c.Name, strings.Join(internalNets, ", "), strings.Join(externalNets, ", ")),
+			Container: c.Name,
+			Network:   internalNets[0],
+		})
+	}
+	return findings
+}
+
+// checkDatabasePortPublished flags well-known database ports published on
+// all host interfaces.
+func checkDatabasePortPublished(topo *Topology) []Finding {
+	var findings []Finding
+	for _, c := range models.SortedContainers(topo.Containers) {
+		seen := make(map[uint16]bool)
+		for _, p := range c.Ports {
+			product, ok := models.DatabasePortProduct(p.PrivatePort)
+			if !ok || !p.IsPublic() || seen[p.PublicPort] {
+				continue
+			}
+			seen[p.PublicPort] = true
+
+			findings = append(findings, Finding{
+				Message: fmt.Sprintf("container %s publishes %s port %d on all interfaces as host port %d",
+					c.Name, product, p.PrivatePort, p.PublicPort),
+				Container: c.Name,
+			})
+		}
+	}
+	return findings
+}
+
+// checkContainerNoNetwork flags containers without any network other than
+// "none". Containers sharing another container's network namespace are not
+// flagged.
+func checkContainerNoNetwork(topo *Topology) []Finding {
+	var findings []Finding
+	for _, c := range models.SortedContainers(topo.Containers) {
+		if strings.HasPrefix(c.NetworkMode, "container:") {
+			continue
+		}
+
+		connected := false
+		for _, netName := range c.Networks {
+			if netName != models.NoneNetwork {
+				connected = true
+				break
+			}
+		}
+		if connected {
+			continue
+		}
+
+		findings = append(findings, Finding{
+			Message:   fmt.Sprintf("container %s is not attached to any network", c.Name),
+			Container: c.Name,
+		})
+	}
+	return findings
+}
+
+// checkHostNetwork flags containers using the host network.
+func checkHostNetwork(topo *Topology) []Finding {
+	var findings []Finding
+	for _, c := range models.SortedContainers(topo.Containers) {
+		if c.NetworkNamespace != "" {
+			continue
+		}
+		if c.HasNetwork(models.HostNetwork) || c.NetworkMode == models.HostNetwork {
+			findings = append(findings, Finding{
+				Message:   fmt.Sprintf("container %s uses the host network", c.Name),
+				Container: c.Name,
+				Network:   models.HostNetwork,
+			})
+		}
+	}
+	return findings
+}
+
+// checkHostPortConflict flags host ports that several containers publish on
+// overlapping addresses, naming the stopped containers that will fail to
+// start because a running container holds the port.
+func checkHostPortConflict(topo *Topology) []Finding {
+	var findings []Finding
+	for _, conflict := range models.FindPortConflicts(topo.Containers) {
+		bindings := make([]string, len(conflict.Bindings))
+		for i, b := range conflict.Bindings {
+			bindings[i] = fmt.Sprintf("%s on %s", b.Container, b.Address())
+		}
+		message := fmt.Sprintf("host port %d/%s is published by %s",
+			conflict.Port, conflict.Type, strings.Join(bindings, ", "))
+
+		container := conflict.Containers()[0]
+		if blocked := conflict.Blocked(); len(blocked) > 0 {
+			message += fmt.Sprintf("; %s will fail to start", strings.Join(blocked, ", "))
+			container = blocked[0]
+		}
+
+		findings = append(findings, Finding{
+			Message:   message,
+			Container: container,
+		})
+	}
+	return findings
+}
@@ -0,0 +1,127 @@
+package lint
+
+import (
+	"testing"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// rulesTopology returns a topology that triggers every topology rule once.
+func rulesTopology() *Topology {
+	containers := map[string]*models.ContainerInfo{}
+	add := func(name string, networks ...string) *models.ContainerInfo {
+		c := models.NewContainerInfo(name)
+		for _, n := range networks {
+			c.AddNetwork(n)
+		}
+		containers[name] = c
+		return c
+	}
+
+	add("legacy", "bridge")
+	proxy := add("proxy", "frontend", "backend")
+	proxy.Ports = []models.PortInfo{{IP: "0.0.0.0", PrivatePort: 80, PublicPort: 8080, Type: "tcp"}}
+	web := add("web", "frontend")
+	web.State = "exited"
+	web.Ports = []models.PortInfo{{IP: "127.0.0.1", PrivatePort: 8080, PublicPort: 8080, Type: "tcp"}}
+	add("api", "backend")
+	add("isolated", "none")
+	add("agent", "host").NetworkMode = "host"
+	add("sidecar").NetworkMode = "container:api"
+
+	db := add("db", "backend")
+	db.Ports = []models.PortInfo{
+		{IP: "0.0.0.0", PrivatePort: 5432, PublicPort: 5432, Type: "tcp"},
+		{IP: "::", PrivatePort: 5432, PublicPort: 5432, Type: "tcp"},
+	}
+	cache := add("cache", "backend")
+	cache.Ports = []models.PortInfo{{IP: "127.0.0.1", PrivatePort: 6379, PublicPort: 6379, Type: "tcp"}}
+
+	netMap := map[string][]models.ContainerInfo{}
+	for _, c := range containers {
+		for _, n := range c.Networks {
+			netMap[n] = append(netMap[n], *c)
+		}
+	}
+
+	return &Topology{
+		Networks: []*models.NetworkInfo{
+			{Name: "bridge", Driver: "bridge"},
+			{Name: "host", Driver: "host"},
+			{Name: "none", Driver: "null"},
+			{Name: "frontend", Driver: "bridge"},
+			{Name: "backend", Driver: "bridge", Internal: true},
+			{Name: "stale", Driver: "bridge"},
+		},
+		Containers:          containers,
+		NetworkToContainers: netMap,
+	}
+}
+
+func TestTopologyRules(t *testing.T) {
+	topo := rulesTopology()
+
+	tests := []struct {
+		check     CheckFunc
+		container string
+		network   string
+	}{
+		{checkDefaultBridge, "legacy", "bridge"},
+		{checkNetworkUnused, "", "stale"},
+		{checkInternalBridged, "proxy", "backend"},
+		{checkDatabasePortPublished, "db", ""},
+		{checkContainerNoNetwork, "isolated", ""},
+		{checkHostNetwork, "agent", "host"},
+		{checkHostPortConflict, "web", ""},
+	}
+
+	for _, tt := range tests {
+		findings := tt.check(topo)
+		if len(findings) != 1 {
+			t.Errorf("expected 1 finding for %s/%s, got %+v", tt.container, tt.network, findings)
+			continue
+		}
+		if findings[0].Container != tt.container || findings[0].Network != tt.network {
+			t.Errorf("unexpected finding: %+v", findings[0])
+		}
+		if findings[0].Message == "" {
+			t.Error("finding should have a message")
+		}
+	}
+}
+
+func TestTopologyRules_Podman(t *testing.T) {
+	infra := models.NewContainerInfo("3f2b1c9d8e7a-infra")
+	infra.AddNetwork("podman")
+	infra.Pod = "shop"
+	app := models.NewContainerInfo("app")
+	app.AddNetwork("podman")
+	app.Pod = "shop"
+	app.NetworkNamespace = "3f2b1c9d8e7a-infra"
+
+	topo := &Topology{
+		Networks:   []*models.NetworkInfo{{Name: "podman", Driver: "bridge"}},
+		Containers: map[string]*models.ContainerInfo{"3f2b1c9d8e7a-infra": infra, "app": app},
+	}
+
+	findings := checkDefaultBridge(topo)
+	if len(findings) != 1 || findings[0].Container != "3f2b1c9d8e7a-infra" || findings[0].Network != "podman" {
+		t.Errorf("expected only the pod owner on the podman network to be flagged, got %+v", findings)
+	}
+
+	if findings := checkNetworkUnused(topo); len(findings) != 0 {
+		t.Errorf("expected the podman network to be treated as predefined, got %+v", findings)
+	}
+}
+
+func TestCheckHostPortConflict_Message(t *testing.T) {
+	findings := checkHostPortConflict(rulesTopology())
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %+v", findings)
+	}
+
+	want := "host port 8080/tcp is published by proxy on 0.0.0.0:8080, web on 127.0.0.1:8080; web will fail to start"
+	if findings[0].Message != want {
+		t.Errorf("Message = %q, want %q", findings[0].Message, want)
+	}
+}
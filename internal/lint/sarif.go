@@ -0,0 +1,219 @@
+// Package lint checks Docker network topology for misconfigurations.
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// SARIF log constants.
+const (
+	// sarifVersion is the SARIF specification version written.
+	sarifVersion = "2.1.0"
+
+	// sarifSchema is the JSON schema of the SARIF version written.
+	sarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+	// sarifToolName is the tool name reported in SARIF logs.
+	sarifToolName = "docker-network-viz"
+
+	// sarifSourceRoot is the base ID the Compose file's URI is relative to.
+	// Code scanning tools resolve it to the root of the checkout.
+	sarifSourceRoot = "%SRCROOT%"
+)
+
+// sarifLog is the top-level SARIF document.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+// sarifRun holds the results of one run of the linter.
+type sarifRun struct {
+	Tool               sarifTool                        `json:"tool"`
+	OriginalURIBaseIDs map[string]sarifArtifactLocation `json:"originalUriBaseIds,omitempty"`
+	Results            []sarifResult                    `json:"results"`
+}
+
+// sarifTool describes the linter and its rules.
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+// sarifDriver is the tool component that produced the results.
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+// sarifRule describes a rule (a SARIF reportingDescriptor).
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+// sarifConfiguration holds a rule's default level.
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+// sarifMessage is a plain text message.
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+// sarifResult is a single finding.
+type sarifResult struct {
+	RuleID     string            `json:"ruleId"`
+	RuleIndex  *int              `json:"ruleIndex,omitempty"`
+	Level      string            `json:"level"`
+	Message    sarifMessage      `json:"message"`
+	Locations  []sarifLocation   `json:"locations,omitempty"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+// sarifLocation places a result in an artifact and on logical locations.
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+// sarifPhysicalLocation is the artifact a result is reported in.
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+// sarifArtifactLocation identifies an artifact by URI, relative to the base
+// URI named by URIBaseID if set.
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+// sarifRegion is a region of an artifact.
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// sarifLogicalLocation names a container, network or alias.
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// sarifLevel maps a severity to a SARIF result level.
+func sarifLevel(s Severity) string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// sarifRootURI returns the file URI of the directory root, with the
+// trailing slash SARIF requires of base URIs.
+func sarifRootURI(root string) string {
+	path := filepath.ToSlash(root)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	if !strings.HasSuffix(path, "/") {
+		path += "/"
+	}
+	return (&url.URL{Scheme: "file", Path: path}).String()
+}
+
+// WriteSARIF renders findings as an indented SARIF 2.1.0 log. Every rule that
+// was run is listed in the tool's rules. Each result references its rule and
+// the container, network and alias concerned as logical locations. When
+// opts.Source names the Compose file the topology was loaded from, each
+// result is also placed at line 1 of the file, relative to %SRCROOT%, which
+// is set to opts.SourceRoot if given. Findings against a live daemon have no
+// physical location, and code scanning tools such as GitHub's drop them, so
+// lint the Compose files for those.
+func WriteSARIF(w io.Writer, rules []Rule, findings []Finding, opts ReportOptions) error {
+	driver := sarifDriver{Name: sarifToolName, Rules: []sarifRule{}}
+	index := make(map[string]int, len(rules))
+	for i, rule := range rules {
+		index[rule.ID] = i
+		driver.Rules = append(driver.Rules, sarifRule{
+			ID:                   rule.ID,
+			ShortDescription:     sarifMessage{Text: rule.Description},
+			DefaultConfiguration: sarifConfiguration{Level: sarifLevel(rule.Severity)},
+		})
+	}
+
+	results := make([]sarifResult, 0, len(findings))
+	for _, f := range findings {
+		result := sarifResult{
+			RuleID:     f.Rule,
+			Level:      sarifLevel(f.Severity),
+			Message:    sarifMessage{Text: f.Message},
+			Properties: map[string]string{},
+		}
+		if i, ok := index[f.Rule]; ok {
+			result.RuleIndex = &i
+		}
+
+		var locations []sarifLogicalLocation
+		for _, loc := range []struct{ kind, name string }{
+			{"container", f.Container},
+			{"network", f.Network},
+			{"alias", f.Alias},
+		} {
+			if loc.name == "" {
+				continue
+			}
+			result.Properties[loc.kind] = loc.name
+			locations = append(locations, sarifLogicalLocation{
+				Name:               loc.name,
+				FullyQualifiedName: loc.kind + "/" + loc.name,
+				Kind:               "resource",
+			})
+		}
+		location := sarifLocation{LogicalLocations: locations}
+		if opts.Source != "" {
+			location.PhysicalLocation = &sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: opts.Source, URIBaseID: sarifSourceRoot},
+				Region:           sarifRegion{StartLine: 1},
+			}
+		}
+		if location.PhysicalLocation != nil || len(location.LogicalLocations) > 0 {
+			result.Locations = []sarifLocation{location}
+		}
+
+		results = append(results, result)
+	}
+
+	run := sarifRun{Tool: sarifTool{Driver: driver}, Results: results}
+	if opts.Source != "" && opts.SourceRoot != "" {
+		run.OriginalURIBaseIDs = map[string]sarifArtifactLocation{
+			sarifSourceRoot: {URI: sarifRootURI(opts.SourceRoot)},
+		}
+	}
+
+	log := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{run},
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(log); err != nil {
+		return fmt.Errorf("failed to encode SARIF log: %w", err)
+	}
+
+	return nil
+}
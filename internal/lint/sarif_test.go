@@ -0,0 +1,132 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+// reportRules returns the rules behind reportFindings, plus one without findings.
+func reportRules() []Rule {
+	noop := func(*Topology) []Finding { return nil }
+	return []Rule{
+		{ID: RuleAliasAmbiguous, Severity: SeverityWarning, Description: "ambiguous name", Check: noop},
+		{ID: RuleAliasDuplicate, Severity: SeverityError, Description: "duplicate alias", Check: noop},
+		{ID: RuleHostNetwork, Severity: SeverityInfo, Description: "host network", Check: noop},
+	}
+}
+
+func TestWriteSARIF(t *testing.T) {
+	buf := new(bytes.Buffer)
+	if err := Write(buf, FormatSARIF, reportRules(), reportFindings(), ReportOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	if log.Version != sarifVersion || len(log.Runs) != 1 {
+		t.Fatalf("unexpected log: %+v", log)
+	}
+
+	run := log.Runs[0]
+	if run.Tool.Driver.Name != sarifToolName || len(run.Tool.Driver.Rules) != 3 {
+		t.Errorf("unexpected driver: %+v", run.Tool.Driver)
+	}
+	if r := run.Tool.Driver.Rules[2]; r.ID != RuleHostNetwork || r.DefaultConfiguration.Level != "note" {
+		t.Errorf("unexpected rule: %+v", r)
+	}
+
+	if len(run.Results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(run.Results))
+	}
+
+	dup := run.Results[0]
+	if dup.RuleID != RuleAliasDuplicate || dup.RuleIndex == nil || *dup.RuleIndex != 1 || dup.Level != "error" {
+		t.Errorf("unexpected result: %+v", dup)
+	}
+	if dup.Properties["network"] != "backend" || dup.Properties["alias"] != "db" {
+		t.Errorf("unexpected properties: %v", dup.Properties)
+	}
+	if len(dup.Locations) != 1 || len(dup.Locations[0].LogicalLocations) != 2 ||
+		dup.Locations[0].LogicalLocations[0].FullyQualifiedName != "network/backend" {
+		t.Errorf("unexpected locations: %+v", dup.Locations)
+	}
+	if dup.Locations[0].PhysicalLocation != nil {
+		t.Errorf("expected no physical location for a live daemon, got %+v", dup.Locations[0].PhysicalLocation)
+	}
+	if run.OriginalURIBaseIDs != nil {
+		t.Errorf("expected no base URIs for a live daemon, got %v", run.OriginalURIBaseIDs)
+	}
+
+	ambiguous := run.Results[1]
+	if ambiguous.Level != "warning" || ambiguous.Properties["container"] != "proxy" {
+		t.Errorf("unexpected result: %+v", ambiguous)
+	}
+}
+
+func TestWriteSARIF_ComposeFile(t *testing.T) {
+	findings := append(reportFindings(), Finding{Rule: RuleHostNetwork, Severity: SeverityInfo, Message: "no resource"})
+
+	opts := ReportOptions{Source: "deploy/docker-compose.yml", SourceRoot: "/src/shop"}
+
+	buf := new(bytes.Buffer)
+	if err := WriteSARIF(buf, reportRules(), findings, opts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	run := log.Runs[0]
+	if got := run.OriginalURIBaseIDs[sarifSourceRoot].URI; got != "file:///src/shop/" {
+		t.Errorf("expected %s to be the source root, got %q", sarifSourceRoot, got)
+	}
+
+	if len(run.Results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(run.Results))
+	}
+	for _, r := range run.Results {
+		if len(r.Locations) != 1 || r.Locations[0].PhysicalLocation == nil {
+			t.Fatalf("expected a physical location, got %+v", r.Locations)
+		}
+		got := r.Locations[0].PhysicalLocation
+		if got.ArtifactLocation.URI != "deploy/docker-compose.yml" || got.ArtifactLocation.URIBaseID != sarifSourceRoot || got.Region.StartLine != 1 {
+			t.Errorf("expected every result in the Compose file, got %+v", got)
+		}
+	}
+}
+
+func TestSarifRootURI(t *testing.T) {
+	tests := map[string]string{
+		"/src/shop":     "file:///src/shop/",
+		"/src/my shop/": "file:///src/my%20shop/",
+	}
+
+	for root, want := range tests {
+		if got := sarifRootURI(root); got != want {
+			t.Errorf("sarifRootURI(%q) = %q, want %q", root, got, want)
+		}
+	}
+}
+
+func TestWriteSARIF_Empty(t *testing.T) {
+	buf := new(bytes.Buffer)
+	if err := WriteSARIF(buf, nil, nil, ReportOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var log map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	run := log["runs"].([]any)[0].(map[string]any)
+	if results, ok := run["results"].([]any); !ok || len(results) != 0 {
+		t.Errorf("expected empty results array, got %v", run["results"])
+	}
+}
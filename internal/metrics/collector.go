@@ -0,0 +1,140 @@
+// Package metrics exposes Docker network topology as Prometheus metrics.
+// It converts the internal topology models into gauges and renders them
+// in the Prometheus text exposition format.
+package metrics
+
+import (
+	"sort"
+	"time"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/ipam"
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+	"git.o.ocom.com.au/go/docker-network-viz/internal/output"
+)
+
+// NetworkMetric holds the per-network gauge values.
+type NetworkMetric struct {
+	// Name is the network name.
+	Name string
+
+	// Driver is the network driver.
+	Driver string
+
+	// Containers is the number of containers attached to the network.
+	Containers int
+}
+
+// ContainerMetric holds the per-container gauge values.
+type ContainerMetric struct {
+	// Name is the container name.
+	Name string
+
+	// Networks is the number of networks the container is attached to,
+	// excluding the "none" network.
+	Networks int
+
+	// Peers is the number of distinct containers reachable over any network
+	// that allows inter-container communication.
+	Peers int
+}
+
+// Snapshot is a point-in-time view of the topology metrics.
+type Snapshot struct {
+	// Networks holds per-network metrics sorted by name.
+	Networks []NetworkMetric
+
+	// Containers holds per-container metrics sorted by name.
+	Containers []ContainerMetric
+
+	// Pools holds the utilization of every IPAM pool, sorted by network and subnet.
+	Pools []ipam.PoolUsage
+
+	// Timestamp records when the snapshot was collected.
+	Timestamp time.Time
+}
+
+// Collect builds a metrics snapshot from the topology models.
+//
+// Parameters:
+//   - networks: The networks to report on
+//   - containerMap: Map of container names to ContainerInfo
+//   - netMap: Map of network names to the containers attached to each network
+//   - addresses: Map of network names to the endpoint addresses allocated on each network
+func Collect(
+	networks []*models.NetworkInfo,
+	containerMap map[string]*models.ContainerInfo,
+	netMap map[string][]models.ContainerInfo,
+	addresses map[string][]string,
+) *Snapshot {
+	s := &Snapshot{Timestamp: time.Now()}
+
+	for _, net := range networks {
+		s.Networks = append(s.Networks, NetworkMetric{
+			Name:       net.Name,
+			Driver:     net.Driver,
+			Containers: len(netMap[net.Name]),
+		})
+		s.Pools = append(s.Pools, ipam.NetworkUsage(*net, addresses[net.Name])...)
+	}
+
+	byName := output.NetworksByName(networks)
+	for name, c := range containerMap {
+		metric := ContainerMetric{Name: name}
+		peers := make(map[string]bool)
+		for _, net := range c.Networks {
+			// The none network provides no connectivity, so it is not
+			// counted as a network attachment.
+			if net == models.NoneNetwork {
+				continue
+			}
+			metric.Networks++
+			if !output.CanReach(net, byName) {
+				continue
+			}
+			for _, peer := range output.ReachableContainers(c.Name, net, netMap) {
+				peers[peer] = true
+			}
+		}
+		metric.Peers = len(peers)
+		s.Containers = append(s.Containers, metric)
+	}
+
+	sort.Slice(s.Networks, func(i, j int) bool {
+		return s.Networks[i].Name < s.Networks[j].Name
+	})
+	sort.Slice(s.Containers, func(i, j int) bool {
+		return s.Containers[i].Name < s.Containers[j].Name
+	})
+	sort.SliceStable(s.Pools, func(i, j int) bool {
+		if s.Pools[i].Network != s.Pools[j].Network {
+			return s.Pools[i].Network < s.Pools[j].Network
+		}
+		return s.Pools[i].Subnet < s.Pools[j].Subnet
+	})
+
+	return s
+}
+
+// IsolatedContainers returns the number of containers with no network
+// attachment at all (other than "none").
+func (s *Snapshot) IsolatedContainers() int {
+	count := 0
+	for _, c := range s.Containers {
+		if c.Networks == 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// ContainersWithoutPeers returns the number of containers that are attached
+// to at least one network but cannot reach any other container.
+func (s *Snapshot) ContainersWithoutPeers() int {
+	count := 0
+	for _, c := range s.Containers {
+		if c.Networks > 0 && c.Peers == 0 {
+			count++
+		}
+	}
+	return count
+}
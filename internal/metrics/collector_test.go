@@ -0,0 +1,102 @@
+package metrics
+
+import (
+	"testing"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// testTopology returns a small topology used across the metrics tests.
+func testTopology() ([]*models.NetworkInfo, map[string]*models.ContainerInfo, map[string][]models.ContainerInfo) {
+	web := models.ContainerInfo{Name: "web", Networks: []string{"frontend"}}
+	api := models.ContainerInfo{Name: "api", Networks: []string{"frontend", "backend"}}
+	db := models.ContainerInfo{Name: "db", Networks: []string{"backend"}}
+	lonely := models.ContainerInfo{Name: "lonely", Networks: []string{"solo"}}
+	offline := models.ContainerInfo{Name: "offline", Networks: []string{"none"}}
+
+	networks := []*models.NetworkInfo{
+		{Name: "frontend", Driver: "bridge", Subnets: []models.SubnetInfo{{Subnet: "10.0.0.0/28", Gateway: "10.0.0.1"}}},
+		{Name: "backend", Driver: "bridge"},
+		{Name: "solo", Driver: "bridge"},
+	}
+
+	containerMap := map[string]*models.ContainerInfo{
+		"web": &web, "api": &api, "db": &db, "lonely": &lonely, "offline": &offline,
+	}
+
+	netMap := map[string][]models.ContainerInfo{
+		"frontend": {api, web},
+		"backend":  {api, db},
+		"solo":     {lonely},
+		"none":     {offline},
+	}
+
+	return networks, containerMap, netMap
+}
+
+func TestCollect(t *testing.T) {
+	networks, containerMap, netMap := testTopology()
+	addresses := map[string][]string{"frontend": {"10.0.0.2", "10.0.0.3"}}
+
+	s := Collect(networks, containerMap, netMap, addresses)
+
+	if len(s.Networks) != 3 {
+		t.Fatalf("expected 3 network metrics, got %d", len(s.Networks))
+	}
+	if s.Networks[0].Name != "backend" || s.Networks[0].Containers != 2 {
+		t.Errorf("unexpected first network metric: %+v", s.Networks[0])
+	}
+
+	if len(s.Containers) != 5 {
+		t.Fatalf("expected 5 container metrics, got %d", len(s.Containers))
+	}
+	if s.Containers[0].Name != "api" || s.Containers[0].Networks != 2 || s.Containers[0].Peers != 2 {
+		t.Errorf("unexpected api metric: %+v", s.Containers[0])
+	}
+
+	if len(s.Pools) != 1 {
+		t.Fatalf("expected 1 pool, got %d", len(s.Pools))
+	}
+	if s.Pools[0].Used != 2 || s.Pools[0].Size != 13 {
+		t.Errorf("unexpected pool usage: %+v", s.Pools[0])
+	}
+
+	if s.Timestamp.IsZero() {
+		t.Error("Timestamp should be set")
+	}
+}
+
+func TestCollect_ICCDisabled(t *testing.T) {
+	networks, containerMap, netMap := testTopology()
+	networks[1].Options = map[string]string{models.BridgeICCOption: "false"}
+
+	s := Collect(networks, containerMap, netMap, nil)
+
+	// api only reaches web; db shares backend with it but cannot talk.
+	if s.Containers[0].Name != "api" || s.Containers[0].Networks != 2 || s.Containers[0].Peers != 1 {
+		t.Errorf("unexpected api metric: %+v", s.Containers[0])
+	}
+	if got := s.ContainersWithoutPeers(); got != 2 {
+		t.Errorf("ContainersWithoutPeers() = %d, want 2", got)
+	}
+}
+
+func TestSnapshot_IsolationCounts(t *testing.T) {
+	networks, containerMap, netMap := testTopology()
+	s := Collect(networks, containerMap, netMap, nil)
+
+	if got := s.IsolatedContainers(); got != 1 {
+		t.Errorf("IsolatedContainers() = %d, want 1", got)
+	}
+	if got := s.ContainersWithoutPeers(); got != 1 {
+		t.Errorf("ContainersWithoutPeers() = %d, want 1", got)
+	}
+}
+
+func TestCollect_Empty(t *testing.T) {
+	s := Collect(nil, nil, nil, nil)
+
+	if len(s.Networks) != 0 || len(s.Containers) != 0 || len(s.Pools) != 0 {
+		t.Errorf("expected empty snapshot, got %+v", s)
+	}
+}
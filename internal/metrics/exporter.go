@@ -0,0 +1,178 @@
+// Package metrics exposes Docker network topology as Prometheus metrics.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ContentType is the Prometheus text exposition format content type.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Exporter serves the most recent metrics snapshot over HTTP.
+// It is safe for concurrent use: snapshots are updated by a refresh loop
+// while scrapes are being served.
+type Exporter struct {
+	mu            sync.RWMutex
+	snapshot      *Snapshot
+	refreshErrors int
+}
+
+// NewExporter creates an Exporter with no snapshot.
+// Until the first Update, only the exporter's own metrics are served.
+func NewExporter() *Exporter {
+	return &Exporter{}
+}
+
+// Update replaces the snapshot served by the exporter.
+func (e *Exporter) Update(s *Snapshot) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.snapshot = s
+}
+
+// RecordError counts a failed refresh. The previous snapshot keeps being served.
+func (e *Exporter) RecordError() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.refreshErrors++
+}
+
+// ServeHTTP writes the current snapshot in the Prometheus text format.
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	e.mu.RLock()
+	snapshot := e.snapshot
+	refreshErrors := e.refreshErrors
+	e.mu.RUnlock()
+
+	w.Header().Set("Content-Type", ContentType)
+
+	bw := bufio.NewWriter(w)
+	if snapshot != nil {
+		snapshot.WriteText(bw)
+	}
+	writeHeader(bw, "docker_network_viz_refresh_errors_total", "counter",
+		"Total number of failed topology refreshes.")
+	writeSample(bw, "docker_network_viz_refresh_errors_total", nil, float64(refreshErrors))
+	_ = bw.Flush()
+}
+
+// WriteText renders the snapshot in the Prometheus text exposition format.
+func (s *Snapshot) WriteText(w io.Writer) {
+	writeHeader(w, "docker_network_containers", "gauge",
+		"Number of containers attached to the network.")
+	for _, n := range s.Networks {
+		writeSample(w, "docker_network_containers",
+			[]string{"network", n.Name, "driver", n.Driver}, float64(n.Containers))
+	}
+
+	writeHeader(w, "docker_container_networks", "gauge",
+		"Number of networks the container is attached to.")
+	for _, c := range s.Containers {
+		writeSample(w, "docker_container_networks",
+			[]string{"container", c.Name}, float64(c.Networks))
+	}
+
+	writeHeader(w, "docker_container_reachable_peers", "gauge",
+		"Number of other containers reachable from the container over any network.")
+	for _, c := range s.Containers {
+		writeSample(w, "docker_container_reachable_peers",
+			[]string{"container", c.Name}, float64(c.Peers))
+	}
+
+	writeHeader(w, "docker_containers_isolated", "gauge",
+		"Number of containers with no network attachments.")
+	writeSample(w, "docker_containers_isolated", nil, float64(s.IsolatedContainers()))
+
+	writeHeader(w, "docker_containers_without_peers", "gauge",
+		"Number of attached containers that cannot reach any other container.")
+	writeSample(w, "docker_containers_without_peers", nil, float64(s.ContainersWithoutPeers()))
+
+	writeHeader(w, "docker_network_ip_pool_size", "gauge",
+		"Number of addresses in the IPAM pool available to container endpoints.")
+	for _, p := range s.Pools {
+		writeSample(w, "docker_network_ip_pool_size", poolLabels(p.Network, p.Pool()), float64(p.Size))
+	}
+
+	writeHeader(w, "docker_network_ip_pool_used", "gauge",
+		"Number of IPAM pool addresses allocated to container endpoints.")
+	for _, p := range s.Pools {
+		writeSample(w, "docker_network_ip_pool_used", poolLabels(p.Network, p.Pool()), float64(p.Used))
+	}
+
+	writeHeader(w, "docker_network_ip_pool_utilization_ratio", "gauge",
+		"Fraction of the IPAM pool allocated to container endpoints.")
+	for _, p := range s.Pools {
+		writeSample(w, "docker_network_ip_pool_utilization_ratio", poolLabels(p.Network, p.Pool()), p.Utilization())
+	}
+
+	writeHeader(w, "docker_network_viz_last_refresh_timestamp_seconds", "gauge",
+		"Unix time of the last successful topology refresh.")
+	writeSample(w, "docker_network_viz_last_refresh_timestamp_seconds", nil,
+		float64(s.Timestamp.UnixNano())/1e9)
+}
+
+// poolLabels returns the label pairs identifying an IPAM pool.
+func poolLabels(network, pool string) []string {
+	return []string{"network", network, "pool", pool}
+}
+
+// writeHeader writes the HELP and TYPE lines for a metric family.
+func writeHeader(w io.Writer, name, metricType, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(w, "# TYPE %s %s\n", name, metricType)
+}
+
+// writeSample writes a single sample line. Labels are given as alternating
+// name/value pairs.
+func writeSample(w io.Writer, name string, labels []string, value float64) {
+	var sb strings.Builder
+	sb.WriteString(name)
+
+	if len(labels) > 0 {
+		sb.WriteByte('{')
+		for i := 0; i+1 < len(labels); i += 2 {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			sb.WriteString(labels[i])
+			sb.WriteString(`="`)
+			sb.WriteString(escapeLabelValue(labels[i+1]))
+			sb.WriteByte('"')
+		}
+		sb.WriteByte('}')
+	}
+
+	sb.WriteByte(' ')
+	sb.WriteString(formatValue(value))
+	sb.WriteByte('\n')
+
+	_, _ = io.WriteString(w, sb.String())
+}
+
+// escapeLabelValue escapes backslashes, double quotes and newlines as
+// required by the text exposition format.
+func escapeLabelValue(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+}
+
+// formatValue formats a sample value, using the special spellings the
+// exposition format defines for infinities and NaN.
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+}
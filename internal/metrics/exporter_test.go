@@ -0,0 +1,96 @@
+package metrics
+
+import (
+	"bytes"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSnapshot_WriteText(t *testing.T) {
+	networks, containerMap, netMap := testTopology()
+	s := Collect(networks, containerMap, netMap, map[string][]string{"frontend": {"10.0.0.2"}})
+
+	buf := new(bytes.Buffer)
+	s.WriteText(buf)
+	out := buf.String()
+
+	expected := []string{
+		"# TYPE docker_network_containers gauge",
+		`docker_network_containers{network="frontend",driver="bridge"} 2`,
+		`docker_container_networks{container="offline"} 0`,
+		`docker_container_networks{container="api"} 2`,
+		`docker_container_reachable_peers{container="web"} 1`,
+		"docker_containers_isolated 1",
+		"docker_containers_without_peers 1",
+		`docker_network_ip_pool_size{network="frontend",pool="10.0.0.0/28"} 13`,
+		`docker_network_ip_pool_used{network="frontend",pool="10.0.0.0/28"} 1`,
+		"docker_network_viz_last_refresh_timestamp_seconds ",
+	}
+
+	for _, want := range expected {
+		if !strings.Contains(out, want) {
+			t.Errorf("output should contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestExporter_ServeHTTP(t *testing.T) {
+	e := NewExporter()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", http.NoBody))
+
+	if ct := rec.Header().Get("Content-Type"); ct != ContentType {
+		t.Errorf("Content-Type = %q, want %q", ct, ContentType)
+	}
+	if strings.Contains(rec.Body.String(), "docker_network_containers") {
+		t.Error("no topology metrics should be served before the first update")
+	}
+
+	networks, containerMap, netMap := testTopology()
+	e.Update(Collect(networks, containerMap, netMap, nil))
+	e.RecordError()
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", http.NoBody))
+	body := rec.Body.String()
+
+	if !strings.Contains(body, "docker_network_containers") {
+		t.Error("topology metrics should be served after an update")
+	}
+	if !strings.Contains(body, "docker_network_viz_refresh_errors_total 1") {
+		t.Errorf("refresh error counter should be 1, got:\n%s", body)
+	}
+}
+
+func TestWriteSample_EscapesLabels(t *testing.T) {
+	buf := new(bytes.Buffer)
+	writeSample(buf, "metric", []string{"name", "a\"b\\c\nd"}, 1)
+
+	want := `metric{name="a\"b\\c\nd"} 1` + "\n"
+	if buf.String() != want {
+		t.Errorf("writeSample() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestFormatValue(t *testing.T) {
+	tests := []struct {
+		value float64
+		want  string
+	}{
+		{1, "1"},
+		{0.25, "0.25"},
+		{math.Inf(1), "+Inf"},
+		{math.Inf(-1), "-Inf"},
+		{math.NaN(), "NaN"},
+	}
+
+	for _, tt := range tests {
+		if got := formatValue(tt.value); got != tt.want {
+			t.Errorf("formatValue(%v) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// Package models provides data structures for docker-network-viz.
+package models
+
+import "sort"
+
+// BlastRadius lists the containers an attacker could move to from a
+// compromised container, hopping from container to container over shared
+// networks. Multi-homed containers act as pivots into their other networks.
+type BlastRadius struct {
+	// Container is the name of the compromised container.
+	Container string
+
+	// Hops are the reachable containers in the order they are first reached:
+	// sorted by depth, then by the order of the containers they are reached
+	// from, then by name.
+	Hops []BlastHop
+}
+
+// BlastHop is a container reachable from the compromised container and the
+// shortest way to it.
+type BlastHop struct {
+	// Container is the reachable container's name.
+	Container string
+
+	// Via is the container it is reached from: the compromised container for
+	// direct peers, otherwise a pivot.
+	Via string
+
+	// Networks are the networks shared with Via that carry the hop, sorted
+	// by name.
+	Networks []string
+
+	// Depth is the number of hops from the compromised container, starting
+	// at 1 for direct peers.
+	Depth int
+}
+
+// Path returns the chain of containers from the compromised container to the
+// named one, both included, or nil if the container is not reachable.
+func (b BlastRadius) Path(container string) []string {
+	byName := make(map[string]BlastHop, len(b.Hops))
+	for _, hop := range b.Hops {
+		byName[hop.Container] = hop
+	}
+
+	hop, ok := byName[container]
+	if !ok {
+		return nil
+	}
+
+	path := []string{container}
+	for hop.Via != b.Container {
+		hop = byName[hop.Via]
+		path = append([]string{hop.Container}, path...)
+	}
+	return append([]string{b.Container}, path...)
+}
+
+// Networks returns the networks used by any hop, sorted by name.
+func (b BlastRadius) Networks() []string {
+	seen := make(map[string]bool)
+	var networks []string
+	for _, hop := range b.Hops {
+		for _, n := range hop.Networks {
+			if !seen[n] {
+				seen[n] = true
+				networks = append(networks, n)
+			}
+		}
+	}
+	sort.Strings(networks)
+	return networks
+}
+
+// Pivots returns the containers, other than the compromised one, through
+// which further containers are reached, sorted by name.
+func (b BlastRadius) Pivots() []string {
+	seen := make(map[string]bool)
+	var pivots []string
+	for _, hop := range b.Hops {
+		if hop.Via != b.Container && !seen[hop.Via] {
+			seen[hop.Via] = true
+			pivots = append(pivots, hop.Via)
+		}
+	}
+	sort.Strings(pivots)
+	return pivots
+}
+
+// MaxDepth returns the number of hops to the farthest reachable container.
+func (b BlastRadius) MaxDepth() int {
+	depth := 0
+	for _, hop := range b.Hops {
+		if hop.Depth > depth {
+			depth = hop.Depth
+		}
+	}
+	return depth
+}
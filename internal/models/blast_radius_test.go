@@ -0,0 +1,56 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+// testBlastRadius returns a blast radius with a pivot two hops deep.
+func testBlastRadius() BlastRadius {
+	return BlastRadius{
+		Container: "web",
+		Hops: []BlastHop{
+			{Container: "api", Via: "web", Networks: []string{"frontend"}, Depth: 1},
+			{Container: "cache", Via: "web", Networks: []string{"frontend"}, Depth: 1},
+			{Container: "db", Via: "api", Networks: []string{"backend"}, Depth: 2},
+			{Container: "backup", Via: "db", Networks: []string{"admin", "storage"}, Depth: 3},
+		},
+	}
+}
+
+func TestBlastRadiusPath(t *testing.T) {
+	b := testBlastRadius()
+
+	if want := []string{"web", "api", "db", "backup"}; !reflect.DeepEqual(b.Path("backup"), want) {
+		t.Errorf("expected path %v, got %v", want, b.Path("backup"))
+	}
+	if want := []string{"web", "cache"}; !reflect.DeepEqual(b.Path("cache"), want) {
+		t.Errorf("expected path %v, got %v", want, b.Path("cache"))
+	}
+	if got := b.Path("unknown"); got != nil {
+		t.Errorf("expected nil path for unreachable container, got %v", got)
+	}
+}
+
+func TestBlastRadiusNetworks(t *testing.T) {
+	want := []string{"admin", "backend", "frontend", "storage"}
+	if got := testBlastRadius().Networks(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected networks %v, got %v", want, got)
+	}
+}
+
+func TestBlastRadiusPivots(t *testing.T) {
+	want := []string{"api", "db"}
+	if got := testBlastRadius().Pivots(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected pivots %v, got %v", want, got)
+	}
+}
+
+func TestBlastRadiusMaxDepth(t *testing.T) {
+	if got := testBlastRadius().MaxDepth(); got != 3 {
+		t.Errorf("expected max depth 3, got %d", got)
+	}
+	if got := (BlastRadius{Container: "web"}).MaxDepth(); got != 0 {
+		t.Errorf("expected max depth 0 without hops, got %d", got)
+	}
+}
@@ -4,9 +4,31 @@
 package models
 
 import (
+	"fmt"
+	"maps"
+	"slices"
 	"sort"
+	"strings"
 )
 
+// ShortIDLength is the length of the short container ID Docker registers as
+// a DNS name and alias for every endpoint.
+const ShortIDLength = 12
+
+// SortedContainers returns the containers of a container map sorted by name.
+func SortedContainers(containers map[string]*ContainerInfo) []*ContainerInfo {
+	sorted := make([]*ContainerInfo, 0, len(containers))
+	for _, name := range slices.Sorted(maps.Keys(containers)) {
+		sorted = append(sorted, containers[name])
+	}
+	return sorted
+}
+
+// IsShortID reports whether name is the short form of the container ID id.
+func IsShortID(id, name string) bool {
+	return len(name) == ShortIDLength && strings.HasPrefix(id, name)
+}
+
 // ContainerInfo represents a Docker container's network-related information.
 // It stores the container's name, network aliases, and the networks it belongs to.
 // This struct is used for building network topology views and determining
@@ -16,6 +38,10 @@ type ContainerInfo struct {
 	// Example: "web_app" not "/web_app"
 	Name string
 
+	// ID is the full container ID. It is used to recognize the names Docker
+	// generates from it, such as the short container ID.
+	ID string
+
 	// Aliases are the network-scoped aliases assigned to this container.
 	// Aliases allow containers to be discovered by alternative names within a network.
 	Aliases []string
@@ -23,15 +49,121 @@ type ContainerInfo struct {
 	// Networks contains the names of all networks this container is connected to.
 	// A container can be connected to multiple networks simultaneously.
 	Networks []string
+
+	// NetworkAliases maps each network name to the aliases the container has
+	// on that network. Aliases holds the union of these across all networks.
+	NetworkAliases map[string][]string
+
+	// NetworkDNSNames maps each network name to the DNS names Docker
+	// registers for the container's endpoint on that network. Besides the
+	// aliases, these include the container name, the short container ID and
+	// the hostname.
+	NetworkDNSNames map[string][]string
+
+	// Service is the Compose service the container belongs to, if any.
+	// Replicas of a service share their aliases intentionally.
+	Service string
+
+	// NetworkMode is the container's network mode, such as "bridge", "host"
+	// or "container:<id>" for containers sharing another container's network
+	// namespace.
+	NetworkMode string
+
+	// Ports are the container's exposed and published ports.
+	Ports []PortInfo
+
+	// Project is the Compose project the container belongs to, if any.
+	Project string
+
+	// State is the container's state, such as "running" or "exited".
+	State string
+
+	// NetworkNamespace is the name of the container whose network namespace,
+	// and so whose endpoints, this container shares through a "container:"
+	// network mode. It is empty for containers with their own namespace.
+	NetworkNamespace string
+
+	// Pod is the name of the pod the container belongs to, if any. Containers
+	// sharing one network namespace, such as the members of a Podman pod,
+	// form a pod together with the namespace owner.
+	Pod string
+
+	// Image is the image the container runs, such as "traefik:v3.0".
+	Image string
+
+	// Labels are the container's labels, such as the routing labels read by
+	// reverse proxies.
+	Labels map[string]string
+
+	// Env holds the container's environment variables. The container list
+	// does not include them, so it is only set when the container has been
+	// inspected.
+	Env map[string]string
+}
+
+// PortInfo represents a container port and its host mapping, if published.
+type PortInfo struct {
+	// IP is the host address the port is published on; empty when not published.
+	IP string
+
+	// PrivatePort is the port inside the container.
+	PrivatePort uint16
+
+	// PublicPort is the port on the host; zero when not published.
+	PublicPort uint16
+
+	// Type is the protocol, "tcp", "udp" or "sctp".
+	Type string
+}
+
+// IsPublished reports whether the port is published on the host.
+func (p PortInfo) IsPublished() bool {
+	return p.PublicPort != 0
+}
+
+// String formats the port like "docker ps": "0.0.0.0:8080->80/tcp" when
+// published and "80/tcp" otherwise.
+func (p PortInfo) String() string {
+	port := fmt.Sprintf("%d/%s", p.PrivatePort, p.Type)
+	if !p.IsPublished() {
+		return port
+	}
+	ip := p.IP
+	if ip == "" {
+		ip = "0.0.0.0"
+	}
+	if strings.Contains(ip, ":") {
+		ip = "[" + ip + "]"
+	}
+	return fmt.Sprintf("%s:%d->%s", ip, p.PublicPort, port)
+}
+
+// IsPublic reports whether the port is published on all host interfaces
+// rather than a specific address such as 127.0.0.1.
+func (p PortInfo) IsPublic() bool {
+	return p.IsPublished() && (p.IP == "" || p.IP == "0.0.0.0" || p.IP == "::")
+}
+
+// IsActive reports whether the container is running, paused or restarting,
+// and so holds endpoints on its networks. Containers with an unknown state
+// are treated as active.
+func (c *ContainerInfo) IsActive() bool {
+	switch c.State {
+	case "created", "exited", "dead":
+		return false
+	default:
+		return true
+	}
 }
 
 // NewContainerInfo creates a new ContainerInfo with the given name.
 // The Aliases and Networks slices are initialized as empty slices.
 func NewContainerInfo(name string) *ContainerInfo {
 	return &ContainerInfo{
-		Name:     name,
-		Aliases:  []string{},
-		Networks: []string{},
+		Name:           name,
+		Aliases:        []string{},
+		Networks:       []string{},
+		NetworkAliases: map[string][]string{},
 	}
 }
 
@@ -47,6 +179,113 @@ func (c *ContainerInfo) AddAlias(alias string) bool {
 	return true
 }
 
+// AddNetworkAlias adds an alias the container has on a specific network.
+// The alias is also added to Aliases. Returns true if the alias was new for
+// the network, false if it already existed there.
+func (c *ContainerInfo) AddNetworkAlias(network, alias string) bool {
+	c.AddAlias(alias)
+
+	if c.NetworkAliases == nil {
+		c.NetworkAliases = map[string][]string{}
+	}
+	for _, existing := range c.NetworkAliases[network] {
+		if existing == alias {
+			return false
+		}
+	}
+	c.NetworkAliases[network] = append(c.NetworkAliases[network], alias)
+	return true
+}
+
+// AliasesOn returns the aliases the container has on the specified network,
+// sorted alphabetically.
+func (c *ContainerInfo) AliasesOn(network string) []string {
+	sorted := make([]string, len(c.NetworkAliases[network]))
+	copy(sorted, c.NetworkAliases[network])
+	sort.Strings(sorted)
+	return sorted
+}
+
+// AddNetworkDNSName adds a DNS name the container's endpoint has on a
+// specific network. Returns true if the name was new for the network.
+func (c *ContainerInfo) AddNetworkDNSName(network, name string) bool {
+	if c.NetworkDNSNames == nil {
+		c.NetworkDNSNames = map[string][]string{}
+	}
+	for _, existing := range c.NetworkDNSNames[network] {
+		if existing == name {
+			return false
+		}
+	}
+	c.NetworkDNSNames[network] = append(c.NetworkDNSNames[network], name)
+	return true
+}
+
+// NamesOn returns the names the container can be reached by on the specified
+// network, other than its own name: its aliases and DNS names there, sorted
+// alphabetically and without duplicates. A container without any per-network
+// aliases or DNS names, such as one built with AddAlias only, has its
+// Aliases on every network.
+func (c *ContainerInfo) NamesOn(network string) []string {
+	lists := [][]string{c.NetworkAliases[network], c.NetworkDNSNames[network]}
+	if len(c.NetworkAliases) == 0 && len(c.NetworkDNSNames) == 0 {
+		lists = [][]string{c.Aliases}
+	}
+
+	seen := map[string]bool{c.Name: true}
+	var names []string
+	for _, list := range lists {
+		for _, name := range list {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsGeneratedName reports whether name is one Docker generates for every
+// container, the full or short container ID, rather than one given by the
+// user.
+func (c *ContainerInfo) IsGeneratedName(name string) bool {
+	if c.ID == "" {
+		return false
+	}
+	return name == c.ID || IsShortID(c.ID, name)
+}
+
+// RemoveGeneratedNames drops the generated names (see IsGeneratedName) from
+// the container's aliases and DNS names. The aliases and DNS names are
+// replaced rather than modified in place, so copies of the ContainerInfo
+// that share them are not affected.
+func (c *ContainerInfo) RemoveGeneratedNames() {
+	keep := func(list []string) []string {
+		kept := []string{}
+		for _, name := range list {
+			if !c.IsGeneratedName(name) {
+				kept = append(kept, name)
+			}
+		}
+		return kept
+	}
+	keepPerNetwork := func(m map[string][]string) map[string][]string {
+		if m == nil {
+			return nil
+		}
+		kept := make(map[string][]string, len(m))
+		for network, list := range m {
+			kept[network] = keep(list)
+		}
+		return kept
+	}
+
+	c.Aliases = keep(c.Aliases)
+	c.NetworkAliases = keepPerNetwork(c.NetworkAliases)
+	c.NetworkDNSNames = keepPerNetwork(c.NetworkDNSNames)
+}
+
 // AddNetwork adds a network name to the container if it doesn't already exist.
 // Returns true if the network was added, false if it already existed.
 func (c *ContainerInfo) AddNetwork(network string) bool {
@@ -59,6 +298,50 @@ func (c *ContainerInfo) AddNetwork(network string) bool {
 	return true
 }
 
+// RemoveNetwork disconnects the container from a network, dropping the
+// aliases it only had on that network. Returns true if the container was
+// connected to the network.
+func (c *ContainerInfo) RemoveNetwork(network string) bool {
+	index := -1
+	for i, existing := range c.Networks {
+		if existing == network {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return false
+	}
+	c.Networks = append(c.Networks[:index:index], c.Networks[index+1:]...)
+
+	dropped := c.NetworkAliases[network]
+	delete(c.NetworkAliases, network)
+	delete(c.NetworkDNSNames, network)
+
+	for _, alias := range dropped {
+		kept := false
+		for _, aliases := range c.NetworkAliases {
+			for _, a := range aliases {
+				if a == alias {
+					kept = true
+				}
+			}
+		}
+		if kept {
+			continue
+		}
+
+		for i, a := range c.Aliases {
+			if a == alias {
+				c.Aliases = append(c.Aliases[:i:i], c.Aliases[i+1:]...)
+				break
+			}
+		}
+	}
+
+	return true
+}
+
 // HasNetwork checks if the container is connected to the specified network.
 func (c *ContainerInfo) HasNetwork(network string) bool {
 	for _, n := range c.Networks {
@@ -117,9 +400,35 @@ func (c *ContainerInfo) Clone() *ContainerInfo {
 	networks := make([]string, len(c.Networks))
 	copy(networks, c.Networks)
 
+	networkAliases := make(map[string][]string, len(c.NetworkAliases))
+	for network, list := range c.NetworkAliases {
+		networkAliases[network] = append([]string(nil), list...)
+	}
+
+	var networkDNSNames map[string][]string
+	if c.NetworkDNSNames != nil {
+		networkDNSNames = make(map[string][]string, len(c.NetworkDNSNames))
+		for network, list := range c.NetworkDNSNames {
+			networkDNSNames[network] = append([]string(nil), list...)
+		}
+	}
+
 	return &ContainerInfo{
-		Name:     c.Name,
-		Aliases:  aliases,
-		Networks: networks,
+		Name:             c.Name,
+		ID:               c.ID,
+		Aliases:          aliases,
+		Networks:         networks,
+		NetworkAliases:   networkAliases,
+		NetworkDNSNames:  networkDNSNames,
+		Service:          c.Service,
+		NetworkMode:      c.NetworkMode,
+		Ports:            append([]PortInfo(nil), c.Ports...),
+		Project:          c.Project,
+		State:            c.State,
+		NetworkNamespace: c.NetworkNamespace,
+		Pod:              c.Pod,
+		Image:            c.Image,
+		Labels:           maps.Clone(c.Labels),
+		Env:              maps.Clone(c.Env),
 	}
 }
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -427,6 +428,53 @@ func TestContainerInfo_Clone(t *testing.T) {
 		}
 	})
 
+	t.Run("copies image, labels and environment", func(t *testing.T) {
+		original := NewContainerInfo("web")
+		original.Image = "nginx:1.27"
+		original.Labels = map[string]string{"traefik.enable": "true"}
+		original.Env = map[string]string{"VIRTUAL_HOST": "shop.example.com"}
+
+		clone := original.Clone()
+		clone.Labels["traefik.enable"] = "false"
+		clone.Env["VIRTUAL_HOST"] = "other.example.com"
+
+		if clone.Image != "nginx:1.27" {
+			t.Errorf("Clone Image = %q, want nginx:1.27", clone.Image)
+		}
+		if original.Labels["traefik.enable"] != "true" || original.Env["VIRTUAL_HOST"] != "shop.example.com" {
+			t.Error("modifying the clone's labels or environment changed the original")
+		}
+	})
+
+	t.Run("copies pod membership", func(t *testing.T) {
+		original := NewContainerInfo("app")
+		original.Pod = "shop"
+		original.NetworkNamespace = "shop-infra"
+
+		clone := original.Clone()
+
+		if clone.Pod != "shop" || clone.NetworkNamespace != "shop-infra" {
+			t.Errorf("Clone Pod/NetworkNamespace = %q/%q, want shop/shop-infra",
+				clone.Pod, clone.NetworkNamespace)
+		}
+	})
+
+	t.Run("copies ID and DNS names", func(t *testing.T) {
+		original := NewContainerInfo("web")
+		original.ID = "0123456789abcdef"
+		original.AddNetworkDNSName("frontend", "www")
+
+		clone := original.Clone()
+		clone.AddNetworkDNSName("frontend", "web.local")
+
+		if clone.ID != original.ID {
+			t.Errorf("Clone ID = %q, want %q", clone.ID, original.ID)
+		}
+		if len(original.NetworkDNSNames["frontend"]) != 1 {
+			t.Error("Original DNS names changed through the clone")
+		}
+	})
+
 	t.Run("clone empty container", func(t *testing.T) {
 		original := NewContainerInfo("empty")
 		clone := original.Clone()
@@ -465,3 +513,233 @@ func TestContainerInfo_DirectFieldAccess(t *testing.T) {
 		t.Errorf("Networks length = %d, want 2", len(c.Networks))
 	}
 }
+
+func TestContainerInfo_AddNetworkAlias(t *testing.T) {
+	c := &ContainerInfo{Name: "api"}
+
+	if !c.AddNetworkAlias("backend", "db-client") {
+		t.Error("AddNetworkAlias should return true for a new alias")
+	}
+	if c.AddNetworkAlias("backend", "db-client") {
+		t.Error("AddNetworkAlias should return false for a duplicate alias on the same network")
+	}
+	if !c.AddNetworkAlias("frontend", "db-client") {
+		t.Error("AddNetworkAlias should return true for the same alias on another network")
+	}
+	c.AddNetworkAlias("backend", "api.local")
+
+	if got := c.AliasesOn("backend"); len(got) != 2 || got[0] != "api.local" || got[1] != "db-client" {
+		t.Errorf("AliasesOn(backend) = %v, want [api.local db-client]", got)
+	}
+	if got := c.AliasesOn("other"); len(got) != 0 {
+		t.Errorf("AliasesOn(other) = %v, want empty", got)
+	}
+	if c.AliasCount() != 2 {
+		t.Errorf("AliasCount = %d, want 2", c.AliasCount())
+	}
+
+	clone := c.Clone()
+	clone.AddNetworkAlias("backend", "extra")
+	if len(c.AliasesOn("backend")) != 2 {
+		t.Error("Clone should deep copy network aliases")
+	}
+}
+
+func TestPortInfo_IsPublic(t *testing.T) {
+	tests := []struct {
+		name      string
+		port      PortInfo
+		published bool
+		public    bool
+	}{
+		{"exposed only", PortInfo{PrivatePort: 80, Type: "tcp"}, false, false},
+		{"all IPv4 interfaces", PortInfo{IP: "0.0.0.0", PrivatePort: 80, PublicPort: 8080}, true, true},
+		{"all IPv6 interfaces", PortInfo{IP: "::", PrivatePort: 80, PublicPort: 8080}, true, true},
+		{"loopback", PortInfo{IP: "127.0.0.1", PrivatePort: 80, PublicPort: 8080}, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.port.IsPublished(); got != tt.published {
+				t.Errorf("IsPublished() = %v, want %v", got, tt.published)
+			}
+			if got := tt.port.IsPublic(); got != tt.public {
+				t.Errorf("IsPublic() = %v, want %v", got, tt.public)
+			}
+		})
+	}
+}
+
+func TestPortInfo_String(t *testing.T) {
+	tests := []struct {
+		port PortInfo
+		want string
+	}{
+		{PortInfo{PrivatePort: 80, Type: "tcp"}, "80/tcp"},
+		{PortInfo{IP: "0.0.0.0", PrivatePort: 80, PublicPort: 8080, Type: "tcp"}, "0.0.0.0:8080->80/tcp"},
+		{PortInfo{PrivatePort: 53, PublicPort: 53, Type: "udp"}, "0.0.0.0:53->53/udp"},
+		{PortInfo{IP: "::", PrivatePort: 443, PublicPort: 443, Type: "tcp"}, "[::]:443->443/tcp"},
+		{PortInfo{IP: "127.0.0.1", PrivatePort: 5432, PublicPort: 5432, Type: "tcp"}, "127.0.0.1:5432->5432/tcp"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.port.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestContainerInfo_IsActive(t *testing.T) {
+	tests := []struct {
+		state string
+		want  bool
+	}{
+		{"running", true},
+		{"paused", true},
+		{"restarting", true},
+		{"", true},
+		{"created", false},
+		{"exited", false},
+		{"dead", false},
+	}
+
+	for _, tt := range tests {
+		c := &ContainerInfo{Name: "web", State: tt.state}
+		if got := c.IsActive(); got != tt.want {
+			t.Errorf("IsActive() with state %q = %v, want %v", tt.state, got, tt.want)
+		}
+	}
+}
+
+func TestContainerInfo_RemoveNetwork(t *testing.T) {
+	c := NewContainerInfo("api")
+	c.AddNetwork("frontend")
+	c.AddNetwork("backend")
+	c.AddNetworkAlias("frontend", "api.local")
+	c.AddNetworkAlias("frontend", "shared")
+	c.AddNetworkAlias("backend", "shared")
+
+	if !c.RemoveNetwork("frontend") {
+		t.Error("RemoveNetwork should return true for a connected network")
+	}
+	if c.RemoveNetwork("frontend") {
+		t.Error("RemoveNetwork should return false for a network that is not connected")
+	}
+
+	if c.HasNetwork("frontend") || !c.HasNetwork("backend") {
+		t.Errorf("Networks = %v, want [backend]", c.Networks)
+	}
+	if c.HasAlias("api.local") {
+		t.Error("alias only on the removed network should be dropped")
+	}
+	if !c.HasAlias("shared") {
+		t.Error("alias also on another network should be kept")
+	}
+	if len(c.AliasesOn("frontend")) != 0 {
+		t.Error("aliases of the removed network should be dropped")
+	}
+}
+
+func TestContainerInfo_NamesOn(t *testing.T) {
+	t.Run("per-network names", func(t *testing.T) {
+		c := NewContainerInfo("api")
+		c.AddNetworkAlias("frontend", "public-api")
+		c.AddNetworkAlias("backend", "internal-api")
+		c.AddNetworkDNSName("backend", "api")
+		c.AddNetworkDNSName("backend", "internal-api")
+		c.AddNetworkDNSName("backend", "0123456789ab")
+
+		if got := c.NamesOn("backend"); !reflect.DeepEqual(got, []string{"0123456789ab", "internal-api"}) {
+			t.Errorf("NamesOn(backend) = %v", got)
+		}
+		if got := c.NamesOn("frontend"); !reflect.DeepEqual(got, []string{"public-api"}) {
+			t.Errorf("NamesOn(frontend) = %v", got)
+		}
+		if got := c.NamesOn("other"); len(got) != 0 {
+			t.Errorf("NamesOn(other) = %v, want none", got)
+		}
+	})
+
+	t.Run("aliases without networks", func(t *testing.T) {
+		c := &ContainerInfo{Name: "web", Aliases: []string{"www", "web"}}
+
+		if got := c.NamesOn("any"); !reflect.DeepEqual(got, []string{"www"}) {
+			t.Errorf("NamesOn(any) = %v, want [www]", got)
+		}
+	})
+}
+
+func TestSortedContainers(t *testing.T) {
+	containers := map[string]*ContainerInfo{
+		"web": NewContainerInfo("web"),
+		"api": NewContainerInfo("api"),
+		"db":  NewContainerInfo("db"),
+	}
+
+	var names []string
+	for _, c := range SortedContainers(containers) {
+		names = append(names, c.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"api", "db", "web"}) {
+		t.Errorf("SortedContainers() = %v, want [api db web]", names)
+	}
+
+	if got := SortedContainers(nil); len(got) != 0 {
+		t.Errorf("expected no containers, got %v", got)
+	}
+}
+
+func TestIsShortID(t *testing.T) {
+	id := "0123456789abcdef0123"
+
+	tests := map[string]bool{
+		"0123456789ab":  true,
+		"0123456789abc": false,
+		"0123456789":    false,
+		"123456789abc":  false,
+	}
+	for name, want := range tests {
+		if got := IsShortID(id, name); got != want {
+			t.Errorf("IsShortID(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestContainerInfo_IsGeneratedName(t *testing.T) {
+	c := &ContainerInfo{Name: "web", ID: "0123456789abcdef0123"}
+
+	tests := map[string]bool{
+		"0123456789ab":         true,
+		"0123456789abcdef0123": true,
+		"0123456789":           false,
+		"web":                  false,
+		"www":                  false,
+	}
+	for name, want := range tests {
+		if got := c.IsGeneratedName(name); got != want {
+			t.Errorf("IsGeneratedName(%q) = %v, want %v", name, got, want)
+		}
+	}
+
+	if (&ContainerInfo{Name: "web"}).IsGeneratedName("0123456789ab") {
+		t.Error("names are not generated when the ID is unknown")
+	}
+}
+
+func TestContainerInfo_RemoveGeneratedNames(t *testing.T) {
+	c := NewContainerInfo("web")
+	c.ID = "0123456789abcdef0123"
+	c.AddNetworkAlias("frontend", "www")
+	c.AddNetworkAlias("frontend", "0123456789ab")
+	c.AddNetworkDNSName("frontend", "0123456789ab")
+
+	shared := *c
+	c.RemoveGeneratedNames()
+
+	if !reflect.DeepEqual(c.Aliases, []string{"www"}) || !reflect.DeepEqual(c.NamesOn("frontend"), []string{"www"}) {
+		t.Errorf("generated names not removed: %v, %v", c.Aliases, c.NamesOn("frontend"))
+	}
+	if len(shared.NetworkAliases["frontend"]) != 2 || len(shared.NetworkDNSNames["frontend"]) != 1 {
+		t.Error("copies sharing the original slices should not be modified")
+	}
+}
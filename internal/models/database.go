@@ -0,0 +1,67 @@
+// Package models provides data structures for docker-network-viz.
+package models
+
+import "strings"
+
+// databasePorts maps well-known database container ports to their product.
+var databasePorts = map[uint16]string{
+	1433:  "SQL Server",
+	1521:  "Oracle",
+	3306:  "MySQL/MariaDB",
+	5432:  "PostgreSQL",
+	5984:  "CouchDB",
+	6379:  "Redis",
+	8086:  "InfluxDB",
+	9042:  "Cassandra",
+	9200:  "Elasticsearch",
+	11211: "Memcached",
+	26257: "CockroachDB",
+	27017: "MongoDB",
+}
+
+// databaseImages maps the repository names of database images, without
+// registry, namespace or tag, to their product.
+var databaseImages = map[string]string{
+	"postgres":      "PostgreSQL",
+	"postgis":       "PostgreSQL",
+	"timescaledb":   "PostgreSQL",
+	"mysql":         "MySQL/MariaDB",
+	"mariadb":       "MySQL/MariaDB",
+	"mongo":         "MongoDB",
+	"redis":         "Redis",
+	"valkey":        "Redis",
+	"memcached":     "Memcached",
+	"elasticsearch": "Elasticsearch",
+	"opensearch":    "Elasticsearch",
+	"cassandra":     "Cassandra",
+	"couchdb":       "CouchDB",
+	"influxdb":      "InfluxDB",
+	"cockroach":     "CockroachDB",
+}
+
+// DatabasePortProduct returns the database product that conventionally
+// listens on a container port, such as "PostgreSQL" for 5432, and whether
+// the port is a well-known database port.
+func DatabasePortProduct(port uint16) (string, bool) {
+	product, ok := databasePorts[port]
+	return product, ok
+}
+
+// DatabaseProduct returns the database product the container runs and
+// whether it runs one, judged by its well-known database ports and then by
+// its image.
+func (c *ContainerInfo) DatabaseProduct() (string, bool) {
+	for _, p := range c.Ports {
+		if product, ok := DatabasePortProduct(p.PrivatePort); ok {
+			return product, true
+		}
+	}
+
+	image, _, _ := strings.Cut(c.Image, "@")
+	if i := strings.LastIndex(image, "/"); i >= 0 {
+		image = image[i+1:]
+	}
+	image, _, _ = strings.Cut(image, ":")
+	product, ok := databaseImages[strings.ToLower(image)]
+	return product, ok
+}
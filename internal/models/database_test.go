@@ -0,0 +1,36 @@
+package models
+
+import "testing"
+
+func TestDatabasePortProduct(t *testing.T) {
+	if product, ok := DatabasePortProduct(5432); !ok || product != "PostgreSQL" {
+		t.Errorf("expected PostgreSQL for 5432, got %q (%v)", product, ok)
+	}
+	if _, ok := DatabasePortProduct(8080); ok {
+		t.Error("expected 8080 not to be a database port")
+	}
+}
+
+func TestContainerInfoDatabaseProduct(t *testing.T) {
+	tests := []struct {
+		name      string
+		container ContainerInfo
+		want      string
+		wantOK    bool
+	}{
+		{"by port", ContainerInfo{Image: "custom/store", Ports: []PortInfo{{PrivatePort: 27017, Type: "tcp"}}}, "MongoDB", true},
+		{"by image", ContainerInfo{Image: "docker.io/library/postgres:16-alpine"}, "PostgreSQL", true},
+		{"by image with digest", ContainerInfo{Image: "bitnami/mariadb@sha256:abc"}, "MySQL/MariaDB", true},
+		{"web server", ContainerInfo{Image: "nginx", Ports: []PortInfo{{PrivatePort: 80, Type: "tcp"}}}, "", false},
+		{"no image", ContainerInfo{}, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			product, ok := tt.container.DatabaseProduct()
+			if product != tt.want || ok != tt.wantOK {
+				t.Errorf("DatabaseProduct() = %q, %v; want %q, %v", product, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
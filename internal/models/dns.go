@@ -0,0 +1,83 @@
+// Package models provides data structures for docker-network-viz.
+package models
+
+// DNS name sources describe why a name resolves to a container.
+const (
+	// DNSSourceName is a container name.
+	DNSSourceName = "name"
+
+	// DNSSourceAlias is a network-scoped alias.
+	DNSSourceAlias = "alias"
+
+	// DNSSourceService is a Compose service name.
+	DNSSourceService = "service"
+
+	// DNSSourceLink is a legacy --link name, visible only to the linking container.
+	DNSSourceLink = "link"
+)
+
+// ContainerDNS describes the names a container can resolve through Docker's
+// embedded DNS server (and, on the default bridge network, through legacy
+// links in /etc/hosts) on each of its networks.
+type ContainerDNS struct {
+	// Container is the name of the container doing the lookups.
+	Container string
+
+	// Networks holds the resolvable names per network, sorted by network name.
+	Networks []NetworkDNS
+
+	// Conflicts lists names that resolve to different containers on
+	// different networks of the container.
+	Conflicts []DNSConflict
+}
+
+// NetworkDNS holds the names resolvable on a single network.
+type NetworkDNS struct {
+	// Network is the network name.
+	Network string
+
+	// EmbeddedDNS reports whether Docker's embedded DNS server answers on the
+	// network. It is false for the default bridge network, where only legacy
+	// links resolve, and for the host and none networks.
+	EmbeddedDNS bool
+
+	// Records holds the resolvable names sorted by name.
+	Records []DNSRecord
+}
+
+// DNSRecord is a name and the containers it resolves to.
+type DNSRecord struct {
+	// Name is the DNS name.
+	Name string
+
+	// Sources lists why the name resolves, such as "name" or "alias".
+	Sources []string
+
+	// Targets are the containers the name resolves to, sorted by container name.
+	Targets []DNSTarget
+}
+
+// IsAmbiguous reports whether the name resolves to more than one container,
+// in which case Docker answers with all of them in round-robin order.
+func (r DNSRecord) IsAmbiguous() bool {
+	return len(r.Targets) > 1
+}
+
+// DNSTarget is a container a name resolves to.
+type DNSTarget struct {
+	// Container is the target container name.
+	Container string
+
+	// IPs are the target's addresses on the network; empty when unknown.
+	IPs []string
+}
+
+// DNSConflict is a name that resolves to different containers depending on
+// which network answers the query.
+type DNSConflict struct {
+	// Name is the conflicting DNS name.
+	Name string
+
+	// Networks maps each network name to the containers the name resolves to there.
+	Networks map[string][]string
+}
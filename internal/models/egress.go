@@ -0,0 +1,110 @@
+// Package models provides data structures for docker-network-viz.
+package models
+
+// BridgeMasqueradeOption is the bridge driver option that enables or
+// disables IP masquerading (source NAT) for traffic leaving the network.
+const BridgeMasqueradeOption = "com.docker.network.bridge.enable_ip_masquerade"
+
+// Egress kinds describe how a network connects its containers to the
+// outside world.
+const (
+	// EgressMasqueraded is a gateway with masquerading, so traffic reaches
+	// external hosts with the host's address.
+	EgressMasqueraded = "masqueraded"
+
+	// EgressRouted is a gateway without masquerading. Traffic leaves with
+	// the container's address and only gets replies if the upstream network
+	// routes the subnet back to the host.
+	EgressRouted = "routed"
+
+	// EgressDirect is a macvlan or ipvlan network, whose containers sit
+	// directly on the parent interface's network.
+	EgressDirect = "direct"
+
+	// EgressHost is the host network stack.
+	EgressHost = "host"
+
+	// EgressNone is a network without a route to the outside world.
+	EgressNone = "none"
+
+	// EgressUnknown is a network whose settings are not known, such as an
+	// external network of a Compose project.
+	EgressUnknown = "unknown"
+)
+
+// ContainerEgress describes whether a container has a route to the outside
+// world, and through which of its networks.
+type ContainerEgress struct {
+	// Container is the container name.
+	Container string
+
+	// Networks holds the egress of each of the container's networks, sorted
+	// by network name.
+	Networks []NetworkEgress
+
+	// DefaultRoute is the network that provides the container's default
+	// route, or an empty string if none does.
+	DefaultRoute string
+}
+
+// NetworkEgress describes the egress a single network provides.
+type NetworkEgress struct {
+	// Network is the network name.
+	Network string
+
+	// Kind is one of the Egress* constants.
+	Kind string
+
+	// Gateway is the network's IPv4 gateway, if known.
+	Gateway string
+
+	// Reason explains the kind, for example "internal network".
+	Reason string
+}
+
+// HasRoute reports whether the network gives its containers a route to the
+// outside world.
+func (e NetworkEgress) HasRoute() bool {
+	switch e.Kind {
+	case EgressMasqueraded, EgressRouted, EgressDirect, EgressHost:
+		return true
+	}
+	return false
+}
+
+// Internet returns the egress kind of the network providing the default
+// route: EgressNone when no network provides one, or EgressUnknown when
+// none does but some networks could not be assessed.
+func (e ContainerEgress) Internet() string {
+	unknown := false
+	for _, n := range e.Networks {
+		if n.Network == e.DefaultRoute {
+			return n.Kind
+		}
+		if n.Kind == EgressUnknown {
+			unknown = true
+		}
+	}
+	if unknown {
+		return EgressUnknown
+	}
+	return EgressNone
+}
+
+// MasqueradeEnabled reports whether the network masquerades outgoing
+// traffic. A missing or unparsable option leaves it enabled, as the Docker
+// daemon does.
+func (n *NetworkInfo) MasqueradeEnabled() bool {
+	return n.boolOption(BridgeMasqueradeOption, true)
+}
+
+// Gateway returns the first IPv4 gateway of the network's address pools, or
+// an empty string if none is configured.
+func (n *NetworkInfo) Gateway() string {
+	for _, s := range n.Subnets {
+		if s.Gateway != "" && !isIPv6(s.Gateway) {
+			return s.Gateway
+		}
+	}
+	return ""
+}
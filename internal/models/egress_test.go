@@ -0,0 +1,101 @@
+package models
+
+import "testing"
+
+func TestNetworkInfo_MasqueradeEnabled(t *testing.T) {
+	tests := []struct {
+		name    string
+		options map[string]string
+		want    bool
+	}{
+		{name: "no options", options: nil, want: true},
+		{name: "enabled", options: map[string]string{BridgeMasqueradeOption: "true"}, want: true},
+		{name: "disabled", options: map[string]string{BridgeMasqueradeOption: "false"}, want: false},
+		{name: "unparsable", options: map[string]string{BridgeMasqueradeOption: "nope"}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := &NetworkInfo{Name: "net", Driver: "bridge", Options: tt.options}
+			if got := n.MasqueradeEnabled(); got != tt.want {
+				t.Errorf("MasqueradeEnabled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNetworkInfo_Gateway(t *testing.T) {
+	n := &NetworkInfo{Subnets: []SubnetInfo{
+		{Subnet: "fd00::/64", Gateway: "fd00::1"},
+		{Subnet: "172.18.0.0/16"},
+		{Subnet: "172.19.0.0/16", Gateway: "172.19.0.1"},
+	}}
+	if got := n.Gateway(); got != "172.19.0.1" {
+		t.Errorf("Gateway() = %q, want %q", got, "172.19.0.1")
+	}
+
+	if got := (&NetworkInfo{}).Gateway(); got != "" {
+		t.Errorf("Gateway() = %q, want empty", got)
+	}
+}
+
+func TestNetworkEgress_HasRoute(t *testing.T) {
+	for kind, want := range map[string]bool{
+		EgressMasqueraded: true,
+		EgressRouted:      true,
+		EgressDirect:      true,
+		EgressHost:        true,
+		EgressNone:        false,
+		EgressUnknown:     false,
+	} {
+		if got := (NetworkEgress{Kind: kind}).HasRoute(); got != want {
+			t.Errorf("HasRoute() for %s = %v, want %v", kind, got, want)
+		}
+	}
+}
+
+func TestContainerEgress_Internet(t *testing.T) {
+	tests := []struct {
+		name   string
+		egress ContainerEgress
+		want   string
+	}{
+		{
+			name: "default route",
+			egress: ContainerEgress{
+				Networks: []NetworkEgress{
+					{Network: "backend", Kind: EgressNone},
+					{Network: "frontend", Kind: EgressRouted},
+				},
+				DefaultRoute: "frontend",
+			},
+			want: EgressRouted,
+		},
+		{
+			name:   "no route",
+			egress: ContainerEgress{Networks: []NetworkEgress{{Network: "backend", Kind: EgressNone}}},
+			want:   EgressNone,
+		},
+		{
+			name: "unknown network",
+			egress: ContainerEgress{Networks: []NetworkEgress{
+				{Network: "backend", Kind: EgressNone},
+				{Network: "shared", Kind: EgressUnknown},
+			}},
+			want: EgressUnknown,
+		},
+		{
+			name:   "no networks",
+			egress: ContainerEgress{},
+			want:   EgressNone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.egress.Internet(); got != tt.want {
+				t.Errorf("Internet() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
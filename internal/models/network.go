@@ -1,10 +1,59 @@
 // Package models provides data structures for docker-network-viz.
 package models
 
+import (
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/network"
+)
+
+// BridgeICCOption is the bridge driver option that enables or disables
+// inter-container communication on a network.
+const BridgeICCOption = "com.docker.network.bridge.enable_icc"
+
+// Predefined networks, created by Docker or Podman themselves.
+const (
+	// BridgeNetwork is Docker's default bridge network.
+	BridgeNetwork = network.NetworkBridge
+
+	// HostNetwork gives containers the host's network stack.
+	HostNetwork = network.NetworkHost
+
+	// NoneNetwork gives containers only a loopback interface; it connects
+	// nothing.
+	NoneNetwork = network.NetworkNone
+
+	// PodmanDefaultNetwork is Podman's default network, the counterpart of
+	// Docker's default bridge network.
+	PodmanDefaultNetwork = "podman"
+)
+
+// IsPredefinedNetwork reports whether a network is one of the predefined
+// networks of Docker or Podman: bridge, host, none or podman.
+func IsPredefinedNetwork(name string) bool {
+	switch name {
+	case BridgeNetwork, HostNetwork, NoneNetwork, PodmanDefaultNetwork:
+		return true
+	default:
+		return false
+	}
+}
+
+// HasEmbeddedDNS reports whether the embedded DNS server resolves container
+// names and aliases on a network. Only user-defined networks have it; the
+// default bridge networks of Docker and Podman do not.
+func HasEmbeddedDNS(name string) bool {
+	return !IsPredefinedNetwork(name)
+}
+
 // NetworkInfo represents a Docker network's basic information.
 // It stores the network's name and driver type for visualization purposes.
 // This struct is used to decouple the output package from Docker API types.
 type NetworkInfo struct {
+	// ID is the network's ID.
+	ID string
+
 	// Name is the network's name.
 	// Example: "bridge", "frontend_net", "backend_net"
 	Name string
@@ -12,6 +61,41 @@ type NetworkInfo struct {
 	// Driver is the network driver type.
 	// Common values: "bridge", "host", "overlay", "macvlan", "none"
 	Driver string
+
+	// Subnets holds the IPAM pools configured for the network.
+	// It is empty for networks without IP address management, such as "host" and "none".
+	Subnets []SubnetInfo
+
+	// Addresses holds the IP addresses allocated to container endpoints on the network.
+	// It is used together with Subnets to report address pool utilization.
+	Addresses []string
+
+	// Internal reports whether the network is internal, i.e. has no external connectivity.
+	// Containers on an internal network can still reach each other.
+	Internal bool
+
+	// Options holds the driver options the network was created with,
+	// for example "com.docker.network.bridge.enable_icc".
+	Options map[string]string
+
+	// Project is the Compose project that created the network, if any.
+	Project string
+}
+
+// SubnetInfo represents a single IPAM pool configured on a Docker network.
+type SubnetInfo struct {
+	// Subnet is the pool's CIDR, for example "172.18.0.0/16".
+	Subnet string
+
+	// IPRange optionally restricts dynamic allocation to a sub-range of Subnet.
+	IPRange string
+
+	// Gateway is the address reserved for the network gateway.
+	Gateway string
+
+	// AuxAddresses are additional addresses reserved by the user and
+	// therefore unavailable for container endpoints.
+	AuxAddresses []string
 }
 
 // NewNetworkInfo creates a new NetworkInfo with the given name and driver.
@@ -21,3 +105,29 @@ func NewNetworkInfo(name, driver string) *NetworkInfo {
 		Driver: driver,
 	}
 }
+
+// ICCEnabled reports whether containers on the network can reach each other.
+// Only the bridge driver's enable_icc option disables this; a missing or
+// unparsable value leaves it enabled, as the Docker daemon does.
+func (n *NetworkInfo) ICCEnabled() bool {
+	return n.boolOption(BridgeICCOption, true)
+}
+
+// boolOption returns the boolean value of a driver option, or def if the
+// option is missing or unparsable.
+func (n *NetworkInfo) boolOption(name string, def bool) bool {
+	value, ok := n.Options[name]
+	if !ok {
+		return def
+	}
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return def
+	}
+	return enabled
+}
+
+// isIPv6 reports whether an address is an IPv6 address.
+func isIPv6(addr string) bool {
+	return strings.Contains(addr, ":")
+}
@@ -180,3 +180,46 @@ func TestNetworkInfo_PointerVsValue(t *testing.T) {
 		}
 	})
 }
+
+func TestNetworkInfo_ICCEnabled(t *testing.T) {
+	tests := []struct {
+		name    string
+		options map[string]string
+		want    bool
+	}{
+		{name: "no options", options: nil, want: true},
+		{name: "option not set", options: map[string]string{"com.docker.network.bridge.name": "br0"}, want: true},
+		{name: "enabled", options: map[string]string{BridgeICCOption: "true"}, want: true},
+		{name: "disabled", options: map[string]string{BridgeICCOption: "false"}, want: false},
+		{name: "disabled numeric", options: map[string]string{BridgeICCOption: "0"}, want: false},
+		{name: "unparsable", options: map[string]string{BridgeICCOption: "maybe"}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := &NetworkInfo{Name: "net", Driver: "bridge", Options: tt.options}
+			if got := n.ICCEnabled(); got != tt.want {
+				t.Errorf("ICCEnabled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsPredefinedNetwork(t *testing.T) {
+	tests := map[string]bool{
+		"bridge":  true,
+		"host":    true,
+		"none":    true,
+		"podman":  true,
+		"backend": false,
+	}
+
+	for name, want := range tests {
+		if got := IsPredefinedNetwork(name); got != want {
+			t.Errorf("IsPredefinedNetwork(%q) = %v, want %v", name, got, want)
+		}
+		if got := HasEmbeddedDNS(name); got == want {
+			t.Errorf("HasEmbeddedDNS(%q) = %v, want %v", name, got, !want)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+// Package models defines the data structures used throughout the application.
+// This file contains host port conflict detection.
+package models
+
+import (
+	"net"
+	"slices"
+	"sort"
+	"strconv"
+)
+
+// Wildcard host addresses a port can be published on.
+const (
+	// ipv4Any is the address of all IPv4 host interfaces.
+	ipv4Any = "0.0.0.0"
+
+	// ipv6Any is the address of all IPv6 host interfaces.
+	ipv6Any = "::"
+)
+
+// HostBinding is a host port a container publishes.
+type HostBinding struct {
+	// Container is the name of the container.
+	Container string
+
+	// State is the container's state, such as "running" or "exited".
+	State string
+
+	// IP is the host address the port is published on; empty means all
+	// host interfaces.
+	IP string
+
+	// Port is the host port.
+	Port uint16
+
+	// Type is the protocol, "tcp", "udp" or "sctp".
+	Type string
+}
+
+// Active reports whether the binding's container is running and so holds
+// the port, using the same states as ContainerInfo.IsActive.
+func (b HostBinding) Active() bool {
+	return (&ContainerInfo{State: b.State}).IsActive()
+}
+
+// Address formats the binding's host address and port, such as
+// "0.0.0.0:80" or "[::1]:80".
+func (b HostBinding) Address() string {
+	ip := b.IP
+	if ip == "" {
+		ip = ipv4Any
+	}
+	return net.JoinHostPort(ip, strconv.Itoa(int(b.Port)))
+}
+
+// PortConflict is a host port and protocol that several containers publish
+// on overlapping host addresses. Only one of them can hold it at a time.
+type PortConflict struct {
+	// Port is the host port.
+	Port uint16
+
+	// Type is the protocol.
+	Type string
+
+	// Bindings are the overlapping bindings, sorted by container and address.
+	Bindings []HostBinding
+}
+
+// Containers returns the names of the conflicting containers, sorted.
+func (p PortConflict) Containers() []string {
+	var names []string
+	for _, b := range p.Bindings {
+		if len(names) == 0 || names[len(names)-1] != b.Container {
+			names = append(names, b.Container)
+		}
+	}
+	return names
+}
+
+// Blocked returns the stopped containers that will fail to start because a
+// running container holds an overlapping address, sorted by name.
+func (p PortConflict) Blocked() []string {
+	var blocked []string
+	for _, b := range p.Bindings {
+		if b.Active() || slices.Contains(blocked, b.Container) {
+			continue
+		}
+		for _, other := range p.Bindings {
+			if other.Container != b.Container && other.Active() && AddressesOverlap(b.IP, other.IP) {
+				blocked = append(blocked, b.Container)
+				break
+			}
+		}
+	}
+	return blocked
+}
+
+// AddressesOverlap reports whether two host addresses a port is published on
+// overlap, so that the same port cannot be bound on both: the addresses are
+// equal, or one is the wildcard address of the other's family. An empty
+// address stands for all interfaces of both families.
+func AddressesOverlap(a, b string) bool {
+	if a == b || a == "" || b == "" {
+		return true
+	}
+	switch {
+	case a == ipv4Any || a == ipv6Any:
+		return isIPv4(a) == isIPv4(b)
+	case b == ipv4Any || b == ipv6Any:
+		return isIPv4(a) == isIPv4(b)
+	default:
+		ipA, ipB := net.ParseIP(a), net.ParseIP(b)
+		return ipA != nil && ipA.Equal(ipB)
+	}
+}
+
+// FindPortConflicts finds the host ports that several containers publish on
+// overlapping addresses. Each container's published ports are read from
+// Ports, which holds the port bindings of stopped containers when they have
+// been inspected. Containers sharing another container's network namespace
+// are skipped, as the owner publishes the ports. Conflicts are sorted by
+// port and protocol.
+func FindPortConflicts(containers map[string]*ContainerInfo) []PortConflict {
+	type portKey struct {
+		port  uint16
+		proto string
+	}
+
+	byPort := make(map[portKey][]HostBinding)
+	for _, c := range containers {
+		if c.NetworkNamespace != "" {
+			continue
+		}
+		for _, p := range c.Ports {
+			if !p.IsPublished() {
+				continue
+			}
+			key := portKey{p.PublicPort, p.Type}
+			binding := HostBinding{Container: c.Name, State: c.State, IP: p.IP, Port: p.PublicPort, Type: p.Type}
+			if !slices.Contains(byPort[key], binding) {
+				byPort[key] = append(byPort[key], binding)
+			}
+		}
+	}
+
+	var conflicts []PortConflict
+	for key, bindings := range byPort {
+		for _, group := range overlappingGroups(bindings) {
+			conflicts = append(conflicts, PortConflict{Port: key.port, Type: key.proto, Bindings: group})
+		}
+	}
+
+	sort.Slice(conflicts, func(i, j int) bool {
+		if conflicts[i].Port != conflicts[j].Port {
+			return conflicts[i].Port < conflicts[j].Port
+		}
+		if conflicts[i].Type != conflicts[j].Type {
+			return conflicts[i].Type < conflicts[j].Type
+		}
+		return conflicts[i].Bindings[0].Container < conflicts[j].Bindings[0].Container
+	})
+	return conflicts
+}
+
+// overlappingGroups splits the bindings of one port into groups linked by
+// overlapping addresses of different containers, and returns the groups of
+// more than one container with their bindings sorted.
+func overlappingGroups(bindings []HostBinding) [][]HostBinding {
+	group := make([]int, len(bindings))
+	for i := range group {
+		group[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if group[i] != i {
+			group[i] = find(group[i])
+		}
+		return group[i]
+	}
+
+	linked := make([]bool, len(bindings))
+	for i, a := range bindings {
+		for j := i + 1; j < len(bindings); j++ {
+			b := bindings[j]
+			if a.Container != b.Container && AddressesOverlap(a.IP, b.IP) {
+				group[find(i)] = find(j)
+				linked[i], linked[j] = true, true
+			}
+		}
+	}
+
+	members := make(map[int][]HostBinding)
+	for i, b := range bindings {
+		if linked[i] {
+			members[find(i)] = append(members[find(i)], b)
+		}
+	}
+
+	var groups [][]HostBinding
+	for _, g := range members {
+		sort.Slice(g, func(i, j int) bool {
+			if g[i].Container != g[j].Container {
+				return g[i].Container < g[j].Container
+			}
+			return g[i].IP < g[j].IP
+		})
+		groups = append(groups, g)
+	}
+	return groups
+}
+
+// isIPv4 reports whether an address is an IPv4 address.
+func isIPv4(addr string) bool {
+	ip := net.ParseIP(addr)
+	return ip != nil && ip.To4() != nil
+}
@@ -0,0 +1,105 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAddressesOverlap(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"0.0.0.0", "0.0.0.0", true},
+		{"0.0.0.0", "127.0.0.1", true},
+		{"192.168.1.10", "0.0.0.0", true},
+		{"127.0.0.1", "192.168.1.10", false},
+		{"0.0.0.0", "::", false},
+		{"0.0.0.0", "::1", false},
+		{"::", "::1", true},
+		{"::1", "0:0:0:0:0:0:0:1", true},
+		{"", "127.0.0.1", true},
+		{"", "::1", true},
+	}
+
+	for _, tt := range tests {
+		if got := AddressesOverlap(tt.a, tt.b); got != tt.want {
+			t.Errorf("AddressesOverlap(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+// published returns a port published on the given host address and port.
+func published(ip string, port uint16) PortInfo {
+	return PortInfo{IP: ip, PrivatePort: port, PublicPort: port, Type: "tcp"}
+}
+
+func TestFindPortConflicts(t *testing.T) {
+	containers := map[string]*ContainerInfo{
+		"proxy": {Name: "proxy", State: "running", Ports: []PortInfo{
+			published("0.0.0.0", 80), published("::", 80), published("0.0.0.0", 443),
+		}},
+		"legacy-web": {Name: "legacy-web", State: "exited", Ports: []PortInfo{published("127.0.0.1", 80)}},
+		"admin":      {Name: "admin", State: "exited", Ports: []PortInfo{published("::1", 443)}},
+		"db":         {Name: "db", State: "exited", Ports: []PortInfo{published("0.0.0.0", 5432)}},
+		"db-old":     {Name: "db-old", State: "created", Ports: []PortInfo{published("", 5432)}},
+		"dns":        {Name: "dns", State: "running", Ports: []PortInfo{{IP: "0.0.0.0", PrivatePort: 53, PublicPort: 53, Type: "udp"}}},
+		"dns-tcp":    {Name: "dns-tcp", State: "running", Ports: []PortInfo{published("0.0.0.0", 53)}},
+		"pod-member": {Name: "pod-member", NetworkNamespace: "proxy", Ports: []PortInfo{published("0.0.0.0", 80)}},
+		"internal":   {Name: "internal", Ports: []PortInfo{{PrivatePort: 80, Type: "tcp"}}},
+	}
+
+	conflicts := FindPortConflicts(containers)
+
+	want := []PortConflict{
+		{Port: 80, Type: "tcp", Bindings: []HostBinding{
+			{Container: "legacy-web", State: "exited", IP: "127.0.0.1", Port: 80, Type: "tcp"},
+			{Container: "proxy", State: "running", IP: "0.0.0.0", Port: 80, Type: "tcp"},
+		}},
+		{Port: 5432, Type: "tcp", Bindings: []HostBinding{
+			{Container: "db", State: "exited", IP: "0.0.0.0", Port: 5432, Type: "tcp"},
+			{Container: "db-old", State: "created", IP: "", Port: 5432, Type: "tcp"},
+		}},
+	}
+	if !reflect.DeepEqual(conflicts, want) {
+		t.Errorf("FindPortConflicts =\n%+v\nwant\n%+v", conflicts, want)
+	}
+}
+
+func TestPortConflictBlocked(t *testing.T) {
+	conflict := PortConflict{Port: 80, Type: "tcp", Bindings: []HostBinding{
+		{Container: "a", State: "exited", IP: "127.0.0.1", Port: 80, Type: "tcp"},
+		{Container: "b", State: "exited", IP: "0.0.0.0", Port: 80, Type: "tcp"},
+		{Container: "c", State: "running", IP: "127.0.0.1", Port: 80, Type: "tcp"},
+	}}
+
+	if got := conflict.Blocked(); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("Blocked = %v, want [a b]", got)
+	}
+	if got := conflict.Containers(); !reflect.DeepEqual(got, []string{"a", "b", "c"}) {
+		t.Errorf("Containers = %v, want [a b c]", got)
+	}
+
+	conflict.Bindings[2].State = "exited"
+	if got := conflict.Blocked(); len(got) != 0 {
+		t.Errorf("Blocked = %v, want none when no container holds the port", got)
+	}
+}
+
+func TestHostBindingAddress(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want string
+	}{
+		{"", "0.0.0.0:80"},
+		{"127.0.0.1", "127.0.0.1:80"},
+		{"::", "[::]:80"},
+	}
+
+	for _, tt := range tests {
+		b := HostBinding{IP: tt.ip, Port: 80, Type: "tcp"}
+		if got := b.Address(); got != tt.want {
+			t.Errorf("Address() with IP %q = %q, want %q", tt.ip, got, tt.want)
+		}
+	}
+}
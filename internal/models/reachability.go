@@ -0,0 +1,30 @@
+// Package models provides data structures for docker-network-viz.
+package models
+
+// PeerChange is a container whose reachability from another container changed.
+type PeerChange struct {
+	// Peer is the name of the other container.
+	Peer string
+
+	// Networks are the networks shared with the peer: after the change for a
+	// gained peer, before the change for a lost peer. Sorted by name.
+	Networks []string
+}
+
+// ReachabilityChange lists the peers a container gains and loses when the
+// topology changes.
+type ReachabilityChange struct {
+	// Container is the container whose peers changed.
+	Container string
+
+	// Gained are the peers that become reachable, sorted by name.
+	Gained []PeerChange
+
+	// Lost are the peers that are no longer reachable, sorted by name.
+	Lost []PeerChange
+}
+
+// IsEmpty reports whether the container neither gains nor loses peers.
+func (c ReachabilityChange) IsEmpty() bool {
+	return len(c.Gained) == 0 && len(c.Lost) == 0
+}
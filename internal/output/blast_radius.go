@@ -0,0 +1,132 @@
+// Package output provides tree-style formatters for Docker network topology visualization.
+package output
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// BlastRadiusOf returns every container transitively reachable from a
+// compromised container. Starting from its direct peers (see
+// ReachablePeers), each reached container is in turn assumed compromised,
+// so multi-homed containers carry the attack into their other networks.
+// Each container is listed once, with the shortest hop chain to it.
+//
+// Containers that are not running cannot be attacked or used as pivots and
+// are skipped. Networks that disable inter-container communication carry no
+// hops, nor does the "none" network, which gives each container only a
+// loopback interface; networks may be nil to treat every other network as
+// open.
+func BlastRadiusOf(self string, netMap map[string][]models.ContainerInfo, networks map[string]*models.NetworkInfo) models.BlastRadius {
+	attached := make(map[string][]models.ContainerInfo, len(netMap))
+	inactive := make(map[string]bool)
+	for network, containers := range netMap {
+		if network == driverNone {
+			continue
+		}
+		attached[network] = containers
+		for _, c := range containers {
+			if !c.IsActive() {
+				inactive[c.Name] = true
+			}
+		}
+	}
+
+	result := models.BlastRadius{Container: self}
+	visited := map[string]bool{self: true}
+	frontier := []string{self}
+
+	for depth := 1; len(frontier) > 0; depth++ {
+		var next []string
+		for _, from := range frontier {
+			peers := ReachablePeers(from, attached, networks)
+
+			names := make([]string, 0, len(peers))
+			for name := range peers {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			for _, name := range names {
+				if visited[name] || inactive[name] {
+					continue
+				}
+				visited[name] = true
+				result.Hops = append(result.Hops, models.BlastHop{
+					Container: name,
+					Via:       from,
+					Networks:  peers[name],
+					Depth:     depth,
+				})
+				next = append(next, name)
+			}
+		}
+		frontier = next
+	}
+
+	return result
+}
+
+// PrintBlastRadius prints the containers reachable from a compromised
+// container as a tree of hops: each container is listed under the container
+// it is reached from, with the networks carrying the hop. Containers through
+// which further containers are reached are marked as pivots.
+//
+// Example output:
+//
+//	Compromised: api
+//	├── db via backend
+//	└── proxy via frontend [pivot]
+//	    └── admin via admin_net
+//
+//	Reachable: 3 container(s) on 3 network(s), up to 2 hop(s)
+//	Pivots: proxy
+func PrintBlastRadius(w io.Writer, radius models.BlastRadius) {
+	cw := NewColorWriter(w)
+
+	fmt.Fprintf(w, "%s %s\n", cw.Label("Compromised:"), cw.Container(radius.Container))
+
+	if len(radius.Hops) == 0 {
+		fmt.Fprintf(w, "%s (no reachable containers)\n", cw.Tree(TreeEnd))
+		return
+	}
+
+	children := make(map[string][]models.BlastHop)
+	for _, hop := range radius.Hops {
+		children[hop.Via] = append(children[hop.Via], hop)
+	}
+	printBlastHops(w, cw, "", radius.Container, children)
+
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "%s %d container(s) on %d network(s), up to %d hop(s)\n",
+		cw.Label("Reachable:"), len(radius.Hops), len(radius.Networks()), radius.MaxDepth())
+	if pivots := radius.Pivots(); len(pivots) > 0 {
+		fmt.Fprintf(w, "%s %s\n", cw.Label("Pivots:"), cw.Warning(strings.Join(pivots, ", ")))
+	}
+}
+
+// printBlastHops prints the hops reached from a container and, beneath each,
+// the hops reached from it.
+func printBlastHops(w io.Writer, cw *ColorWriter, indent, from string, children map[string][]models.BlastHop) {
+	hops := children[from]
+	for i, hop := range hops {
+		prefix := TreeBranch
+		childIndent := indent + TreeVertical
+		if i == len(hops)-1 {
+			prefix = TreeEnd
+			childIndent = indent + TreeSpace
+		}
+
+		line := fmt.Sprintf("%s via %s", cw.Container(hop.Container), cw.Network(strings.Join(hop.Networks, ", ")))
+		if len(children[hop.Container]) > 0 {
+			line += " " + cw.Warning("[pivot]")
+		}
+		fmt.Fprintf(w, "%s%s %s\n", cw.Tree(indent), cw.Tree(prefix), line)
+
+		printBlastHops(w, cw, childIndent, hop.Container, children)
+	}
+}
@@ -0,0 +1,84 @@
+package output
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// testBlastNetMap returns networks where proxy pivots from frontend into
+// admin, and db pivots from backend into storage.
+func testBlastNetMap() map[string][]models.ContainerInfo {
+	return map[string][]models.ContainerInfo{
+		"frontend": {{Name: "web"}, {Name: "proxy"}, {Name: "api"}},
+		"backend":  {{Name: "api"}, {Name: "db"}, {Name: "old", State: "exited"}},
+		"admin":    {{Name: "proxy"}, {Name: "dashboard"}},
+		"storage":  {{Name: "db"}, {Name: "backup"}},
+		"isolated": {{Name: "backup"}, {Name: "vault"}},
+		"none":     {{Name: "web"}, {Name: "offline"}},
+	}
+}
+
+func TestBlastRadiusOf(t *testing.T) {
+	networks := map[string]*models.NetworkInfo{
+		"isolated": {Name: "isolated", Driver: "bridge", Options: map[string]string{models.BridgeICCOption: "false"}},
+	}
+
+	got := BlastRadiusOf("web", testBlastNetMap(), networks)
+
+	want := models.BlastRadius{
+		Container: "web",
+		Hops: []models.BlastHop{
+			{Container: "api", Via: "web", Networks: []string{"frontend"}, Depth: 1},
+			{Container: "proxy", Via: "web", Networks: []string{"frontend"}, Depth: 1},
+			{Container: "db", Via: "api", Networks: []string{"backend"}, Depth: 2},
+			{Container: "dashboard", Via: "proxy", Networks: []string{"admin"}, Depth: 2},
+			{Container: "backup", Via: "db", Networks: []string{"storage"}, Depth: 3},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("BlastRadiusOf() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestBlastRadiusOf_Isolated(t *testing.T) {
+	got := BlastRadiusOf("offline", testBlastNetMap(), nil)
+
+	if len(got.Hops) != 0 {
+		t.Errorf("expected no hops over the none network, got %+v", got.Hops)
+	}
+}
+
+func TestPrintBlastRadius(t *testing.T) {
+	radius := BlastRadiusOf("web", testBlastNetMap(), nil)
+
+	var buf bytes.Buffer
+	PrintBlastRadius(&buf, radius)
+
+	want := "Compromised: web\n" +
+		"├── api via frontend [pivot]\n" +
+		"│   └── db via backend [pivot]\n" +
+		"│       └── backup via storage [pivot]\n" +
+		"│           └── vault via isolated\n" +
+		"└── proxy via frontend [pivot]\n" +
+		"    └── dashboard via admin\n" +
+		"\n" +
+		"Reachable: 6 container(s) on 5 network(s), up to 4 hop(s)\n" +
+		"Pivots: api, backup, db, proxy\n"
+	if buf.String() != want {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestPrintBlastRadius_NoHops(t *testing.T) {
+	var buf bytes.Buffer
+	PrintBlastRadius(&buf, models.BlastRadius{Container: "offline"})
+
+	want := "Compromised: offline\n" +
+		"└── (no reachable containers)\n"
+	if buf.String() != want {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
@@ -0,0 +1,94 @@
+// Package output provides tree-style formatters for Docker network topology visualization.
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/ipam"
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// PrintNetworkCapacity prints a tree-style report of the address pool
+// utilization of a Docker network. Pools at or above the warning threshold
+// are flagged as nearly exhausted.
+//
+// Example output:
+//
+//	Network: small_net (bridge)
+//	└── 172.20.0.0/28: 11 used, 2 available of 13 (85%) WARNING: nearly exhausted
+//
+// Parameters:
+//   - w: The io.Writer to write the output to
+//   - net: The NetworkInfo including its subnets and allocated endpoint addresses
+//   - threshold: The utilization (0-1) at which a pool is flagged
+func PrintNetworkCapacity(w io.Writer, net models.NetworkInfo, threshold float64) {
+	cw := NewColorWriter(w)
+
+	fmt.Fprintf(w, "%s %s (%s)\n",
+		cw.Label("Network:"),
+		cw.Network(net.Name),
+		net.Driver)
+
+	pools := ipam.NetworkUsage(net, net.Addresses)
+	if len(pools) == 0 {
+		fmt.Fprintf(w, "%s (no address pools)\n", cw.Tree(TreeEnd))
+		return
+	}
+
+	for i, p := range pools {
+		prefix := TreeBranch
+		if i == len(pools)-1 {
+			prefix = TreeEnd
+		}
+
+		line := fmt.Sprintf("%s: %s used, %s available of %s (%s)",
+			p.Pool(),
+			ipam.FormatCount(p.Used),
+			ipam.FormatCount(p.Available()),
+			ipam.FormatCount(p.Size),
+			formatPercent(p.Utilization()))
+		if p.NearlyExhausted(threshold) {
+			line += " " + cw.Warning("WARNING: nearly exhausted")
+		}
+
+		fmt.Fprintf(w, "%s %s\n", cw.Tree(prefix), line)
+	}
+}
+
+// poolSummary returns a compact description of a network's address pool
+// utilization for use in the network tree header, for example
+// "[172.20.0.0/28 11/13 used (85%)]". It returns an empty string for networks
+// without address pools.
+func poolSummary(cw *ColorWriter, net models.NetworkInfo, threshold float64) string {
+	pools := ipam.NetworkUsage(net, net.Addresses)
+	if len(pools) == 0 {
+		return ""
+	}
+
+	parts := make([]string, 0, len(pools))
+	exhausted := false
+	for _, p := range pools {
+		parts = append(parts, fmt.Sprintf("%s %s/%s used (%s)",
+			p.Pool(),
+			ipam.FormatCount(p.Used),
+			ipam.FormatCount(p.Size),
+			formatPercent(p.Utilization())))
+		if p.NearlyExhausted(threshold) {
+			exhausted = true
+		}
+	}
+
+	summary := "[" + strings.Join(parts, ", ") + "]"
+	if exhausted {
+		summary += " " + cw.Warning("WARNING: address pool nearly exhausted")
+	}
+
+	return summary
+}
+
+// formatPercent formats a 0-1 ratio as a whole percentage.
+func formatPercent(ratio float64) string {
+	return fmt.Sprintf("%.0f%%", ratio*100)
+}
@@ -0,0 +1,70 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+func TestPrintNetworkCapacity(t *testing.T) {
+	net := models.NetworkInfo{
+		Name:   "small_net",
+		Driver: "bridge",
+		Subnets: []models.SubnetInfo{
+			{Subnet: "172.20.0.0/28", Gateway: "172.20.0.1"},
+			{Subnet: "fd00::/64", Gateway: "fd00::1"},
+		},
+		Addresses: []string{"172.20.0.2", "172.20.0.3", "fd00::2"},
+	}
+
+	buf := new(bytes.Buffer)
+	PrintNetworkCapacity(buf, net, 0.8)
+	out := buf.String()
+
+	expected := []string{
+		"Network: small_net (bridge)\n",
+		TreeBranch + " 172.20.0.0/28: 2 used, 11 available of 13 (15%)\n",
+		TreeEnd + " fd00::/64: 1 used, 2^64+ available of 2^64+ (0%)\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(out, want) {
+			t.Errorf("output should contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestPrintNetworkCapacity_Warning(t *testing.T) {
+	net := models.NetworkInfo{
+		Name:      "tiny_net",
+		Driver:    "bridge",
+		Subnets:   []models.SubnetInfo{{Subnet: "10.0.0.0/30", Gateway: "10.0.0.1"}},
+		Addresses: []string{"10.0.0.2"},
+	}
+
+	buf := new(bytes.Buffer)
+	PrintNetworkCapacity(buf, net, 0.8)
+
+	if !strings.Contains(buf.String(), "WARNING: nearly exhausted") {
+		t.Errorf("full pool should be flagged, got:\n%s", buf.String())
+	}
+}
+
+func TestPrintNetworkCapacity_NoPools(t *testing.T) {
+	buf := new(bytes.Buffer)
+	PrintNetworkCapacity(buf, models.NetworkInfo{Name: "host", Driver: "host"}, 0.8)
+
+	if !strings.Contains(buf.String(), TreeEnd+" (no address pools)") {
+		t.Errorf("expected no pools message, got:\n%s", buf.String())
+	}
+}
+
+func TestFormatPercent(t *testing.T) {
+	tests := map[float64]string{0: "0%", 0.5: "50%", 0.846: "85%", 1: "100%"}
+	for ratio, want := range tests {
+		if got := formatPercent(ratio); got != want {
+			t.Errorf("formatPercent(%v) = %q, want %q", ratio, got, want)
+		}
+	}
+}
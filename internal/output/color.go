@@ -17,6 +17,7 @@ var (
 	colorAlias     = color.New(color.FgYellow)
 	colorLabel     = color.New(color.FgMagenta)
 	colorTree      = color.New(color.FgBlue)
+	colorWarning   = color.New(color.FgRed, color.Bold)
 )
 
 // ColorWriter wraps an io.Writer and provides colored output methods.
@@ -99,6 +100,14 @@ func (cw *ColorWriter) Tree(text string) string {
 	return colorTree.Sprint(text)
 }
 
+// Warning prints text in warning color (red, bold).
+func (cw *ColorWriter) Warning(text string) string {
+	if !cw.enabled {
+		return text
+	}
+	return colorWarning.Sprint(text)
+}
+
 // IsEnabled returns whether color is enabled.
 func (cw *ColorWriter) IsEnabled() bool {
 	return cw.enabled
@@ -171,6 +171,31 @@ func TestColorWriter_Tree_Enabled(t *testing.T) {
 	}
 }
 
+func TestColorWriter_Warning_Disabled(t *testing.T) {
+	var buf bytes.Buffer
+	cw := &ColorWriter{writer: &buf, enabled: false}
+
+	result := cw.Warning("WARNING")
+
+	if result != "WARNING" {
+		t.Errorf("expected plain text 'WARNING', got %q", result)
+	}
+}
+
+func TestColorWriter_Warning_Enabled(t *testing.T) {
+	color.NoColor = false
+	defer func() { color.NoColor = true }()
+
+	var buf bytes.Buffer
+	cw := &ColorWriter{writer: &buf, enabled: true}
+
+	result := cw.Warning("WARNING")
+
+	if result == "WARNING" {
+		t.Error("expected colored output when enabled")
+	}
+}
+
 func TestColorWriter_IsEnabled(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -318,6 +343,7 @@ func TestColorWriter_AllColorMethodsReturnTextWhenDisabled(t *testing.T) {
 		{"Alias", cw.Alias},
 		{"Label", cw.Label},
 		{"Tree", cw.Tree},
+		{"Warning", cw.Warning},
 	}
 
 	for _, m := range methods {
@@ -348,6 +374,7 @@ func TestColorWriter_AllColorMethodsReturnColoredTextWhenEnabled(t *testing.T) {
 		{"Alias", cw.Alias},
 		{"Label", cw.Label},
 		{"Tree", cw.Tree},
+		{"Warning", cw.Warning},
 	}
 
 	for _, m := range methods {
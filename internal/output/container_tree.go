@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"sort"
+	"strings"
 
 	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
 )
@@ -27,14 +28,34 @@ import (
 //	        ├── postgres
 //	        └── redis
 //
+// Internal networks and networks with inter-container communication disabled
+// are annotated. On the latter, the containers sharing the network are still
+// listed but marked as blocked, since the bridge drops traffic between them:
+//
+//	└── Network: vault_net [internal: no external access] [inter-container communication disabled]
+//	    └── connects to:
+//	        └── vault (blocked)
+//
 // Parameters:
 //   - w: The io.Writer to write the output to
 //   - c: Pointer to the ContainerInfo for the container being displayed
 //   - netMap: Map of network names to slices of ContainerInfo for containers on each network
-func PrintContainerTree(w io.Writer, c *models.ContainerInfo, netMap map[string][]models.ContainerInfo) {
+//   - networks: Map of network names to NetworkInfo, used for the internal and
+//     inter-container communication settings; may be nil
+func PrintContainerTree(
+	w io.Writer,
+	c *models.ContainerInfo,
+	netMap map[string][]models.ContainerInfo,
+	networks map[string]*models.NetworkInfo,
+) {
 	cw := NewColorWriter(w)
 
-	fmt.Fprintf(w, "%s %s\n", cw.Label("Container:"), cw.Container(c.Name))
+	if c.NetworkNamespace != "" {
+		fmt.Fprintf(w, "%s %s (shares the network namespace of %s)\n",
+			cw.Label("Container:"), cw.Container(c.Name), cw.Container(c.NetworkNamespace))
+	} else {
+		fmt.Fprintf(w, "%s %s\n", cw.Label("Container:"), cw.Container(c.Name))
+	}
 
 	// Sort networks for consistent output
 	sortedNetworks := make([]string, len(c.Networks))
@@ -49,7 +70,8 @@ func PrintContainerTree(w io.Writer, c *models.ContainerInfo, netMap map[string]
 			indent = TreeSpace
 		}
 
-		fmt.Fprintf(w, "%s %s %s\n", cw.Tree(prefix), cw.Label("Network:"), cw.Network(net))
+		fmt.Fprintf(w, "%s %s %s%s\n", cw.Tree(prefix), cw.Label("Network:"), cw.Network(net),
+			networkAnnotations(cw, net, networks))
 		fmt.Fprintf(w, "%s%s %s\n", cw.Tree(indent), cw.Tree(TreeEnd), cw.Label("connects to:"))
 
 		others := ReachableContainers(c.Name, net, netMap)
@@ -58,12 +80,35 @@ func PrintContainerTree(w io.Writer, c *models.ContainerInfo, netMap map[string]
 			continue
 		}
 
+		blocked := ""
+		if !CanReach(net, networks) {
+			blocked = " " + cw.Warning("(blocked)")
+		}
+
 		for j, o := range others {
 			op := TreeBranch
 			if j == len(others)-1 {
 				op = TreeEnd
 			}
-			fmt.Fprintf(w, "%s    %s %s\n", cw.Tree(indent), cw.Tree(op), cw.Container(o))
+			fmt.Fprintf(w, "%s    %s %s%s\n", cw.Tree(indent), cw.Tree(op), cw.Container(o), blocked)
 		}
 	}
 }
+
+// networkAnnotations returns the notes printed after a network name in the
+// container tree, each preceded by a space, or an empty string.
+func networkAnnotations(cw *ColorWriter, network string, networks map[string]*models.NetworkInfo) string {
+	n, ok := networks[network]
+	if !ok || n == nil {
+		return ""
+	}
+
+	var notes []string
+	if n.Internal {
+		notes = append(notes, " [internal: no external access]")
+	}
+	if !n.ICCEnabled() {
+		notes = append(notes, " "+cw.Warning("[inter-container communication disabled]"))
+	}
+	return strings.Join(notes, "")
+}
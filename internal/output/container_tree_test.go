@@ -21,7 +21,7 @@ func TestPrintContainerTree_SingleNetworkNoReachability(t *testing.T) {
 		},
 	}
 
-	PrintContainerTree(&buf, c, netMap)
+	PrintContainerTree(&buf, c, netMap, nil)
 
 	output := buf.String()
 
@@ -60,7 +60,7 @@ func TestPrintContainerTree_SingleNetworkWithReachability(t *testing.T) {
 		},
 	}
 
-	PrintContainerTree(&buf, c, netMap)
+	PrintContainerTree(&buf, c, netMap, nil)
 
 	output := buf.String()
 
@@ -104,7 +104,7 @@ func TestPrintContainerTree_MultipleNetworks(t *testing.T) {
 		},
 	}
 
-	PrintContainerTree(&buf, c, netMap)
+	PrintContainerTree(&buf, c, netMap, nil)
 
 	output := buf.String()
 
@@ -144,7 +144,7 @@ func TestPrintContainerTree_NetworksSortedAlphabetically(t *testing.T) {
 		"beta_net":  {{Name: "service", Networks: []string{"zebra_net", "alpha_net", "beta_net"}}},
 	}
 
-	PrintContainerTree(&buf, c, netMap)
+	PrintContainerTree(&buf, c, netMap, nil)
 
 	output := buf.String()
 
@@ -179,7 +179,7 @@ func TestPrintContainerTree_ReachableContainersSortedAlphabetically(t *testing.T
 		},
 	}
 
-	PrintContainerTree(&buf, c, netMap)
+	PrintContainerTree(&buf, c, netMap, nil)
 
 	output := buf.String()
 
@@ -214,7 +214,7 @@ func TestPrintContainerTree_TreePrefixesCorrect(t *testing.T) {
 		},
 	}
 
-	PrintContainerTree(&buf, c, netMap)
+	PrintContainerTree(&buf, c, netMap, nil)
 
 	output := buf.String()
 	lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
@@ -251,7 +251,7 @@ func TestPrintContainerTree_EmptyNetworks(t *testing.T) {
 	}
 	netMap := map[string][]models.ContainerInfo{}
 
-	PrintContainerTree(&buf, c, netMap)
+	PrintContainerTree(&buf, c, netMap, nil)
 
 	output := buf.String()
 
@@ -277,7 +277,7 @@ func TestPrintContainerTree_DoesNotModifyOriginalNetworks(t *testing.T) {
 	// Keep original order
 	originalOrder := []string{c.Networks[0], c.Networks[1]}
 
-	PrintContainerTree(&buf, c, netMap)
+	PrintContainerTree(&buf, c, netMap, nil)
 
 	// Verify original slice is not modified
 	if c.Networks[0] != originalOrder[0] || c.Networks[1] != originalOrder[1] {
@@ -300,7 +300,7 @@ func TestPrintContainerTree_SingleReachableContainer(t *testing.T) {
 		},
 	}
 
-	PrintContainerTree(&buf, c, netMap)
+	PrintContainerTree(&buf, c, netMap, nil)
 
 	output := buf.String()
 
@@ -326,7 +326,7 @@ func TestPrintContainerTree_MultipleReachableContainersPrefixes(t *testing.T) {
 		},
 	}
 
-	PrintContainerTree(&buf, c, netMap)
+	PrintContainerTree(&buf, c, netMap, nil)
 
 	output := buf.String()
 	lines := strings.Split(output, "\n")
@@ -356,3 +356,48 @@ func TestPrintContainerTree_MultipleReachableContainersPrefixes(t *testing.T) {
 		t.Errorf("last reachable should have end prefix:\n%s", reachableLines[2])
 	}
 }
+
+func TestPrintContainerTree_SharedNamespaceHeader(t *testing.T) {
+	var buf bytes.Buffer
+	c := &models.ContainerInfo{Name: "app", NetworkNamespace: "3f2b1c9d8e7a-infra", Networks: []string{"shop"}}
+	netMap := map[string][]models.ContainerInfo{
+		"shop": {{Name: "app"}, {Name: "3f2b1c9d8e7a-infra"}},
+	}
+
+	PrintContainerTree(&buf, c, netMap, nil)
+
+	if !strings.HasPrefix(buf.String(), "Container: app (shares the network namespace of 3f2b1c9d8e7a-infra)\n") {
+		t.Errorf("expected shared namespace header, got:\n%s", buf.String())
+	}
+}
+
+func TestPrintContainerTree_ICCDisabledAndInternal(t *testing.T) {
+	var buf bytes.Buffer
+	c := &models.ContainerInfo{Name: "api", Networks: []string{"frontend", "vault_net"}}
+	netMap := map[string][]models.ContainerInfo{
+		"frontend":  {{Name: "api"}, {Name: "nginx"}},
+		"vault_net": {{Name: "api"}, {Name: "vault"}},
+	}
+	networks := NetworksByName([]*models.NetworkInfo{
+		{Name: "frontend", Driver: "bridge"},
+		{
+			Name:     "vault_net",
+			Driver:   "bridge",
+			Internal: true,
+			Options:  map[string]string{models.BridgeICCOption: "false"},
+		},
+	})
+
+	PrintContainerTree(&buf, c, netMap, networks)
+
+	want := "Container: api\n" +
+		"├── Network: frontend\n" +
+		"│   └── connects to:\n" +
+		"│       └── nginx\n" +
+		"└── Network: vault_net [internal: no external access] [inter-container communication disabled]\n" +
+		"    └── connects to:\n" +
+		"        └── vault (blocked)\n"
+	if buf.String() != want {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
@@ -0,0 +1,118 @@
+// Package output provides tree-style formatters for Docker network topology visualization.
+package output
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/deps"
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// PrintDependencyTree prints a network and its containers, with the
+// dependencies of each container that resolve on the network beneath it.
+// dependencies maps container names to their checked dependencies.
+//
+// Example output:
+//
+//	Network: shop_back (bridge)
+//	├── shop-api-1
+//	│   ├── depends on: cache -> shop-cache-1 (depends_on)
+//	│   └── depends on: db -> shop-db-1 (DATABASE_URL)
+//	├── shop-cache-1
+//	└── shop-db-1
+func PrintDependencyTree(w io.Writer, net models.NetworkInfo, containers []models.ContainerInfo, dependencies map[string][]deps.Dependency) {
+	cw := NewColorWriter(w)
+
+	fmt.Fprintf(w, "%s %s (%s)\n", cw.Label("Network:"), cw.Network(net.Name), net.Driver)
+
+	if len(containers) == 0 {
+		fmt.Fprintf(w, "%s (no containers)\n", cw.Tree(TreeEnd))
+		return
+	}
+
+	sorted := make([]models.ContainerInfo, len(containers))
+	copy(sorted, containers)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	for i, c := range sorted {
+		prefix := TreeBranch
+		indent := TreeVertical
+		if i == len(sorted)-1 {
+			prefix = TreeEnd
+			indent = TreeSpace
+		}
+		fmt.Fprintf(w, "%s %s\n", cw.Tree(prefix), cw.Container(c.Name))
+
+		var onNetwork []deps.Dependency
+		for _, dep := range dependencies[c.Name] {
+			if dep.Resolved() && dep.Network == net.Name {
+				onNetwork = append(onNetwork, dep)
+			}
+		}
+		for j, dep := range onNetwork {
+			depPrefix := TreeBranch
+			if j == len(onNetwork)-1 {
+				depPrefix = TreeEnd
+			}
+			fmt.Fprintf(w, "%s%s %s %s -> %s (%s)\n",
+				cw.Tree(indent), cw.Tree(depPrefix), cw.Label("depends on:"),
+				dep.Name, cw.Container(strings.Join(dep.Targets, ", ")), dep.Reference)
+		}
+	}
+}
+
+// PrintUnresolvedDependencies prints the dependencies that do not resolve
+// from their container, grouped by container, with the reason each one
+// fails. Nothing is printed when every dependency resolves.
+//
+// Example output:
+//
+//	Unresolved dependencies:
+//	└── shop-worker-1
+//	    └── mail (SMTP_HOST) ERROR: resolves only on mail_net, which shop-worker-1 is not attached to
+func PrintUnresolvedDependencies(w io.Writer, dependencies map[string][]deps.Dependency) {
+	cw := NewColorWriter(w)
+
+	var names []string
+	unresolved := make(map[string][]deps.Dependency)
+	for name, list := range dependencies {
+		for _, dep := range list {
+			if !dep.Resolved() {
+				unresolved[name] = append(unresolved[name], dep)
+			}
+		}
+		if len(unresolved[name]) > 0 {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(w, cw.Label("Unresolved dependencies:"))
+	for i, name := range names {
+		prefix := TreeBranch
+		indent := TreeVertical
+		if i == len(names)-1 {
+			prefix = TreeEnd
+			indent = TreeSpace
+		}
+		fmt.Fprintf(w, "%s %s\n", cw.Tree(prefix), cw.Container(name))
+
+		list := unresolved[name]
+		for j, dep := range list {
+			depPrefix := TreeBranch
+			if j == len(list)-1 {
+				depPrefix = TreeEnd
+			}
+			fmt.Fprintf(w, "%s%s %s (%s) %s\n",
+				cw.Tree(indent), cw.Tree(depPrefix), dep.Name, dep.Reference, cw.Warning("ERROR: "+dep.Problem))
+		}
+	}
+}
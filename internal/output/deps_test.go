@@ -0,0 +1,88 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/deps"
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// testDependencies returns checked dependencies with resolved and
+// unresolved entries.
+func testDependencies() map[string][]deps.Dependency {
+	return map[string][]deps.Dependency{
+		"api": {
+			{
+				Reference: deps.Reference{Name: "cache", Source: deps.SourceDependsOn},
+				Container: "api", Network: "backend", Targets: []string{"cache"},
+			},
+			{
+				Reference: deps.Reference{Name: "db", Source: deps.SourceEnv, Variable: "DATABASE_URL"},
+				Container: "api", Network: "backend", Targets: []string{"db"},
+			},
+			{
+				Reference: deps.Reference{Name: "web", Source: deps.SourceEnv, Variable: "WEB_URL"},
+				Container: "api", Network: "frontend", Targets: []string{"web"},
+			},
+		},
+		"worker": {
+			{
+				Reference: deps.Reference{Name: "mail", Source: deps.SourceEnv, Variable: "SMTP_HOST"},
+				Container: "worker", Problem: "resolves only on mail_net, which worker is not attached to",
+			},
+		},
+	}
+}
+
+func TestPrintDependencyTree(t *testing.T) {
+	containers := []models.ContainerInfo{{Name: "db"}, {Name: "api"}, {Name: "cache"}}
+
+	var buf bytes.Buffer
+	PrintDependencyTree(&buf, models.NetworkInfo{Name: "backend", Driver: "bridge"}, containers, testDependencies())
+
+	want := "Network: backend (bridge)\n" +
+		"├── api\n" +
+		"│   ├── depends on: cache -> cache (depends_on)\n" +
+		"│   └── depends on: db -> db (DATABASE_URL)\n" +
+		"├── cache\n" +
+		"└── db\n"
+	if buf.String() != want {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestPrintDependencyTree_NoContainers(t *testing.T) {
+	var buf bytes.Buffer
+	PrintDependencyTree(&buf, models.NetworkInfo{Name: "empty", Driver: "bridge"}, nil, nil)
+
+	want := "Network: empty (bridge)\n" +
+		"└── (no containers)\n"
+	if buf.String() != want {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestPrintUnresolvedDependencies(t *testing.T) {
+	var buf bytes.Buffer
+	PrintUnresolvedDependencies(&buf, testDependencies())
+
+	want := "Unresolved dependencies:\n" +
+		"└── worker\n" +
+		"    └── mail (SMTP_HOST) ERROR: resolves only on mail_net, which worker is not attached to\n"
+	if buf.String() != want {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestPrintUnresolvedDependencies_AllResolved(t *testing.T) {
+	dependencies := testDependencies()
+	delete(dependencies, "worker")
+
+	var buf bytes.Buffer
+	PrintUnresolvedDependencies(&buf, dependencies)
+
+	if buf.Len() != 0 {
+		t.Errorf("expected no output, got %q", buf.String())
+	}
+}
@@ -0,0 +1,117 @@
+// Package output provides tree-style formatters for Docker network topology visualization.
+package output
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// PrintContainerDNS prints a tree-style representation of the names a
+// container can resolve on each of its networks, with the containers and
+// addresses they resolve to. Names that resolve to several containers are
+// flagged as round-robin, and names that resolve to different containers on
+// different networks are listed as conflicts.
+//
+// Example output:
+//
+//	Container: api
+//	├── Network: backend_net
+//	│   ├── cache -> redis-1 (172.20.0.4), redis-2 (172.20.0.5) [alias] ROUND-ROBIN
+//	│   └── db -> postgres (172.20.0.3) [name]
+//	└── Network: bridge (no embedded DNS)
+//	    └── (no resolvable names)
+//	Conflicts:
+//	└── web: backend_net -> api-proxy; frontend_net -> nginx
+//
+// Parameters:
+//   - w: The io.Writer to write the output to
+//   - dns: The container's DNS view
+func PrintContainerDNS(w io.Writer, dns *models.ContainerDNS) {
+	cw := NewColorWriter(w)
+
+	fmt.Fprintf(w, "%s %s\n", cw.Label("Container:"), cw.Container(dns.Container))
+
+	if len(dns.Networks) == 0 {
+		fmt.Fprintf(w, "%s (no networks)\n", cw.Tree(TreeEnd))
+	}
+
+	for i, nd := range dns.Networks {
+		prefix := TreeBranch
+		indent := TreeVertical
+		if i == len(dns.Networks)-1 {
+			prefix = TreeEnd
+			indent = TreeSpace
+		}
+
+		header := fmt.Sprintf("%s %s %s", cw.Tree(prefix), cw.Label("Network:"), cw.Network(nd.Network))
+		if !nd.EmbeddedDNS {
+			header += " (no embedded DNS)"
+		}
+		fmt.Fprintln(w, header)
+
+		if len(nd.Records) == 0 {
+			fmt.Fprintf(w, "%s%s (no resolvable names)\n", cw.Tree(indent), cw.Tree(TreeEnd))
+			continue
+		}
+
+		for j, rec := range nd.Records {
+			rp := TreeBranch
+			if j == len(nd.Records)-1 {
+				rp = TreeEnd
+			}
+			fmt.Fprintf(w, "%s%s %s\n", cw.Tree(indent), cw.Tree(rp), formatDNSRecord(cw, rec))
+		}
+	}
+
+	if len(dns.Conflicts) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, cw.Warning("Conflicts:"))
+	for i, conflict := range dns.Conflicts {
+		prefix := TreeBranch
+		if i == len(dns.Conflicts)-1 {
+			prefix = TreeEnd
+		}
+		fmt.Fprintf(w, "%s %s: %s\n", cw.Tree(prefix), cw.Alias(conflict.Name), formatDNSConflict(cw, conflict))
+	}
+}
+
+// formatDNSRecord formats a DNS record as "name -> target (ip), ... [sources]".
+func formatDNSRecord(cw *ColorWriter, rec models.DNSRecord) string {
+	targets := make([]string, len(rec.Targets))
+	for i, t := range rec.Targets {
+		targets[i] = cw.Container(t.Container)
+		if len(t.IPs) > 0 {
+			targets[i] += " (" + strings.Join(t.IPs, ", ") + ")"
+		}
+	}
+
+	line := fmt.Sprintf("%s -> %s [%s]", cw.Alias(rec.Name), strings.Join(targets, ", "), strings.Join(rec.Sources, ", "))
+	if rec.IsAmbiguous() {
+		line += " " + cw.Warning("ROUND-ROBIN")
+	}
+
+	return line
+}
+
+// formatDNSConflict formats the per-network targets of a conflicting name as
+// "net1 -> a; net2 -> b", sorted by network name.
+func formatDNSConflict(cw *ColorWriter, conflict models.DNSConflict) string {
+	networks := make([]string, 0, len(conflict.Networks))
+	for net := range conflict.Networks {
+		networks = append(networks, net)
+	}
+	sort.Strings(networks)
+
+	parts := make([]string, len(networks))
+	for i, net := range networks {
+		parts[i] = fmt.Sprintf("%s -> %s", cw.Network(net), strings.Join(conflict.Networks[net], ", "))
+	}
+
+	return strings.Join(parts, "; ")
+}
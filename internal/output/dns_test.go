@@ -0,0 +1,72 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+func TestPrintContainerDNS(t *testing.T) {
+	dns := &models.ContainerDNS{
+		Container: "api",
+		Networks: []models.NetworkDNS{
+			{
+				Network:     "backend_net",
+				EmbeddedDNS: true,
+				Records: []models.DNSRecord{
+					{
+						Name:    "cache",
+						Sources: []string{models.DNSSourceAlias},
+						Targets: []models.DNSTarget{
+							{Container: "redis-1", IPs: []string{"172.20.0.4"}},
+							{Container: "redis-2", IPs: []string{"172.20.0.5"}},
+						},
+					},
+					{
+						Name:    "db",
+						Sources: []string{models.DNSSourceName},
+						Targets: []models.DNSTarget{{Container: "db", IPs: []string{"172.20.0.3"}}},
+					},
+				},
+			},
+			{Network: "bridge"},
+		},
+		Conflicts: []models.DNSConflict{
+			{Name: "web", Networks: map[string][]string{"frontend_net": {"nginx"}, "backend_net": {"api-proxy"}}},
+		},
+	}
+
+	buf := new(bytes.Buffer)
+	PrintContainerDNS(buf, dns)
+	out := buf.String()
+
+	expected := []string{
+		"Container: api\n",
+		TreeBranch + " Network: backend_net\n",
+		TreeVertical + TreeBranch + " cache -> redis-1 (172.20.0.4), redis-2 (172.20.0.5) [alias] ROUND-ROBIN\n",
+		TreeVertical + TreeEnd + " db -> db (172.20.0.3) [name]\n",
+		TreeEnd + " Network: bridge (no embedded DNS)\n",
+		TreeSpace + TreeEnd + " (no resolvable names)\n",
+		"Conflicts:\n",
+		TreeEnd + " web: backend_net -> api-proxy; frontend_net -> nginx\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(out, want) {
+			t.Errorf("output should contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestPrintContainerDNS_NoNetworks(t *testing.T) {
+	buf := new(bytes.Buffer)
+	PrintContainerDNS(buf, &models.ContainerDNS{Container: "isolated"})
+
+	if !strings.Contains(buf.String(), "(no networks)") {
+		t.Errorf("expected no networks message, got:\n%s", buf.String())
+	}
+	if strings.Contains(buf.String(), "Conflicts") {
+		t.Errorf("expected no conflicts section, got:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,146 @@
+// Package output provides tree-style formatters for Docker network topology visualization.
+package output
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// Network drivers with specific egress behavior.
+const (
+	driverBridge  = "bridge"
+	driverHost    = "host"
+	driverNone    = "none"
+	driverOverlay = "overlay"
+	driverMacvlan = "macvlan"
+	driverIpvlan  = "ipvlan"
+)
+
+// gatewayBridgeNetwork is the bridge network Docker attaches containers on
+// overlay networks to for their egress.
+const gatewayBridgeNetwork = "docker_gwbridge"
+
+// NetworkEgressOf returns the egress a network provides to its containers.
+// Bridge networks route out through their gateway unless they are internal,
+// and masquerade unless com.docker.network.bridge.enable_ip_masquerade is
+// false. Overlay networks route out through docker_gwbridge; macvlan and
+// ipvlan networks place containers directly on the parent network. n may be
+// nil when the network's details are not known.
+func NetworkEgressOf(name string, n *models.NetworkInfo) models.NetworkEgress {
+	e := models.NetworkEgress{Network: name}
+
+	switch {
+	case name == driverNone || (n != nil && n.Driver == driverNone):
+		e.Kind, e.Reason = models.EgressNone, "no network interface"
+	case n == nil:
+		e.Kind, e.Reason = models.EgressUnknown, "network details unavailable"
+	case n.Driver == driverHost:
+		e.Kind = models.EgressHost
+	case n.Internal:
+		e.Kind, e.Reason = models.EgressNone, "internal network"
+	case n.Driver == driverBridge:
+		e.Gateway = n.Gateway()
+		e.Kind = models.EgressMasqueraded
+		if !n.MasqueradeEnabled() {
+			e.Kind, e.Reason = models.EgressRouted, "masquerading disabled"
+		}
+	case n.Driver == driverOverlay:
+		e.Kind, e.Reason = models.EgressMasqueraded, "through "+gatewayBridgeNetwork
+	case n.Driver == driverMacvlan || n.Driver == driverIpvlan:
+		e.Kind, e.Gateway, e.Reason = models.EgressDirect, n.Gateway(), n.Driver
+	default:
+		e.Kind, e.Reason = models.EgressUnknown, n.Driver+" driver"
+	}
+
+	return e
+}
+
+// ContainerEgressOf returns the egress of each of a container's networks
+// and the network that provides its default route.
+//
+// Like Docker, the default route is taken from the first network, by name,
+// that has a gateway. Overlay networks only provide one through
+// docker_gwbridge, which Docker uses only when no other network has a
+// gateway.
+func ContainerEgressOf(c *models.ContainerInfo, networks map[string]*models.NetworkInfo) models.ContainerEgress {
+	result := models.ContainerEgress{Container: c.Name}
+
+	names := make([]string, len(c.Networks))
+	copy(names, c.Networks)
+	sort.Strings(names)
+
+	fallback := ""
+	for _, name := range names {
+		e := NetworkEgressOf(name, networks[name])
+		result.Networks = append(result.Networks, e)
+
+		if !e.HasRoute() {
+			continue
+		}
+		if n := networks[name]; n != nil && n.Driver == driverOverlay {
+			if fallback == "" {
+				fallback = name
+			}
+			continue
+		}
+		if result.DefaultRoute == "" {
+			result.DefaultRoute = name
+		}
+	}
+
+	if result.DefaultRoute == "" {
+		result.DefaultRoute = fallback
+	}
+
+	return result
+}
+
+// PrintContainerEgress prints a container's egress per network and whether
+// it can reach the internet.
+//
+// Example output:
+//
+//	Container: api
+//	├── Network: backend_net - none (internal network)
+//	├── Network: frontend_net - masqueraded via 172.18.0.1 [default route]
+//	└── internet access: yes (via frontend_net)
+func PrintContainerEgress(w io.Writer, egress models.ContainerEgress) {
+	cw := NewColorWriter(w)
+
+	fmt.Fprintf(w, "%s %s\n", cw.Label("Container:"), cw.Container(egress.Container))
+
+	for _, e := range egress.Networks {
+		line := e.Kind
+		if e.Gateway != "" {
+			line += " via " + e.Gateway
+		}
+		if e.Reason != "" {
+			line += " (" + e.Reason + ")"
+		}
+		if e.Network == egress.DefaultRoute {
+			line += " [default route]"
+		}
+		fmt.Fprintf(w, "%s %s %s - %s\n", cw.Tree(TreeBranch), cw.Label("Network:"), cw.Network(e.Network), line)
+	}
+
+	fmt.Fprintf(w, "%s %s %s\n", cw.Tree(TreeEnd), cw.Label("internet access:"), internetAccess(cw, egress))
+}
+
+// internetAccess describes whether a container can reach the internet.
+func internetAccess(cw *ColorWriter, egress models.ContainerEgress) string {
+	via := fmt.Sprintf("(via %s)", cw.Network(egress.DefaultRoute))
+
+	switch egress.Internet() {
+	case models.EgressMasqueraded, models.EgressDirect, models.EgressHost:
+		return cw.Warning("yes") + " " + via
+	case models.EgressRouted:
+		return cw.Warning("routed without NAT") + " " + via
+	case models.EgressUnknown:
+		return "unknown"
+	default:
+		return "no"
+	}
+}
@@ -0,0 +1,148 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// egressNetworks returns networks covering every egress kind.
+func egressNetworks() map[string]*models.NetworkInfo {
+	return NetworksByName([]*models.NetworkInfo{
+		{Name: "backend", Driver: "bridge", Internal: true},
+		{Name: "frontend", Driver: "bridge", Subnets: []models.SubnetInfo{{Subnet: "172.18.0.0/16", Gateway: "172.18.0.1"}}},
+		{
+			Name:    "lab",
+			Driver:  "bridge",
+			Options: map[string]string{models.BridgeMasqueradeOption: "false"},
+			Subnets: []models.SubnetInfo{{Subnet: "10.10.0.0/24", Gateway: "10.10.0.1"}},
+		},
+		{Name: "mesh", Driver: "overlay"},
+		{Name: "lan", Driver: "macvlan", Subnets: []models.SubnetInfo{{Subnet: "192.168.1.0/24", Gateway: "192.168.1.1"}}},
+		{Name: "host", Driver: "host"},
+		{Name: "none", Driver: "null"},
+		{Name: "shop_shared", Driver: "external"},
+	})
+}
+
+func TestNetworkEgressOf(t *testing.T) {
+	networks := egressNetworks()
+
+	tests := []struct {
+		network string
+		kind    string
+		gateway string
+		reason  string
+	}{
+		{network: "backend", kind: models.EgressNone, reason: "internal network"},
+		{network: "frontend", kind: models.EgressMasqueraded, gateway: "172.18.0.1"},
+		{network: "lab", kind: models.EgressRouted, gateway: "10.10.0.1", reason: "masquerading disabled"},
+		{network: "mesh", kind: models.EgressMasqueraded, reason: "through docker_gwbridge"},
+		{network: "lan", kind: models.EgressDirect, gateway: "192.168.1.1", reason: "macvlan"},
+		{network: "host", kind: models.EgressHost},
+		{network: "none", kind: models.EgressNone, reason: "no network interface"},
+		{network: "shop_shared", kind: models.EgressUnknown, reason: "external driver"},
+		{network: "missing", kind: models.EgressUnknown, reason: "network details unavailable"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.network, func(t *testing.T) {
+			e := NetworkEgressOf(tt.network, networks[tt.network])
+			if e.Kind != tt.kind || e.Gateway != tt.gateway || e.Reason != tt.reason {
+				t.Errorf("NetworkEgressOf(%s) = %+v, want kind %q gateway %q reason %q",
+					tt.network, e, tt.kind, tt.gateway, tt.reason)
+			}
+		})
+	}
+}
+
+func TestContainerEgressOf_DefaultRoute(t *testing.T) {
+	networks := egressNetworks()
+
+	tests := []struct {
+		name     string
+		networks []string
+		want     string
+	}{
+		{name: "first network with a gateway by name", networks: []string{"lab", "frontend", "backend"}, want: "frontend"},
+		{name: "overlay only without other gateways", networks: []string{"mesh", "lab"}, want: "lab"},
+		{name: "overlay alone", networks: []string{"mesh", "backend"}, want: "mesh"},
+		{name: "internal only", networks: []string{"backend"}, want: ""},
+		{name: "host", networks: []string{"host"}, want: "host"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &models.ContainerInfo{Name: "api", Networks: tt.networks}
+			egress := ContainerEgressOf(c, networks)
+			if egress.DefaultRoute != tt.want {
+				t.Errorf("DefaultRoute = %q, want %q", egress.DefaultRoute, tt.want)
+			}
+			if len(egress.Networks) != len(tt.networks) {
+				t.Errorf("expected %d networks, got %d", len(tt.networks), len(egress.Networks))
+			}
+		})
+	}
+}
+
+func TestContainerEgressOf_DoesNotModifyNetworks(t *testing.T) {
+	c := &models.ContainerInfo{Name: "api", Networks: []string{"lab", "frontend"}}
+
+	ContainerEgressOf(c, egressNetworks())
+
+	if c.Networks[0] != "lab" {
+		t.Errorf("container networks were reordered: %v", c.Networks)
+	}
+}
+
+func TestPrintContainerEgress(t *testing.T) {
+	tests := []struct {
+		name     string
+		networks []string
+		want     string
+	}{
+		{
+			name:     "internet access",
+			networks: []string{"frontend", "backend"},
+			want: "Container: api\n" +
+				"├── Network: backend - none (internal network)\n" +
+				"├── Network: frontend - masqueraded via 172.18.0.1 [default route]\n" +
+				"└── internet access: yes (via frontend)\n",
+		},
+		{
+			name:     "routed without NAT",
+			networks: []string{"lab"},
+			want: "Container: api\n" +
+				"├── Network: lab - routed via 10.10.0.1 (masquerading disabled) [default route]\n" +
+				"└── internet access: routed without NAT (via lab)\n",
+		},
+		{
+			name:     "no internet access",
+			networks: []string{"backend"},
+			want: "Container: api\n" +
+				"├── Network: backend - none (internal network)\n" +
+				"└── internet access: no\n",
+		},
+		{
+			name:     "unknown",
+			networks: []string{"shop_shared"},
+			want: "Container: api\n" +
+				"├── Network: shop_shared - unknown (external driver)\n" +
+				"└── internet access: unknown\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			c := &models.ContainerInfo{Name: "api", Networks: tt.networks}
+
+			PrintContainerEgress(&buf, ContainerEgressOf(c, egressNetworks()))
+
+			if buf.String() != tt.want {
+				t.Errorf("unexpected output:\n%s\nwant:\n%s", buf.String(), tt.want)
+			}
+		})
+	}
+}
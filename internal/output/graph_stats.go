@@ -0,0 +1,94 @@
+// Package output provides tree-style formatters for Docker network topology visualization.
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/graph"
+)
+
+// PrintGraphStats prints the statistics of the container/network graph: each
+// island with its containers and networks, the containers whose removal
+// would split their island, and the degree of every container and network.
+//
+// Example output:
+//
+//	Islands: 2
+//	├── Island 1: 3 container(s), 2 network(s)
+//	│   ├── containers: api, db, web
+//	│   └── networks: backend, frontend
+//	└── Island 2: 1 container(s), 1 network(s)
+//	    ├── containers: prometheus
+//	    └── networks: monitoring
+//
+//	Articulation points: 1
+//	└── api: splits its island into 2 (networks: backend, frontend)
+//
+//	Container degree:
+//	├── api: 2
+//	├── db: 1
+//	├── prometheus: 1
+//	└── web: 1
+//
+//	Network degree:
+//	├── backend: 2
+//	├── frontend: 2
+//	└── monitoring: 1
+func PrintGraphStats(w io.Writer, stats *graph.Stats) {
+	cw := NewColorWriter(w)
+
+	fmt.Fprintf(w, "%s %d\n", cw.Label("Islands:"), len(stats.Components))
+	for i, comp := range stats.Components {
+		prefix, indent := treePrefix(i, len(stats.Components))
+		fmt.Fprintf(w, "%s Island %d: %d container(s), %d network(s)\n",
+			cw.Tree(prefix), i+1, len(comp.Containers), len(comp.Networks))
+		fmt.Fprintf(w, "%s%s %s %s\n", cw.Tree(indent), cw.Tree(TreeBranch),
+			cw.Label("containers:"), cw.Container(joinOrNone(comp.Containers)))
+		fmt.Fprintf(w, "%s%s %s %s\n", cw.Tree(indent), cw.Tree(TreeEnd),
+			cw.Label("networks:"), cw.Network(joinOrNone(comp.Networks)))
+	}
+	fmt.Fprintln(w)
+
+	fmt.Fprintf(w, "%s %d\n", cw.Label("Articulation points:"), len(stats.ArticulationPoints))
+	for i, ap := range stats.ArticulationPoints {
+		prefix, _ := treePrefix(i, len(stats.ArticulationPoints))
+		fmt.Fprintf(w, "%s %s: %s (networks: %s)\n", cw.Tree(prefix), cw.Container(ap.Container),
+			cw.Warning(fmt.Sprintf("splits its island into %d", ap.Pieces)), cw.Network(strings.Join(ap.Networks, ", ")))
+	}
+	fmt.Fprintln(w)
+
+	printDegrees(w, cw, "Container degree:", stats.Containers, cw.Container)
+	fmt.Fprintln(w)
+	printDegrees(w, cw, "Network degree:", stats.Networks, cw.Network)
+}
+
+// printDegrees prints a list of degrees under a label.
+func printDegrees(w io.Writer, cw *ColorWriter, label string, degrees []graph.Degree, color func(string) string) {
+	fmt.Fprintln(w, cw.Label(label))
+	if len(degrees) == 0 {
+		fmt.Fprintf(w, "%s (none)\n", cw.Tree(TreeEnd))
+		return
+	}
+	for i, d := range degrees {
+		prefix, _ := treePrefix(i, len(degrees))
+		fmt.Fprintf(w, "%s %s: %d\n", cw.Tree(prefix), color(d.Name), d.Degree)
+	}
+}
+
+// treePrefix returns the branch symbol and child indent for item i of n.
+func treePrefix(i, n int) (string, string) {
+	if i == n-1 {
+		return TreeEnd, TreeSpace
+	}
+	return TreeBranch, TreeVertical
+}
+
+// joinOrNone joins names with commas, or returns "(none)" for an empty list.
+func joinOrNone(names []string) string {
+	if len(names) == 0 {
+		return "(none)"
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,64 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/graph"
+)
+
+func TestPrintGraphStats(t *testing.T) {
+	stats := &graph.Stats{
+		Components: []graph.Component{
+			{Containers: []string{"api", "db", "web"}, Networks: []string{"backend", "frontend"}},
+			{Containers: []string{"cron"}, Networks: []string{}},
+		},
+		ArticulationPoints: []graph.ArticulationPoint{
+			{Container: "api", Networks: []string{"backend", "frontend"}, Pieces: 2},
+		},
+		Containers: []graph.Degree{{Name: "api", Degree: 2}, {Name: "db", Degree: 1}},
+		Networks:   []graph.Degree{{Name: "backend", Degree: 2}},
+	}
+
+	var buf bytes.Buffer
+	PrintGraphStats(&buf, stats)
+
+	want := "Islands: 2\n" +
+		"├── Island 1: 3 container(s), 2 network(s)\n" +
+		"│   ├── containers: api, db, web\n" +
+		"│   └── networks: backend, frontend\n" +
+		"└── Island 2: 1 container(s), 0 network(s)\n" +
+		"    ├── containers: cron\n" +
+		"    └── networks: (none)\n" +
+		"\n" +
+		"Articulation points: 1\n" +
+		"└── api: splits its island into 2 (networks: backend, frontend)\n" +
+		"\n" +
+		"Container degree:\n" +
+		"├── api: 2\n" +
+		"└── db: 1\n" +
+		"\n" +
+		"Network degree:\n" +
+		"└── backend: 2\n"
+	if buf.String() != want {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestPrintGraphStats_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	PrintGraphStats(&buf, graph.Analyze(nil))
+
+	want := "Islands: 0\n" +
+		"\n" +
+		"Articulation points: 0\n" +
+		"\n" +
+		"Container degree:\n" +
+		"└── (none)\n" +
+		"\n" +
+		"Network degree:\n" +
+		"└── (none)\n"
+	if buf.String() != want {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
@@ -0,0 +1,111 @@
+// Package output provides tree-style formatters for Docker network topology visualization.
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/ingress"
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// PrintIngress prints the inbound traffic chain of a host: each reverse
+// proxy with the host ports it publishes and the routes it serves, routes
+// no running proxy serves, and containers publishing ports directly.
+// Routes whose backend the proxy cannot reach are flagged with ERROR.
+//
+// Example output:
+//
+//	Proxy: traefik (traefik)
+//	├── listens on: 0.0.0.0:80->80/tcp, 0.0.0.0:443->443/tcp
+//	├── Host(`shop.example.com`) -> shop-web-1:8080 (via frontend)
+//	└── Host(`api.example.com`) -> api:3000 ERROR: no network shared with traefik
+//
+//	Published directly:
+//	└── postgres: 0.0.0.0:5432->5432/tcp
+func PrintIngress(w io.Writer, report *ingress.Report) {
+	cw := NewColorWriter(w)
+
+	if len(report.Proxies) == 0 && len(report.Unserved) == 0 && len(report.Direct) == 0 {
+		fmt.Fprintln(w, "No published ports or proxy routes")
+		return
+	}
+
+	for _, p := range report.Proxies {
+		fmt.Fprintf(w, "%s %s (%s)\n", cw.Label("Proxy:"), cw.Container(p.Name), p.Kind)
+
+		prefix := TreeBranch
+		if len(p.Hops) == 0 {
+			prefix = TreeEnd
+		}
+		listen := "(no published ports)"
+		if len(p.Ports) > 0 {
+			listen = formatPorts(p.Ports)
+		}
+		fmt.Fprintf(w, "%s %s %s\n", cw.Tree(prefix), cw.Label("listens on:"), listen)
+
+		for i, hop := range p.Hops {
+			hopPrefix := TreeBranch
+			if i == len(p.Hops)-1 {
+				hopPrefix = TreeEnd
+			}
+			fmt.Fprintf(w, "%s %s\n", cw.Tree(hopPrefix), formatHop(cw, hop, false))
+		}
+		fmt.Fprintln(w)
+	}
+
+	if len(report.Unserved) > 0 {
+		fmt.Fprintln(w, cw.Label("Unserved routes:"))
+		for i, hop := range report.Unserved {
+			prefix := TreeBranch
+			if i == len(report.Unserved)-1 {
+				prefix = TreeEnd
+			}
+			fmt.Fprintf(w, "%s %s\n", cw.Tree(prefix), formatHop(cw, hop, true))
+		}
+		fmt.Fprintln(w)
+	}
+
+	if len(report.Direct) > 0 {
+		fmt.Fprintln(w, cw.Label("Published directly:"))
+		for i, e := range report.Direct {
+			prefix := TreeBranch
+			if i == len(report.Direct)-1 {
+				prefix = TreeEnd
+			}
+			fmt.Fprintf(w, "%s %s: %s\n", cw.Tree(prefix), cw.Container(e.Container), formatPorts(e.Ports))
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+// formatHop formats a route and, if the proxy cannot reach the backend, why.
+// withKind adds the kind of proxy the route is for.
+func formatHop(cw *ColorWriter, hop ingress.Hop, withKind bool) string {
+	target := cw.Container(hop.Backend)
+	if hop.Port != "" {
+		target += ":" + hop.Port
+	}
+	line := fmt.Sprintf("%s -> %s", hop.Rule, target)
+
+	if withKind {
+		line += fmt.Sprintf(" (%s)", hop.Kind)
+	} else if len(hop.Networks) > 0 {
+		line += fmt.Sprintf(" (via %s)", cw.Network(strings.Join(hop.Networks, ", ")))
+	}
+
+	if !hop.OK() {
+		line += " " + cw.Warning("ERROR: "+hop.Problem)
+	}
+	return line
+}
+
+// formatPorts formats ports like "docker ps", separated by commas.
+func formatPorts(ports []models.PortInfo) string {
+	formatted := make([]string, len(ports))
+	for i, p := range ports {
+		formatted[i] = p.String()
+	}
+	return strings.Join(formatted, ", ")
+}
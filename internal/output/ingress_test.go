@@ -0,0 +1,79 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/ingress"
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+func TestPrintIngress(t *testing.T) {
+	report := &ingress.Report{
+		Proxies: []ingress.Proxy{{
+			Name: "traefik",
+			Kind: ingress.KindTraefik,
+			Ports: []models.PortInfo{
+				{IP: "0.0.0.0", PrivatePort: 80, PublicPort: 80, Type: "tcp"},
+				{IP: "0.0.0.0", PrivatePort: 443, PublicPort: 443, Type: "tcp"},
+			},
+			Hops: []ingress.Hop{
+				{
+					Route:   ingress.Route{Kind: ingress.KindTraefik, Rule: "Host(`api.example.com`)", Backend: "api", Port: "3000"},
+					Problem: "no network shared with traefik",
+				},
+				{
+					Route:    ingress.Route{Kind: ingress.KindTraefik, Rule: "Host(`shop.example.com`)", Backend: "shop", Port: "8080"},
+					Networks: []string{"frontend"},
+				},
+			},
+		}},
+		Unserved: []ingress.Hop{{
+			Route:   ingress.Route{Kind: ingress.KindCaddy, Rule: "blog.example.com", Backend: "blog", Port: "2368"},
+			Problem: "no caddy proxy is running",
+		}},
+		Direct: []ingress.Exposure{{
+			Container: "db",
+			Ports:     []models.PortInfo{{IP: "127.0.0.1", PrivatePort: 5432, PublicPort: 5432, Type: "tcp"}},
+		}},
+	}
+
+	var buf bytes.Buffer
+	PrintIngress(&buf, report)
+
+	want := "Proxy: traefik (traefik)\n" +
+		"├── listens on: 0.0.0.0:80->80/tcp, 0.0.0.0:443->443/tcp\n" +
+		"├── Host(`api.example.com`) -> api:3000 ERROR: no network shared with traefik\n" +
+		"└── Host(`shop.example.com`) -> shop:8080 (via frontend)\n" +
+		"\n" +
+		"Unserved routes:\n" +
+		"└── blog.example.com -> blog:2368 (caddy) ERROR: no caddy proxy is running\n" +
+		"\n" +
+		"Published directly:\n" +
+		"└── db: 127.0.0.1:5432->5432/tcp\n" +
+		"\n"
+	if buf.String() != want {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestPrintIngress_ProxyWithoutPortsOrRoutes(t *testing.T) {
+	var buf bytes.Buffer
+	PrintIngress(&buf, &ingress.Report{Proxies: []ingress.Proxy{{Name: "caddy", Kind: ingress.KindCaddy}}})
+
+	want := "Proxy: caddy (caddy)\n" +
+		"└── listens on: (no published ports)\n" +
+		"\n"
+	if buf.String() != want {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestPrintIngress_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	PrintIngress(&buf, &ingress.Report{})
+
+	if buf.String() != "No published ports or proxy routes\n" {
+		t.Errorf("unexpected output: %q", buf.String())
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"io"
 	"sort"
 
+	"git.o.ocom.com.au/go/docker-network-viz/internal/ipam"
 	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
 )
 
@@ -14,11 +15,13 @@ import (
 //
 // The output format shows the network name and driver, followed by a tree
 // of containers connected to that network. Each container's aliases are
-// shown as nested items beneath the container name.
+// shown as nested items beneath the container name. When the network has
+// IPAM subnets, the header also shows how much of each address pool is in
+// use and warns when a pool is nearly exhausted.
 //
 // Example output:
 //
-//	Network: bridge (bridge)
+//	Network: bridge (bridge) [172.17.0.0/16 3/65533 used (0%)]
 //	├── web_app
 //	│   ├── alias: web
 //	│   └── alias: web.local
@@ -27,17 +30,30 @@ import (
 //	└── postgres
 //	    └── alias: db
 //
+// Containers sharing another container's network namespace, such as the
+// members of a Podman pod, are grouped under a "Pod:" entry with the
+// namespace owner first:
+//
+//	└── Pod: web (shared network namespace)
+//	    ├── 3f2b1c9d8e7a-infra
+//	    ├── app
+//	    └── log-shipper
+//
 // Parameters:
 //   - w: The io.Writer to write the output to
-//   - net: The NetworkInfo containing the network name and driver
+//   - net: The NetworkInfo containing the network name, driver and address pools
 //   - containers: Slice of ContainerInfo for containers connected to this network
 func PrintNetworkTree(w io.Writer, net models.NetworkInfo, containers []models.ContainerInfo) {
 	cw := NewColorWriter(w)
 
-	fmt.Fprintf(w, "%s %s (%s)\n",
+	header := fmt.Sprintf("%s %s (%s)",
 		cw.Label("Network:"),
 		cw.Network(net.Name),
 		net.Driver)
+	if summary := poolSummary(cw, net, ipam.DefaultWarnThreshold); summary != "" {
+		header += " " + summary
+	}
+	fmt.Fprintln(w, header)
 
 	if len(containers) == 0 {
 		fmt.Fprintf(w, "%s (no containers)\n", cw.Tree(TreeEnd))
@@ -51,28 +67,88 @@ func PrintNetworkTree(w io.Writer, net models.NetworkInfo, containers []models.C
 		return sortedContainers[i].Name < sortedContainers[j].Name
 	})
 
-	for i, c := range sortedContainers {
+	entries := groupPods(sortedContainers)
+	for i, e := range entries {
 		prefix := TreeBranch
 		indent := TreeVertical
-		if i == len(sortedContainers)-1 {
+		if i == len(entries)-1 {
 			prefix = TreeEnd
 			indent = TreeSpace
 		}
 
-		fmt.Fprintf(w, "%s %s\n", cw.Tree(prefix), cw.Container(c.Name))
+		if len(e.members) == 0 {
+			printContainerEntry(w, cw, prefix, indent, net.Name, e.owner)
+			continue
+		}
+
+		pod := e.owner.Pod
+		if pod == "" {
+			pod = e.owner.Name
+		}
+		fmt.Fprintf(w, "%s %s %s (shared network namespace)\n",
+			cw.Tree(prefix), cw.Label("Pod:"), cw.Container(pod))
 
-		// Sort aliases for consistent output
-		sortedAliases := c.SortedAliases()
-		for j, a := range sortedAliases {
-			aliasPrefix := TreeBranch
-			if j == len(sortedAliases)-1 {
-				aliasPrefix = TreeEnd
+		group := append([]models.ContainerInfo{e.owner}, e.members...)
+		for j, c := range group {
+			memberPrefix := TreeBranch
+			memberIndent := TreeVertical
+			if j == len(group)-1 {
+				memberPrefix = TreeEnd
+				memberIndent = TreeSpace
 			}
-			fmt.Fprintf(w, "%s%s %s %s\n",
-				cw.Tree(indent),
-				cw.Tree(aliasPrefix),
-				cw.Label("alias:"),
-				cw.Alias(a))
+			printContainerEntry(w, cw, indent+memberPrefix, indent+memberIndent, net.Name, c)
+		}
+	}
+}
+
+// networkTreeEntry is a top-level entry of a network tree: a container, or a
+// pod made of the namespace owner and the containers sharing its namespace.
+type networkTreeEntry struct {
+	owner   models.ContainerInfo
+	members []models.ContainerInfo
+}
+
+// groupPods groups the sorted containers into tree entries. Containers
+// sharing the network namespace of another listed container are nested under
+// it; the others are listed on their own.
+func groupPods(sorted []models.ContainerInfo) []networkTreeEntry {
+	listed := make(map[string]bool, len(sorted))
+	for _, c := range sorted {
+		listed[c.Name] = true
+	}
+
+	members := make(map[string][]models.ContainerInfo)
+	for _, c := range sorted {
+		if c.NetworkNamespace != "" && listed[c.NetworkNamespace] {
+			members[c.NetworkNamespace] = append(members[c.NetworkNamespace], c)
+		}
+	}
+
+	var entries []networkTreeEntry
+	for _, c := range sorted {
+		if c.NetworkNamespace != "" && listed[c.NetworkNamespace] {
+			continue
+		}
+		entries = append(entries, networkTreeEntry{owner: c, members: members[c.Name]})
+	}
+	return entries
+}
+
+// printContainerEntry prints a container and the aliases it has on the network.
+func printContainerEntry(w io.Writer, cw *ColorWriter, prefix, indent, network string, c models.ContainerInfo) {
+	fmt.Fprintf(w, "%s %s\n", cw.Tree(prefix), cw.Container(c.Name))
+
+	// Only the names registered on this network resolve to the container here
+	sortedAliases := c.NamesOn(network)
+	for j, a := range sortedAliases {
+		aliasPrefix := TreeBranch
+		if j == len(sortedAliases)-1 {
+			aliasPrefix = TreeEnd
 		}
+		fmt.Fprintf(w, "%s%s %s %s\n",
+			cw.Tree(indent),
+			cw.Tree(aliasPrefix),
+			cw.Label("alias:"),
+			cw.Alias(a))
 	}
 }
@@ -249,3 +249,113 @@ func TestPrintNetworkTree_DifferentDriverTypes(t *testing.T) {
 		})
 	}
 }
+
+func TestPrintNetworkTree_PoolUtilizationHeader(t *testing.T) {
+	var buf bytes.Buffer
+	net := models.NetworkInfo{
+		Name:      "small_net",
+		Driver:    "bridge",
+		Subnets:   []models.SubnetInfo{{Subnet: "172.20.0.0/28", Gateway: "172.20.0.1"}},
+		Addresses: []string{"172.20.0.2"},
+	}
+
+	PrintNetworkTree(&buf, net, []models.ContainerInfo{})
+
+	firstLine := strings.SplitN(buf.String(), "\n", 2)[0]
+	expected := "Network: small_net (bridge) [172.20.0.0/28 1/13 used (8%)]"
+	if firstLine != expected {
+		t.Errorf("expected header %q, got %q", expected, firstLine)
+	}
+}
+
+func TestPrintNetworkTree_PoolExhaustionWarning(t *testing.T) {
+	var buf bytes.Buffer
+	net := models.NetworkInfo{
+		Name:      "tiny_net",
+		Driver:    "bridge",
+		Subnets:   []models.SubnetInfo{{Subnet: "10.0.0.0/30", Gateway: "10.0.0.1"}},
+		Addresses: []string{"10.0.0.2"},
+	}
+
+	PrintNetworkTree(&buf, net, []models.ContainerInfo{})
+
+	if !strings.Contains(buf.String(), "WARNING: address pool nearly exhausted") {
+		t.Errorf("expected exhaustion warning in header, got:\n%s", buf.String())
+	}
+}
+
+func TestPrintNetworkTree_GroupsPodMembers(t *testing.T) {
+	var buf bytes.Buffer
+	net := models.NetworkInfo{Name: "shop", Driver: "bridge"}
+	containers := []models.ContainerInfo{
+		{Name: "3f2b1c9d8e7a-infra", Pod: "shop", Networks: []string{"shop"}},
+		{Name: "app", Pod: "shop", NetworkNamespace: "3f2b1c9d8e7a-infra", Networks: []string{"shop"}},
+		{Name: "worker", Networks: []string{"shop"}},
+		{Name: "log", Pod: "shop", NetworkNamespace: "3f2b1c9d8e7a-infra", Networks: []string{"shop"}},
+	}
+
+	PrintNetworkTree(&buf, net, containers)
+
+	expected := "Network: shop (bridge)\n" +
+		"├── Pod: shop (shared network namespace)\n" +
+		"│   ├── 3f2b1c9d8e7a-infra\n" +
+		"│   ├── app\n" +
+		"│   └── log\n" +
+		"└── worker\n"
+
+	if output := buf.String(); output != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, output)
+	}
+}
+
+func TestPrintNetworkTree_SharedNamespaceWithoutPod(t *testing.T) {
+	var buf bytes.Buffer
+	net := models.NetworkInfo{Name: "backend", Driver: "bridge"}
+	containers := []models.ContainerInfo{
+		{Name: "api", Networks: []string{"backend"}},
+		{Name: "sidecar", NetworkNamespace: "api", Networks: []string{"backend"}},
+	}
+
+	PrintNetworkTree(&buf, net, containers)
+
+	if !strings.Contains(buf.String(), "Pod: api (shared network namespace)") {
+		t.Errorf("expected the namespace owner to name the group, got:\n%s", buf.String())
+	}
+}
+
+func TestPrintNetworkTree_MemberWithoutOwnerListedAlone(t *testing.T) {
+	var buf bytes.Buffer
+	net := models.NetworkInfo{Name: "backend", Driver: "bridge"}
+	containers := []models.ContainerInfo{
+		{Name: "sidecar", NetworkNamespace: "api", Networks: []string{"backend"}},
+	}
+
+	PrintNetworkTree(&buf, net, containers)
+
+	expected := "Network: backend (bridge)\n└── sidecar\n"
+	if output := buf.String(); output != expected {
+		t.Errorf("expected:\n%q\ngot:\n%q", expected, output)
+	}
+}
+
+func TestPrintNetworkTree_OnlyAliasesOfTheNetwork(t *testing.T) {
+	var buf bytes.Buffer
+	net := models.NetworkInfo{Name: "backend", Driver: "bridge"}
+	api := models.NewContainerInfo("api")
+	api.AddNetworkAlias("frontend", "public-api")
+	api.AddNetworkAlias("backend", "internal-api")
+	api.AddNetworkDNSName("backend", "api")
+	api.AddNetworkDNSName("backend", "internal-api")
+	api.AddNetworkDNSName("backend", "api.internal")
+
+	PrintNetworkTree(&buf, net, []models.ContainerInfo{*api})
+
+	expected := "Network: backend (bridge)\n" +
+		"└── api\n" +
+		"    ├── alias: api.internal\n" +
+		"    └── alias: internal-api\n"
+
+	if output := buf.String(); output != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, output)
+	}
+}
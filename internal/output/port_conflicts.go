@@ -0,0 +1,64 @@
+// Package output provides tree-style formatters for Docker network topology visualization.
+package output
+
+import (
+	"fmt"
+	"io"
+	"slices"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// PrintPortConflicts prints each host port published by several containers
+// with the conflicting bindings and their containers' states. Stopped
+// containers that will fail to start because a running container holds the
+// port are flagged. Otherwise, as for the services of a Compose file, only
+// the first container to start will get the port.
+//
+// Example output:
+//
+//	Host port 80/tcp
+//	├── legacy-web: 127.0.0.1:80 (exited) WARNING: will fail to start
+//	├── proxy: 0.0.0.0:80 (running)
+//	└── proxy: [::]:80 (running)
+//
+//	Host port 5432/tcp
+//	├── db: 0.0.0.0:5432 (exited)
+//	├── db-old: 0.0.0.0:5432 (exited)
+//	└── only the first to start will get the port
+func PrintPortConflicts(w io.Writer, conflicts []models.PortConflict) {
+	cw := NewColorWriter(w)
+
+	if len(conflicts) == 0 {
+		fmt.Fprintln(w, "No host port conflicts.")
+		return
+	}
+
+	for i, conflict := range conflicts {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintln(w, cw.Label(fmt.Sprintf("Host port %d/%s", conflict.Port, conflict.Type)))
+
+		blocked := conflict.Blocked()
+		lines := len(conflict.Bindings)
+		if len(blocked) == 0 {
+			lines++
+		}
+		for j, b := range conflict.Bindings {
+			prefix, _ := treePrefix(j, lines)
+			state := b.State
+			if state == "" {
+				state = "unknown"
+			}
+			line := fmt.Sprintf("%s %s: %s (%s)", cw.Tree(prefix), cw.Container(b.Container), b.Address(), state)
+			if slices.Contains(blocked, b.Container) {
+				line += " " + cw.Warning("WARNING: will fail to start")
+			}
+			fmt.Fprintln(w, line)
+		}
+		if len(blocked) == 0 {
+			fmt.Fprintf(w, "%s only the first to start will get the port\n", cw.Tree(TreeEnd))
+		}
+	}
+}
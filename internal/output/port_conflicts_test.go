@@ -0,0 +1,45 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+func TestPrintPortConflicts(t *testing.T) {
+	conflicts := []models.PortConflict{
+		{Port: 80, Type: "tcp", Bindings: []models.HostBinding{
+			{Container: "legacy-web", State: "exited", IP: "127.0.0.1", Port: 80, Type: "tcp"},
+			{Container: "proxy", State: "running", IP: "0.0.0.0", Port: 80, Type: "tcp"},
+		}},
+		{Port: 5432, Type: "tcp", Bindings: []models.HostBinding{
+			{Container: "db", State: "exited", IP: "0.0.0.0", Port: 5432, Type: "tcp"},
+			{Container: "db-old", State: "created", IP: "", Port: 5432, Type: "tcp"},
+		}},
+	}
+
+	var buf bytes.Buffer
+	PrintPortConflicts(&buf, conflicts)
+
+	want := "Host port 80/tcp\n" +
+		"├── legacy-web: 127.0.0.1:80 (exited) WARNING: will fail to start\n" +
+		"└── proxy: 0.0.0.0:80 (running)\n" +
+		"\n" +
+		"Host port 5432/tcp\n" +
+		"├── db: 0.0.0.0:5432 (exited)\n" +
+		"├── db-old: 0.0.0.0:5432 (created)\n" +
+		"└── only the first to start will get the port\n"
+	if buf.String() != want {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestPrintPortConflicts_None(t *testing.T) {
+	var buf bytes.Buffer
+	PrintPortConflicts(&buf, nil)
+
+	if want := "No host port conflicts.\n"; buf.String() != want {
+		t.Errorf("unexpected output: %q, want %q", buf.String(), want)
+	}
+}
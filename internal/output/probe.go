@@ -0,0 +1,95 @@
+// Package output provides tree-style formatters for Docker network topology visualization.
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/probe"
+)
+
+// PrintProbeReport prints the results of probing the connections the
+// reachability model expects, grouped by source container: for every peer,
+// the DNS lookup and TCP connect that were expected to succeed and what
+// actually happened. Containers that could not be probed and a count of the
+// checks by outcome follow.
+//
+// Example output:
+//
+//	Container: api
+//	├── db via backend
+//	│   ├── dns db: expected resolves, actual 172.18.0.3
+//	│   └── tcp db:5432: expected connects, actual connects
+//	└── web via frontend
+//	    ├── dns web: expected resolves, actual ERROR: not found
+//	    └── tcp web:80: expected connects, actual skipped (no nc or bash)
+//
+//	Skipped containers:
+//	└── db: no shell
+//
+//	Checks: 2 ok, 1 failed, 1 skipped
+func PrintProbeReport(w io.Writer, report *probe.Report) {
+	cw := NewColorWriter(w)
+	counts := make(map[probe.Status]int)
+
+	for i := 0; i < len(report.Results); {
+		from := report.Results[i].From
+		end := i
+		for end < len(report.Results) && report.Results[end].From == from {
+			end++
+		}
+
+		fmt.Fprintf(w, "%s %s\n", cw.Label("Container:"), cw.Container(from))
+		for j, r := range report.Results[i:end] {
+			prefix, indent := treePrefix(j, end-i)
+			fmt.Fprintf(w, "%s %s via %s\n", cw.Tree(prefix), cw.Container(r.To), cw.Network(r.Network))
+
+			dnsName := r.Name
+			if dnsName == "" {
+				dnsName = r.To
+			}
+			tcpTarget := fmt.Sprintf("%s:%d", r.Host(), r.Port)
+			if r.Port == 0 || r.Host() == "" {
+				tcpTarget = r.To
+			}
+			fmt.Fprintf(w, "%s%s dns %s: expected resolves, actual %s\n",
+				cw.Tree(indent), cw.Tree(TreeBranch), dnsName, describeCheck(cw, r.DNS, "resolves"))
+			fmt.Fprintf(w, "%s%s tcp %s: expected connects, actual %s\n",
+				cw.Tree(indent), cw.Tree(TreeEnd), tcpTarget, describeCheck(cw, r.TCP, "connects"))
+
+			counts[r.DNS.Status]++
+			counts[r.TCP.Status]++
+		}
+		fmt.Fprintln(w)
+		i = end
+	}
+
+	if len(report.Skipped) > 0 {
+		fmt.Fprintln(w, cw.Label("Skipped containers:"))
+		for i, s := range report.Skipped {
+			prefix, _ := treePrefix(i, len(report.Skipped))
+			fmt.Fprintf(w, "%s %s: %s\n", cw.Tree(prefix), cw.Container(s.Container), s.Reason)
+		}
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintf(w, "%s %d ok, %d failed, %d skipped\n", cw.Label("Checks:"),
+		counts[probe.StatusOK], counts[probe.StatusFailed], counts[probe.StatusSkipped])
+}
+
+// describeCheck describes the actual outcome of a check: the detail of a
+// successful check, or success when it has none, the failure or the reason
+// it was skipped.
+func describeCheck(cw *ColorWriter, check probe.Check, success string) string {
+	switch check.Status {
+	case probe.StatusOK:
+		if check.Detail != "" {
+			return check.Detail
+		}
+		return success
+	case probe.StatusFailed:
+		return cw.Warning("ERROR: " + check.Detail)
+	default:
+		return fmt.Sprintf("skipped (%s)", check.Detail)
+	}
+}
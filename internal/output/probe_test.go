@@ -0,0 +1,64 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/probe"
+)
+
+func TestPrintProbeReport(t *testing.T) {
+	report := &probe.Report{
+		Results: []probe.Result{
+			{
+				Target: probe.Target{From: "api", To: "db", Network: "backend", Name: "db", Port: 5432},
+				DNS:    probe.Check{Status: probe.StatusOK, Detail: "172.18.0.3"},
+				TCP:    probe.Check{Status: probe.StatusOK},
+			},
+			{
+				Target: probe.Target{From: "api", To: "web", Network: "frontend", Name: "web", Port: 80},
+				DNS:    probe.Check{Status: probe.StatusFailed, Detail: "not found"},
+				TCP:    probe.Check{Status: probe.StatusSkipped, Detail: "no nc or bash"},
+			},
+			{
+				Target: probe.Target{From: "worker", To: "legacy", Network: "bridge", IP: "172.17.0.3"},
+				DNS:    probe.Check{Status: probe.StatusSkipped, Detail: "no DNS name on bridge"},
+				TCP:    probe.Check{Status: probe.StatusSkipped, Detail: "no exposed TCP port"},
+			},
+		},
+		Skipped: []probe.SkippedContainer{{Container: "db", Reason: "no shell"}},
+	}
+
+	var buf bytes.Buffer
+	PrintProbeReport(&buf, report)
+
+	want := "Container: api\n" +
+		"├── db via backend\n" +
+		"│   ├── dns db: expected resolves, actual 172.18.0.3\n" +
+		"│   └── tcp db:5432: expected connects, actual connects\n" +
+		"└── web via frontend\n" +
+		"    ├── dns web: expected resolves, actual ERROR: not found\n" +
+		"    └── tcp web:80: expected connects, actual skipped (no nc or bash)\n" +
+		"\n" +
+		"Container: worker\n" +
+		"└── legacy via bridge\n" +
+		"    ├── dns legacy: expected resolves, actual skipped (no DNS name on bridge)\n" +
+		"    └── tcp legacy: expected connects, actual skipped (no exposed TCP port)\n" +
+		"\n" +
+		"Skipped containers:\n" +
+		"└── db: no shell\n" +
+		"\n" +
+		"Checks: 2 ok, 1 failed, 3 skipped\n"
+	if buf.String() != want {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestPrintProbeReport_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	PrintProbeReport(&buf, &probe.Report{})
+
+	if want := "Checks: 0 ok, 0 failed, 0 skipped\n"; buf.String() != want {
+		t.Errorf("unexpected output: %q, want %q", buf.String(), want)
+	}
+}
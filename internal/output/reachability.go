@@ -4,7 +4,10 @@
 package output
 
 import (
+	"fmt"
+	"io"
 	"sort"
+	"strings"
 
 	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
 )
@@ -21,6 +24,10 @@ import (
 // Returns a sorted slice of container names that share the same network as the source
 // container, excluding the source container itself. Returns an empty slice if no other
 // containers are found on the network.
+//
+// Sharing a network does not guarantee a connection: on a network with
+// inter-container communication disabled the containers are listed but
+// cannot reach each other. Use CanReach to check.
 func ReachableContainers(self, network string, netMap map[string][]models.ContainerInfo) []string {
 	var result []string
 	for _, c := range netMap[network] {
@@ -31,3 +38,169 @@ func ReachableContainers(self, network string, netMap map[string][]models.Contai
 	sort.Strings(result)
 	return result
 }
+
+// NetworksByName indexes networks by name for the reachability functions.
+func NetworksByName(networks []*models.NetworkInfo) map[string]*models.NetworkInfo {
+	byName := make(map[string]*models.NetworkInfo, len(networks))
+	for _, n := range networks {
+		byName[n.Name] = n
+	}
+	return byName
+}
+
+// CanReach reports whether containers attached to the named network can
+// reach each other through it. Bridge networks created with
+// com.docker.network.bridge.enable_icc=false block traffic between their
+// containers. Networks missing from networks are assumed to allow it.
+func CanReach(network string, networks map[string]*models.NetworkInfo) bool {
+	n, ok := networks[network]
+	if !ok || n == nil {
+		return true
+	}
+	return n.ICCEnabled()
+}
+
+// ReachablePeers returns the containers reachable from the given container
+// through at least one shared network, mapped to the sorted networks they
+// can talk over. Stopped containers hold no endpoints and are skipped, as are
+// networks that disable inter-container communication; networks may be nil
+// to treat every network as open.
+func ReachablePeers(self string, netMap map[string][]models.ContainerInfo, networks map[string]*models.NetworkInfo) map[string][]string {
+	peers := make(map[string][]string)
+	for network, containers := range netMap {
+		if !containsContainer(containers, self) || !CanReach(network, networks) {
+			continue
+		}
+		for _, peer := range containers {
+			if peer.Name != self && peer.IsActive() {
+				peers[peer.Name] = append(peers[peer.Name], network)
+			}
+		}
+	}
+	for _, networks := range peers {
+		sort.Strings(networks)
+	}
+	return peers
+}
+
+// DiffReachability compares two network maps and returns, for every
+// container whose peers changed, the peers it gained and lost. Reachability
+// is symmetric, so each changed pair appears under both containers. The
+// result is sorted by container name. Networks that disable
+// inter-container communication, according to networks, connect no peers.
+func DiffReachability(before, after map[string][]models.ContainerInfo, networks map[string]*models.NetworkInfo) []models.ReachabilityChange {
+	names := make(map[string]bool)
+	for _, netMap := range []map[string][]models.ContainerInfo{before, after} {
+		for _, containers := range netMap {
+			for _, c := range containers {
+				names[c.Name] = true
+			}
+		}
+	}
+
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	var changes []models.ReachabilityChange
+	for _, name := range sorted {
+		change := ContainerReachabilityChange(name, before, after, networks)
+		if !change.IsEmpty() {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// ContainerReachabilityChange returns the peers a single container gains and
+// loses between two network maps. Networks that disable inter-container
+// communication, according to networks, connect no peers.
+func ContainerReachabilityChange(
+	self string,
+	before, after map[string][]models.ContainerInfo,
+	networks map[string]*models.NetworkInfo,
+) models.ReachabilityChange {
+	beforePeers := ReachablePeers(self, before, networks)
+	afterPeers := ReachablePeers(self, after, networks)
+
+	change := models.ReachabilityChange{Container: self}
+	for peer, networks := range afterPeers {
+		if _, ok := beforePeers[peer]; !ok {
+			change.Gained = append(change.Gained, models.PeerChange{Peer: peer, Networks: networks})
+		}
+	}
+	for peer, networks := range beforePeers {
+		if _, ok := afterPeers[peer]; !ok {
+			change.Lost = append(change.Lost, models.PeerChange{Peer: peer, Networks: networks})
+		}
+	}
+
+	sort.Slice(change.Gained, func(i, j int) bool { return change.Gained[i].Peer < change.Gained[j].Peer })
+	sort.Slice(change.Lost, func(i, j int) bool { return change.Lost[i].Peer < change.Lost[j].Peer })
+	return change
+}
+
+// PrintReachabilityChange prints the peers a container gains and loses.
+//
+// Example output:
+//
+//	Container: api
+//	├── newly reachable:
+//	│   └── postgres (via backend_net)
+//	└── no longer reachable:
+//	    └── nginx (via frontend_net)
+func PrintReachabilityChange(w io.Writer, change models.ReachabilityChange) {
+	cw := NewColorWriter(w)
+
+	fmt.Fprintf(w, "%s %s\n", cw.Label("Container:"), cw.Container(change.Container))
+
+	if change.IsEmpty() {
+		fmt.Fprintf(w, "%s (no reachability change)\n", cw.Tree(TreeEnd))
+		return
+	}
+
+	sections := []struct {
+		label string
+		peers []models.PeerChange
+	}{
+		{"newly reachable:", change.Gained},
+		{"no longer reachable:", change.Lost},
+	}
+
+	var shown []int
+	for i, s := range sections {
+		if len(s.peers) > 0 {
+			shown = append(shown, i)
+		}
+	}
+
+	for n, i := range shown {
+		s := sections[i]
+		prefix, indent := TreeBranch, TreeVertical
+		if n == len(shown)-1 {
+			prefix, indent = TreeEnd, TreeSpace
+		}
+
+		fmt.Fprintf(w, "%s %s\n", cw.Tree(prefix), cw.Label(s.label))
+		for j, p := range s.peers {
+			peerPrefix := TreeBranch
+			if j == len(s.peers)-1 {
+				peerPrefix = TreeEnd
+			}
+			fmt.Fprintf(w, "%s%s %s (via %s)\n", cw.Tree(indent), cw.Tree(peerPrefix),
+				cw.Container(p.Peer), cw.Network(strings.Join(p.Networks, ", ")))
+		}
+	}
+}
+
+// containsContainer reports whether a container with the given name is in the list.
+func containsContainer(containers []models.ContainerInfo, name string) bool {
+	for _, c := range containers {
+		if c.Name == name {
+			return true
+		}
+	}
+	return false
+}
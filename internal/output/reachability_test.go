@@ -1,6 +1,7 @@
 package output
 
 import (
+	"bytes"
 	"testing"
 
 	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
@@ -151,3 +152,150 @@ func TestReachableContainers_MultipleNetworks(t *testing.T) {
 		}
 	}
 }
+
+// reachabilityMaps returns network maps before and after connecting api to
+// backend and disconnecting it from frontend.
+func reachabilityMaps() (map[string][]models.ContainerInfo, map[string][]models.ContainerInfo) {
+	before := map[string][]models.ContainerInfo{
+		"frontend": {{Name: "api"}, {Name: "nginx"}},
+		"shared":   {{Name: "api"}, {Name: "cache"}},
+		"backend":  {{Name: "cache"}, {Name: "postgres"}},
+	}
+	after := map[string][]models.ContainerInfo{
+		"frontend": {{Name: "nginx"}},
+		"shared":   {{Name: "api"}, {Name: "cache"}},
+		"backend":  {{Name: "api"}, {Name: "cache"}, {Name: "postgres"}},
+	}
+	return before, after
+}
+
+func TestReachablePeers(t *testing.T) {
+	before, after := reachabilityMaps()
+
+	peers := ReachablePeers("api", before, nil)
+	if len(peers) != 2 || len(peers["nginx"]) != 1 || peers["cache"][0] != "shared" {
+		t.Errorf("unexpected peers before: %v", peers)
+	}
+
+	peers = ReachablePeers("cache", after, nil)
+	if got := peers["api"]; len(got) != 2 || got[0] != "backend" || got[1] != "shared" {
+		t.Errorf("expected api via backend and shared, got %v", got)
+	}
+}
+
+func TestReachablePeers_SkipsStoppedPeers(t *testing.T) {
+	netMap := map[string][]models.ContainerInfo{
+		"backend": {{Name: "api"}, {Name: "postgres", State: "running"}, {Name: "worker", State: "exited"}},
+	}
+
+	peers := ReachablePeers("api", netMap, nil)
+	if len(peers) != 1 || peers["postgres"] == nil {
+		t.Errorf("expected only the running postgres, got %v", peers)
+	}
+}
+
+func TestReachablePeers_ICCDisabled(t *testing.T) {
+	before, _ := reachabilityMaps()
+	networks := NetworksByName([]*models.NetworkInfo{
+		{Name: "frontend", Driver: "bridge", Options: map[string]string{models.BridgeICCOption: "false"}},
+		{Name: "shared", Driver: "bridge", Internal: true},
+	})
+
+	peers := ReachablePeers("api", before, networks)
+	if len(peers) != 1 || peers["cache"][0] != "shared" {
+		t.Errorf("expected only cache via shared, got %v", peers)
+	}
+}
+
+func TestCanReach(t *testing.T) {
+	networks := NetworksByName([]*models.NetworkInfo{
+		{Name: "isolated", Driver: "bridge", Options: map[string]string{models.BridgeICCOption: "false"}},
+		{Name: "internal", Driver: "bridge", Internal: true},
+	})
+
+	if CanReach("isolated", networks) {
+		t.Error("expected no reachability on a network with ICC disabled")
+	}
+	if !CanReach("internal", networks) {
+		t.Error("containers on an internal network should reach each other")
+	}
+	if !CanReach("unknown", networks) || !CanReach("isolated", nil) {
+		t.Error("unknown networks should be treated as open")
+	}
+}
+
+func TestContainerReachabilityChange(t *testing.T) {
+	before, after := reachabilityMaps()
+
+	change := ContainerReachabilityChange("api", before, after, nil)
+
+	if len(change.Gained) != 1 || change.Gained[0].Peer != "postgres" || change.Gained[0].Networks[0] != "backend" {
+		t.Errorf("unexpected gained peers: %+v", change.Gained)
+	}
+	if len(change.Lost) != 1 || change.Lost[0].Peer != "nginx" || change.Lost[0].Networks[0] != "frontend" {
+		t.Errorf("unexpected lost peers: %+v", change.Lost)
+	}
+
+	// cache stays reachable through the shared network.
+	for _, p := range append(change.Gained, change.Lost...) {
+		if p.Peer == "cache" {
+			t.Error("cache should not be reported as changed")
+		}
+	}
+}
+
+func TestDiffReachability(t *testing.T) {
+	before, after := reachabilityMaps()
+
+	changes := DiffReachability(before, after, nil)
+
+	var names []string
+	for _, c := range changes {
+		names = append(names, c.Container)
+	}
+	if len(names) != 3 || names[0] != "api" || names[1] != "nginx" || names[2] != "postgres" {
+		t.Errorf("expected changes for api, nginx and postgres, got %v", names)
+	}
+
+	if changes := DiffReachability(before, before, nil); len(changes) != 0 {
+		t.Errorf("expected no changes, got %+v", changes)
+	}
+}
+
+func TestDiffReachability_ICCDisabled(t *testing.T) {
+	before, after := reachabilityMaps()
+	networks := NetworksByName([]*models.NetworkInfo{
+		{Name: "backend", Driver: "bridge", Options: map[string]string{models.BridgeICCOption: "false"}},
+	})
+
+	// Joining backend gains api nothing, as its containers cannot talk.
+	change := ContainerReachabilityChange("api", before, after, networks)
+	if len(change.Gained) != 0 {
+		t.Errorf("expected no gained peers, got %+v", change.Gained)
+	}
+	if len(change.Lost) != 1 || change.Lost[0].Peer != "nginx" {
+		t.Errorf("expected nginx lost, got %+v", change.Lost)
+	}
+}
+
+func TestPrintReachabilityChange(t *testing.T) {
+	before, after := reachabilityMaps()
+
+	buf := new(bytes.Buffer)
+	PrintReachabilityChange(buf, ContainerReachabilityChange("api", before, after, nil))
+
+	want := "Container: api\n" +
+		"├── newly reachable:\n" +
+		"│   └── postgres (via backend)\n" +
+		"└── no longer reachable:\n" +
+		"    └── nginx (via frontend)\n"
+	if buf.String() != want {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	PrintReachabilityChange(buf, models.ReachabilityChange{Container: "api"})
+	if buf.String() != "Container: api\n└── (no reachability change)\n" {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,70 @@
+// Package output provides tree-style formatters for Docker network topology visualization.
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/segment"
+)
+
+// PrintSegmentationScore prints a segmentation score with the penalty and
+// findings of every category.
+//
+// Example output:
+//
+//	Score: 63/100
+//	├── default-bridge: -5 (containers on the default bridge network)
+//	│   └── legacy is on bridge
+//	├── broad-network: 0 (overly broad shared networks)
+//	├── database-exposure: -30 (databases sharing a network with public-facing services)
+//	│   └── db (PostgreSQL) shares shop_default with web
+//	└── multi-homed: -2 (multi-homed containers)
+//	    └── api joins shop_backend, shop_default
+func PrintSegmentationScore(w io.Writer, report *segment.Report) {
+	cw := NewColorWriter(w)
+
+	fmt.Fprintf(w, "%s %d/%d\n", cw.Label("Score:"), report.Score, segment.MaxScore)
+	for i, cat := range report.Categories {
+		prefix, indent := treePrefix(i, len(report.Categories))
+
+		penalty := "0"
+		if cat.Penalty > 0 {
+			penalty = cw.Warning(fmt.Sprintf("-%d", cat.Penalty))
+		}
+		fmt.Fprintf(w, "%s %s %s (%s)\n", cw.Tree(prefix), cw.Label(cat.Name+":"), penalty, cat.Description)
+
+		for j, finding := range cat.Findings {
+			childPrefix, _ := treePrefix(j, len(cat.Findings))
+			fmt.Fprintf(w, "%s%s %s\n", cw.Tree(indent), cw.Tree(childPrefix), finding)
+		}
+	}
+}
+
+// PrintSegmentationProposal prints the networks proposed to allow only the
+// needed communication, the containers that need none, and how many
+// container pairs can talk now and would under the proposal. The source
+// describes where the needs came from.
+//
+// Example output:
+//
+//	Proposed networks (from environment and depends_on):
+//	├── db_net: api, db
+//	└── api_net: api, web
+//	Unconnected: legacy
+//	Reachable pairs: 6 now, 2 proposed
+func PrintSegmentationProposal(w io.Writer, proposal *segment.Proposal, source string) {
+	cw := NewColorWriter(w)
+
+	fmt.Fprintln(w, cw.Label(fmt.Sprintf("Proposed networks (from %s):", source)))
+	if len(proposal.Networks) == 0 {
+		fmt.Fprintf(w, "%s (none)\n", cw.Tree(TreeEnd))
+	}
+	for i, net := range proposal.Networks {
+		prefix, _ := treePrefix(i, len(proposal.Networks))
+		fmt.Fprintf(w, "%s %s: %s\n", cw.Tree(prefix), cw.Network(net.Name), cw.Container(joinOrNone(net.Containers)))
+	}
+
+	fmt.Fprintf(w, "%s %s\n", cw.Label("Unconnected:"), cw.Container(joinOrNone(proposal.Unconnected)))
+	fmt.Fprintf(w, "%s %d now, %d proposed\n", cw.Label("Reachable pairs:"), proposal.CurrentPairs, proposal.ProposedPairs)
+}
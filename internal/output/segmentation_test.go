@@ -0,0 +1,72 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/segment"
+)
+
+func TestPrintSegmentationScore(t *testing.T) {
+	report := &segment.Report{
+		Score: 88,
+		Categories: []segment.CategoryResult{
+			{Name: "default-bridge", Description: "containers on the default bridge network",
+				Penalty: 10, Findings: []string{"a is on bridge", "b is on bridge"}},
+			{Name: "multi-homed", Description: "multi-homed containers",
+				Penalty: 2, Findings: []string{"api joins back, front"}},
+			{Name: "broad-network", Description: "overly broad shared networks"},
+		},
+	}
+
+	var buf bytes.Buffer
+	PrintSegmentationScore(&buf, report)
+
+	want := "Score: 88/100\n" +
+		"├── default-bridge: -10 (containers on the default bridge network)\n" +
+		"│   ├── a is on bridge\n" +
+		"│   └── b is on bridge\n" +
+		"├── multi-homed: -2 (multi-homed containers)\n" +
+		"│   └── api joins back, front\n" +
+		"└── broad-network: 0 (overly broad shared networks)\n"
+	if buf.String() != want {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestPrintSegmentationProposal(t *testing.T) {
+	proposal := &segment.Proposal{
+		Networks: []segment.ProposedNetwork{
+			{Name: "db_net", Containers: []string{"api", "db"}},
+			{Name: "api_net", Containers: []string{"api", "web"}},
+		},
+		Unconnected:   []string{"legacy"},
+		CurrentPairs:  6,
+		ProposedPairs: 2,
+	}
+
+	var buf bytes.Buffer
+	PrintSegmentationProposal(&buf, proposal, "policy allow.yml")
+
+	want := "Proposed networks (from policy allow.yml):\n" +
+		"├── db_net: api, db\n" +
+		"└── api_net: api, web\n" +
+		"Unconnected: legacy\n" +
+		"Reachable pairs: 6 now, 2 proposed\n"
+	if buf.String() != want {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestPrintSegmentationProposal_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	PrintSegmentationProposal(&buf, &segment.Proposal{}, "environment and depends_on")
+
+	want := "Proposed networks (from environment and depends_on):\n" +
+		"└── (none)\n" +
+		"Unconnected: (none)\n" +
+		"Reachable pairs: 0 now, 0 proposed\n"
+	if buf.String() != want {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
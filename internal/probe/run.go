@@ -0,0 +1,303 @@
+// Package probe checks that containers which should be able to talk to each
+// other actually can, by running a DNS lookup and a TCP connect inside the
+// source container.
+// This file runs the probes.
+package probe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Defaults for Options.
+const (
+	// DefaultConcurrency is the default number of commands run at once.
+	DefaultConcurrency = 4
+
+	// DefaultTimeout is the default time a single probe may take.
+	DefaultTimeout = 2 * time.Second
+)
+
+// Exit codes of a command that could not be run: not executable, or not
+// found.
+const (
+	exitNotExecutable = 126
+	exitNotFound      = 127
+)
+
+// Tools a probe can use inside a container, in order of preference.
+const (
+	toolGetent   = "getent"
+	toolNslookup = "nslookup"
+	toolNc       = "nc"
+	toolBash     = "bash"
+	toolTimeout  = "timeout"
+)
+
+// detectScript prints the name of every probe tool available in a container.
+const detectScript = `for t in getent nslookup nc bash timeout; do command -v "$t" >/dev/null 2>&1 && echo "$t"; done; exit 0`
+
+// Status is the outcome of a check.
+type Status string
+
+// Check outcomes.
+const (
+	// StatusOK means the check behaved as the reachability model expects.
+	StatusOK Status = "ok"
+
+	// StatusFailed means the check did not behave as expected.
+	StatusFailed Status = "failed"
+
+	// StatusSkipped means the check could not be run.
+	StatusSkipped Status = "skipped"
+)
+
+// Executor runs a command inside a container and returns its exit code and
+// output. docker.Client implements it.
+type Executor interface {
+	ExecCommand(ctx context.Context, container string, cmd []string) (int, string, error)
+}
+
+// Options bounds the probes.
+type Options struct {
+	// Concurrency is the number of commands run at once. Zero uses
+	// DefaultConcurrency.
+	Concurrency int
+
+	// Timeout is the time a single command may take. Zero uses
+	// DefaultTimeout.
+	Timeout time.Duration
+}
+
+// Check is the result of one check of a target.
+type Check struct {
+	// Status is the outcome.
+	Status Status
+
+	// Detail is the resolved address, the reason for a failure or the reason
+	// the check was skipped.
+	Detail string
+}
+
+// Result is the outcome of probing a target.
+type Result struct {
+	Target
+
+	// DNS is the lookup of the target's name.
+	DNS Check
+
+	// TCP is the connection to the target's port.
+	TCP Check
+}
+
+// Failed reports whether any check of the result failed.
+func (r Result) Failed() bool {
+	return r.DNS.Status == StatusFailed || r.TCP.Status == StatusFailed
+}
+
+// SkippedContainer is a source container whose probes were not run.
+type SkippedContainer struct {
+	// Container is the container name.
+	Container string
+
+	// Reason explains why, such as the missing tools.
+	Reason string
+}
+
+// Report holds the results of a probe run.
+type Report struct {
+	// Results are the probe results, in the order of the targets.
+	Results []Result
+
+	// Skipped are the source containers lacking the tools to probe, sorted
+	// by name.
+	Skipped []SkippedContainer
+}
+
+// Source is a container to probe from.
+type Source struct {
+	// Name is the container name, used in results.
+	Name string
+
+	// ID is the container ID the commands run in.
+	ID string
+
+	// Targets are the probes to run from the container.
+	Targets []Target
+}
+
+// tools are the probe tools found in a container.
+type tools map[string]bool
+
+// Run probes every target of every source. It first looks for the tools it
+// needs in each source container: getent or nslookup for DNS lookups, and
+// nc or bash with timeout for TCP connects. Containers without a shell or
+// without any of them are skipped; a check whose tool is missing is reported
+// as skipped. Every command is bounded by the timeout, and at most the given
+// number run at once. A command that times out is abandoned rather than
+// killed, so it may keep running in its container until it ends by itself;
+// TCP connects are only run with tools that enforce the timeout themselves.
+func Run(ctx context.Context, exec Executor, sources []Source, opts Options) *Report {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = DefaultConcurrency
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+
+	found := make([]tools, len(sources))
+	reasons := make([]string, len(sources))
+	forEach(len(sources), opts.Concurrency, func(i int) {
+		found[i], reasons[i] = detect(ctx, exec, sources[i].ID, opts.Timeout)
+	})
+
+	type job struct {
+		source int
+		target Target
+	}
+	var jobs []job
+	report := &Report{}
+	for i, src := range sources {
+		if reasons[i] != "" {
+			report.Skipped = append(report.Skipped, SkippedContainer{Container: src.Name, Reason: reasons[i]})
+			continue
+		}
+		for _, t := range src.Targets {
+			jobs = append(jobs, job{i, t})
+		}
+	}
+
+	report.Results = make([]Result, len(jobs))
+	forEach(len(jobs), opts.Concurrency, func(i int) {
+		j := jobs[i]
+		id, have := sources[j.source].ID, found[j.source]
+		report.Results[i] = Result{
+			Target: j.target,
+			DNS:    lookup(ctx, exec, id, have, j.target, opts.Timeout),
+			TCP:    connect(ctx, exec, id, have, j.target, opts.Timeout),
+		}
+	})
+
+	return report
+}
+
+// detect finds the probe tools in a container, or returns the reason the
+// container cannot be probed.
+func detect(ctx context.Context, exec Executor, id string, timeout time.Duration) (tools, string) {
+	code, out, err := run(ctx, exec, id, []string{"sh", "-c", detectScript}, timeout)
+	switch {
+	case err != nil:
+		return nil, fmt.Sprintf("cannot run commands: %v", err)
+	case code == exitNotExecutable || code == exitNotFound:
+		return nil, "no shell"
+	case code != 0:
+		return nil, fmt.Sprintf("tool detection exited with %d", code)
+	}
+
+	found := make(tools)
+	for _, line := range strings.Fields(out) {
+		found[line] = true
+	}
+	if !found[toolGetent] && !found[toolNslookup] && !found[toolNc] && !found[toolBash] {
+		return nil, "no getent, nslookup, nc or bash"
+	}
+	return found, ""
+}
+
+// lookup resolves the target's name in the container.
+func lookup(ctx context.Context, exec Executor, id string, have tools, t Target, timeout time.Duration) Check {
+	var cmd []string
+	switch {
+	case t.Name == "":
+		return Check{Status: StatusSkipped, Detail: "no DNS name on " + t.Network}
+	case have[toolGetent]:
+		cmd = []string{toolGetent, "hosts", t.Name}
+	case have[toolNslookup]:
+		cmd = []string{toolNslookup, t.Name}
+	default:
+		return Check{Status: StatusSkipped, Detail: "no getent or nslookup"}
+	}
+
+	code, out, err := run(ctx, exec, id, cmd, timeout)
+	switch {
+	case err != nil:
+		return Check{Status: StatusFailed, Detail: err.Error()}
+	case code != 0:
+		return Check{Status: StatusFailed, Detail: "not found"}
+	case cmd[0] == toolGetent:
+		// getent prints "address name [aliases]".
+		if fields := strings.Fields(out); len(fields) > 0 {
+			return Check{Status: StatusOK, Detail: fields[0]}
+		}
+	}
+	return Check{Status: StatusOK}
+}
+
+// connect opens a TCP connection to the target's port from the container.
+func connect(ctx context.Context, exec Executor, id string, have tools, t Target, timeout time.Duration) Check {
+	host, port := t.Host(), strconv.Itoa(int(t.Port))
+	seconds := strconv.Itoa(max(int(timeout.Round(time.Second)/time.Second), 1))
+
+	var cmd []string
+	switch {
+	case t.Port == 0:
+		return Check{Status: StatusSkipped, Detail: "no exposed TCP port"}
+	case host == "":
+		return Check{Status: StatusSkipped, Detail: "no DNS name or address"}
+	case have[toolNc]:
+		cmd = []string{toolNc, "-z", "-w", seconds, host, port}
+	case have[toolBash] && have[toolTimeout]:
+		// The host and port are passed as arguments, not interpolated. An
+		// abandoned exec is not killed, so without timeout a connect to a
+		// host that drops packets would linger in the container.
+		cmd = []string{toolTimeout, seconds, toolBash, "-c", `exec 3<>"/dev/tcp/$0/$1"`, host, port}
+	case have[toolBash]:
+		return Check{Status: StatusSkipped, Detail: "no nc, and bash without timeout"}
+	default:
+		return Check{Status: StatusSkipped, Detail: "no nc or bash"}
+	}
+
+	code, _, err := run(ctx, exec, id, cmd, timeout)
+	switch {
+	case err != nil:
+		return Check{Status: StatusFailed, Detail: err.Error()}
+	case code != 0:
+		return Check{Status: StatusFailed, Detail: "connection failed"}
+	default:
+		return Check{Status: StatusOK}
+	}
+}
+
+// run runs a command in a container, bounded by the timeout.
+func run(ctx context.Context, exec Executor, id string, cmd []string, timeout time.Duration) (int, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	code, out, err := exec.ExecCommand(ctx, id, cmd)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return 0, "", fmt.Errorf("timed out after %s", timeout)
+	}
+	return code, out, err
+}
+
+// forEach calls fn for every index below n, running at most concurrency
+// calls at once, and returns when all have returned.
+func forEach(n, concurrency int, fn func(int)) {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,230 @@
+package probe
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeExec answers probe commands from a script per container and records
+// the commands it ran.
+type fakeExec struct {
+	mu       sync.Mutex
+	tools    map[string]string
+	answer   func(container string, cmd []string) (int, string, error)
+	commands map[string][][]string
+	running  int
+	peak     int
+	delay    time.Duration
+}
+
+// ExecCommand implements Executor.
+func (f *fakeExec) ExecCommand(ctx context.Context, container string, cmd []string) (int, string, error) {
+	f.mu.Lock()
+	if f.commands == nil {
+		f.commands = make(map[string][][]string)
+	}
+	f.commands[container] = append(f.commands[container], cmd)
+	f.running++
+	f.peak = max(f.peak, f.running)
+	f.mu.Unlock()
+
+	defer func() {
+		f.mu.Lock()
+		f.running--
+		f.mu.Unlock()
+	}()
+
+	if f.delay > 0 {
+		select {
+		case <-time.After(f.delay):
+		case <-ctx.Done():
+			return 0, "", ctx.Err()
+		}
+	}
+
+	if cmd[0] == "sh" {
+		tools, ok := f.tools[container]
+		if !ok {
+			return 127, "exec: \"sh\": executable file not found in $PATH", nil
+		}
+		return 0, tools, nil
+	}
+	if f.answer != nil {
+		return f.answer(container, cmd)
+	}
+	return 0, "", nil
+}
+
+func TestRun(t *testing.T) {
+	exec := &fakeExec{
+		tools: map[string]string{
+			"api-id": "getent\nnc\n",
+			"worker": "nslookup\nbash\ntimeout\n",
+			"tiny":   "",
+		},
+		answer: func(container string, cmd []string) (int, string, error) {
+			switch {
+			case cmd[0] == "getent" && cmd[2] == "db":
+				return 0, "172.18.0.3      db\n", nil
+			case cmd[0] == "nc" && cmd[4] == "db":
+				return 1, "", nil
+			case cmd[0] == "nslookup" && cmd[1] == "ghost":
+				return 1, "server can't find ghost", nil
+			}
+			return 0, "", nil
+		},
+	}
+	sources := []Source{
+		{Name: "api", ID: "api-id", Targets: []Target{{From: "api", To: "db", Network: "back", Name: "db", Port: 5432}}},
+		{Name: "worker", ID: "worker", Targets: []Target{
+			{From: "worker", To: "ghost", Network: "back", Name: "ghost", Port: 80},
+			{From: "worker", To: "legacy", Network: "bridge", IP: "172.17.0.3", Port: 8080},
+		}},
+		{Name: "tiny", ID: "tiny", Targets: []Target{{From: "tiny", To: "db", Name: "db"}}},
+		{Name: "distroless", ID: "distroless", Targets: []Target{{From: "distroless", To: "db", Name: "db"}}},
+	}
+
+	report := Run(context.Background(), exec, sources, Options{Timeout: 3 * time.Second})
+
+	want := []Result{
+		{
+			Target: sources[0].Targets[0],
+			DNS:    Check{Status: StatusOK, Detail: "172.18.0.3"},
+			TCP:    Check{Status: StatusFailed, Detail: "connection failed"},
+		},
+		{
+			Target: sources[1].Targets[0],
+			DNS:    Check{Status: StatusFailed, Detail: "not found"},
+			TCP:    Check{Status: StatusOK},
+		},
+		{
+			Target: sources[1].Targets[1],
+			DNS:    Check{Status: StatusSkipped, Detail: "no DNS name on bridge"},
+			TCP:    Check{Status: StatusOK},
+		},
+	}
+	if !reflect.DeepEqual(report.Results, want) {
+		t.Errorf("Results =\n%+v\nwant\n%+v", report.Results, want)
+	}
+
+	wantSkipped := []SkippedContainer{
+		{Container: "tiny", Reason: "no getent, nslookup, nc or bash"},
+		{Container: "distroless", Reason: "no shell"},
+	}
+	if !reflect.DeepEqual(report.Skipped, wantSkipped) {
+		t.Errorf("Skipped = %+v, want %+v", report.Skipped, wantSkipped)
+	}
+
+	if !ran(exec.commands["api-id"], []string{"nc", "-z", "-w", "3", "db", "5432"}) {
+		t.Errorf("expected an nc probe in api, got %q", exec.commands["api-id"])
+	}
+	if !ran(exec.commands["api-id"], []string{"getent", "hosts", "db"}) {
+		t.Errorf("expected a getent lookup in api, got %q", exec.commands["api-id"])
+	}
+	if !ran(exec.commands["worker"], []string{"nslookup", "ghost"}) {
+		t.Errorf("expected an nslookup lookup in worker, got %q", exec.commands["worker"])
+	}
+	if !ran(exec.commands["worker"], []string{"timeout", "3", "bash", "-c", `exec 3<>"/dev/tcp/$0/$1"`, "172.17.0.3", "8080"}) {
+		t.Errorf("expected a bash probe by address in worker, got %q", exec.commands["worker"])
+	}
+
+	if !report.Results[0].Failed() || !report.Results[1].Failed() || report.Results[2].Failed() {
+		t.Error("Failed() should report failed checks only")
+	}
+}
+
+// ran reports whether cmd is among the recorded commands.
+func ran(commands [][]string, cmd []string) bool {
+	for _, c := range commands {
+		if reflect.DeepEqual(c, cmd) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestRunBashWithoutTimeout(t *testing.T) {
+	exec := &fakeExec{tools: map[string]string{"api-id": "getent\nbash\n"}}
+	sources := []Source{
+		{Name: "api", ID: "api-id", Targets: []Target{{From: "api", To: "db", Network: "back", Name: "db", Port: 5432}}},
+	}
+
+	report := Run(context.Background(), exec, sources, Options{})
+
+	want := Check{Status: StatusSkipped, Detail: "no nc, and bash without timeout"}
+	if len(report.Results) != 1 || report.Results[0].TCP != want {
+		t.Fatalf("expected the connect to be skipped, got %+v", report.Results)
+	}
+	for _, cmd := range exec.commands["api-id"] {
+		if cmd[0] == "bash" {
+			t.Errorf("expected no bash probe without timeout, got %q", cmd)
+		}
+	}
+}
+
+func TestRunMissingTools(t *testing.T) {
+	exec := &fakeExec{tools: map[string]string{"api": "nc\n"}}
+	sources := []Source{{Name: "api", ID: "api", Targets: []Target{
+		{From: "api", To: "db", Name: "db", Port: 5432},
+		{From: "api", To: "cache", Name: "cache"},
+	}}}
+
+	report := Run(context.Background(), exec, sources, Options{})
+
+	if got := report.Results[0].DNS; got != (Check{Status: StatusSkipped, Detail: "no getent or nslookup"}) {
+		t.Errorf("DNS = %+v", got)
+	}
+	if got := report.Results[0].TCP; got.Status != StatusOK {
+		t.Errorf("TCP = %+v", got)
+	}
+	if got := report.Results[1].TCP; got != (Check{Status: StatusSkipped, Detail: "no exposed TCP port"}) {
+		t.Errorf("TCP without a port = %+v", got)
+	}
+}
+
+func TestRunTimeout(t *testing.T) {
+	exec := &fakeExec{delay: time.Second}
+	sources := []Source{{Name: "api", ID: "api", Targets: []Target{{From: "api", To: "db", Name: "db"}}}}
+
+	report := Run(context.Background(), exec, sources, Options{Timeout: 10 * time.Millisecond})
+
+	if len(report.Skipped) != 1 || !strings.Contains(report.Skipped[0].Reason, "timed out after 10ms") {
+		t.Errorf("expected the container to be skipped after a timeout, got %+v", report.Skipped)
+	}
+}
+
+func TestRunExecError(t *testing.T) {
+	exec := &fakeExec{
+		tools: map[string]string{"api": "getent\n"},
+		answer: func(string, []string) (int, string, error) {
+			return 0, "", errors.New("container is not running")
+		},
+	}
+	sources := []Source{{Name: "api", ID: "api", Targets: []Target{{From: "api", To: "db", Name: "db"}}}}
+
+	report := Run(context.Background(), exec, sources, Options{})
+
+	if got := report.Results[0].DNS; got != (Check{Status: StatusFailed, Detail: "container is not running"}) {
+		t.Errorf("DNS = %+v", got)
+	}
+}
+
+func TestRunConcurrency(t *testing.T) {
+	exec := &fakeExec{tools: map[string]string{}, delay: 5 * time.Millisecond}
+	var sources []Source
+	for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		exec.tools[name] = "getent\nnc\n"
+		sources = append(sources, Source{Name: name, ID: name, Targets: []Target{{From: name, To: "db", Name: "db", Port: 80}}})
+	}
+
+	Run(context.Background(), exec, sources, Options{Concurrency: 2})
+
+	if exec.peak > 2 {
+		t.Errorf("ran %d commands at once, want at most 2", exec.peak)
+	}
+}
@@ -0,0 +1,157 @@
+// Package probe checks that containers which should be able to talk to each
+// other actually can, by running a DNS lookup and a TCP connect inside the
+// source container.
+package probe
+
+import (
+	"maps"
+	"slices"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// Target is a connection the reachability model expects to work: from a
+// container to a peer on a shared network.
+type Target struct {
+	// From is the name of the container the probe runs in.
+	From string
+
+	// To is the name of the peer container.
+	To string
+
+	// Network is the shared network the probe is expected to use.
+	Network string
+
+	// Name is the DNS name of the peer to look up from the source container;
+	// empty when no name resolves on Network, as on the default bridge.
+	Name string
+
+	// IP is the peer's address on Network; empty when unknown.
+	IP string
+
+	// Port is the peer's lowest exposed TCP port; zero when it exposes none.
+	Port uint16
+}
+
+// Host returns the host a TCP probe connects to: the DNS name, or the
+// address when there is none.
+func (t Target) Host() string {
+	if t.Name != "" {
+		return t.Name
+	}
+	return t.IP
+}
+
+// namePreference orders DNS name sources: a peer is looked up the way other
+// containers usually address it, by its Compose service name or an alias,
+// before its container name or a link.
+var namePreference = map[string]int{
+	models.DNSSourceService: 0,
+	models.DNSSourceAlias:   1,
+	models.DNSSourceName:    2,
+	models.DNSSourceLink:    3,
+}
+
+// ambiguousPenalty ranks names that resolve to several containers, such as
+// the service name of a scaled service, after every unambiguous name, since
+// a probe of them may reach another replica.
+const ambiguousPenalty = 10
+
+// unprobedNetworks are the networks that do not connect containers the way
+// the probes test: the host's own stack and no network at all.
+var unprobedNetworks = map[string]bool{
+	"host": true,
+	"none": true,
+}
+
+// Plan returns the probes to run from a container: one for every running
+// peer it can reach, over the first shared network by name. peers maps each
+// reachable peer to the sorted networks they share, as returned by
+// output.ReachablePeers, and dns is the source container's DNS view. The
+// host and none networks are not probed. Targets are sorted by peer name.
+func Plan(from string, peers map[string][]string, dns *models.ContainerDNS, containers map[string]*models.ContainerInfo) []Target {
+	var targets []Target
+	for _, peer := range slices.Sorted(maps.Keys(peers)) {
+		c := containers[peer]
+		if c == nil || !c.IsActive() {
+			continue
+		}
+
+		network := ""
+		for _, n := range peers[peer] {
+			if !unprobedNetworks[n] {
+				network = n
+				break
+			}
+		}
+		if network == "" {
+			continue
+		}
+
+		name, ip := lookupName(dns, network, peer)
+		targets = append(targets, Target{
+			From:    from,
+			To:      peer,
+			Network: network,
+			Name:    name,
+			IP:      ip,
+			Port:    lowestTCPPort(c),
+		})
+	}
+	return targets
+}
+
+// lookupName finds the preferred DNS name for a peer on a network of the
+// source's DNS view, and the peer's address there.
+func lookupName(dns *models.ContainerDNS, network, peer string) (string, string) {
+	if dns == nil {
+		return "", ""
+	}
+
+	var name, ip string
+	best := -1
+	for _, nd := range dns.Networks {
+		if nd.Network != network {
+			continue
+		}
+		for _, rec := range nd.Records {
+			for _, target := range rec.Targets {
+				if target.Container != peer {
+					continue
+				}
+				if ip == "" && len(target.IPs) > 0 {
+					ip = target.IPs[0]
+				}
+				if rank := recordRank(rec); best < 0 || rank < best {
+					name, best = rec.Name, rank
+				}
+			}
+		}
+	}
+	return name, ip
+}
+
+// recordRank returns the preference of a DNS record; lower is better.
+func recordRank(rec models.DNSRecord) int {
+	rank := len(namePreference)
+	for _, source := range rec.Sources {
+		if r, ok := namePreference[source]; ok && r < rank {
+			rank = r
+		}
+	}
+	if rec.IsAmbiguous() {
+		rank += ambiguousPenalty
+	}
+	return rank
+}
+
+// lowestTCPPort returns a container's lowest exposed TCP port, or zero.
+func lowestTCPPort(c *models.ContainerInfo) uint16 {
+	var lowest uint16
+	for _, p := range c.Ports {
+		if p.Type == "tcp" && p.PrivatePort != 0 && (lowest == 0 || p.PrivatePort < lowest) {
+			lowest = p.PrivatePort
+		}
+	}
+	return lowest
+}
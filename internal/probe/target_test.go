@@ -0,0 +1,90 @@
+package probe
+
+import (
+	"reflect"
+	"testing"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// testDNS returns the DNS view of api: db answers to its service name and
+// container name on backend, web to an alias and its name on frontend, and
+// the two cache replicas share their service name.
+func testDNS() *models.ContainerDNS {
+	target := func(name, ip string) []models.DNSTarget {
+		return []models.DNSTarget{{Container: name, IPs: []string{ip}}}
+	}
+	return &models.ContainerDNS{
+		Container: "api",
+		Networks: []models.NetworkDNS{
+			{Network: "backend", EmbeddedDNS: true, Records: []models.DNSRecord{
+				{Name: "cache", Sources: []string{models.DNSSourceService}, Targets: []models.DNSTarget{
+					{Container: "cache-1", IPs: []string{"172.18.0.5"}},
+					{Container: "cache-2", IPs: []string{"172.18.0.6"}},
+				}},
+				{Name: "cache-1", Sources: []string{models.DNSSourceName}, Targets: target("cache-1", "172.18.0.5")},
+				{Name: "db", Sources: []string{models.DNSSourceService}, Targets: target("shop-db-1", "172.18.0.3")},
+				{Name: "shop-db-1", Sources: []string{models.DNSSourceName}, Targets: target("shop-db-1", "172.18.0.3")},
+			}},
+			{Network: "frontend", EmbeddedDNS: true, Records: []models.DNSRecord{
+				{Name: "web", Sources: []string{models.DNSSourceName}, Targets: target("web", "172.19.0.2")},
+				{Name: "www", Sources: []string{models.DNSSourceAlias}, Targets: target("web", "172.19.0.2")},
+			}},
+		},
+	}
+}
+
+func TestPlan(t *testing.T) {
+	containers := map[string]*models.ContainerInfo{
+		"api":       {Name: "api", State: "running"},
+		"shop-db-1": {Name: "shop-db-1", State: "running", Ports: []models.PortInfo{{PrivatePort: 5432, Type: "tcp"}}},
+		"web": {Name: "web", State: "running", Ports: []models.PortInfo{
+			{PrivatePort: 443, Type: "tcp"}, {PrivatePort: 80, Type: "tcp"}, {PrivatePort: 53, Type: "udp"},
+		}},
+		"cache-1": {Name: "cache-1", State: "running"},
+		"old":     {Name: "old", State: "exited"},
+		"legacy":  {Name: "legacy", State: "running"},
+		"agent":   {Name: "agent", State: "running"},
+	}
+	peers := map[string][]string{
+		"shop-db-1": {"backend"},
+		"web":       {"frontend"},
+		"cache-1":   {"backend"},
+		"old":       {"backend"},
+		"legacy":    {"bridge"},
+		"agent":     {"host", "none"},
+	}
+
+	targets := Plan("api", peers, testDNS(), containers)
+
+	want := []Target{
+		{From: "api", To: "cache-1", Network: "backend", Name: "cache-1", IP: "172.18.0.5"},
+		{From: "api", To: "legacy", Network: "bridge"},
+		{From: "api", To: "shop-db-1", Network: "backend", Name: "db", IP: "172.18.0.3", Port: 5432},
+		{From: "api", To: "web", Network: "frontend", Name: "www", IP: "172.19.0.2", Port: 80},
+	}
+	if !reflect.DeepEqual(targets, want) {
+		t.Errorf("Plan =\n%+v\nwant\n%+v", targets, want)
+	}
+}
+
+func TestPlanWithoutDNS(t *testing.T) {
+	containers := map[string]*models.ContainerInfo{
+		"db": {Name: "db", State: "running"},
+	}
+
+	targets := Plan("api", map[string][]string{"db": {"backend"}}, nil, containers)
+
+	if len(targets) != 1 || targets[0].Name != "" || targets[0].Network != "backend" {
+		t.Errorf("unexpected targets: %+v", targets)
+	}
+}
+
+func TestTargetHost(t *testing.T) {
+	if got := (Target{Name: "db", IP: "172.18.0.3"}).Host(); got != "db" {
+		t.Errorf("Host() = %q, want db", got)
+	}
+	if got := (Target{IP: "172.17.0.3"}).Host(); got != "172.17.0.3" {
+		t.Errorf("Host() = %q, want the address", got)
+	}
+}
@@ -0,0 +1,154 @@
+// Package prune identifies Docker networks that are safe to remove and
+// explains why each one is considered unused.
+package prune
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// Reason explains why a network is considered unused.
+type Reason string
+
+// Reasons, from most to least certain.
+const (
+	// ReasonOrphanedProject marks a network created by a Compose project
+	// that has no containers left, in any state, and that no running
+	// container of another project uses.
+	ReasonOrphanedProject Reason = "orphaned-project"
+
+	// ReasonNoContainers marks a user-defined network without containers.
+	ReasonNoContainers Reason = "no-containers"
+
+	// ReasonExitedOnly marks a network whose containers are all stopped.
+	ReasonExitedOnly Reason = "exited-only"
+)
+
+// swarmNetworks are the networks Docker creates for Swarm mode, which it
+// requires in addition to the predefined networks.
+var swarmNetworks = map[string]bool{
+	"docker_gwbridge": true,
+	"ingress":         true,
+}
+
+// Candidate is a network proposed for removal.
+type Candidate struct {
+	// Network is the network's name.
+	Network string
+
+	// ID is the network's ID, used to remove it unambiguously.
+	ID string
+
+	// Reason is why the network is considered unused.
+	Reason Reason
+
+	// Project is the Compose project that created the network, if any.
+	Project string
+
+	// Containers are the stopped containers still attached to the network,
+	// sorted by name, including those of other projects attached to an
+	// orphaned project's network. They will fail to start once the network
+	// is removed.
+	Containers []string
+}
+
+// String explains why the network is considered unused.
+func (c Candidate) String() string {
+	switch c.Reason {
+	case ReasonOrphanedProject:
+		if len(c.Containers) > 0 {
+			return fmt.Sprintf("network %s belongs to Compose project %s, which has no containers, and is only used by stopped containers: %s",
+				c.Network, c.Project, strings.Join(c.Containers, ", "))
+		}
+		return fmt.Sprintf("network %s belongs to Compose project %s, which has no containers", c.Network, c.Project)
+	case ReasonExitedOnly:
+		return fmt.Sprintf("network %s is only used by stopped containers: %s",
+			c.Network, strings.Join(c.Containers, ", "))
+	default:
+		return fmt.Sprintf("network %s has no containers", c.Network)
+	}
+}
+
+// Command returns the docker CLI command that removes the network.
+func (c Candidate) Command() string {
+	return "docker network rm " + c.Network
+}
+
+// Plan returns the user-defined networks that have no containers, are only
+// used by stopped containers, or belong to Compose projects without any
+// containers. A network still used by a running container is never proposed,
+// even when it belongs to an orphaned project, since other projects may use
+// it as an external network. Networks managed by Docker or Podman
+// themselves are never proposed. The containers must include stopped ones,
+// and networkToContainers is the map built by
+// docker.Client.BuildNetworkToContainersMap from the same containers.
+// Candidates are sorted by network name.
+func Plan(
+	networks []*models.NetworkInfo,
+	containers map[string]*models.ContainerInfo,
+	networkToContainers map[string][]models.ContainerInfo,
+) []Candidate {
+	projects := make(map[string]bool)
+	for _, c := range containers {
+		if c.Project != "" {
+			projects[c.Project] = true
+		}
+	}
+
+	var candidates []Candidate
+	for _, net := range networks {
+		if isManaged(net.Name) {
+			continue
+		}
+
+		stopped, ok := stoppedContainers(networkToContainers[net.Name])
+		if !ok {
+			continue
+		}
+
+		candidate := Candidate{Network: net.Name, ID: net.ID, Project: net.Project}
+		if len(stopped) > 0 {
+			candidate.Containers = stopped
+		}
+
+		switch {
+		case net.Project != "" && !projects[net.Project]:
+			candidate.Reason = ReasonOrphanedProject
+		case len(stopped) == 0:
+			candidate.Reason = ReasonNoContainers
+		default:
+			candidate.Reason = ReasonExitedOnly
+		}
+
+		candidates = append(candidates, candidate)
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Network < candidates[j].Network
+	})
+
+	return candidates
+}
+
+// stoppedContainers returns the sorted names of the given containers, and
+// whether all of them are stopped.
+func stoppedContainers(attached []models.ContainerInfo) ([]string, bool) {
+	names := make([]string, 0, len(attached))
+	for _, c := range attached {
+		if c.IsActive() {
+			return nil, false
+		}
+		names = append(names, c.Name)
+	}
+	sort.Strings(names)
+	return names, true
+}
+
+// isManaged reports whether a network is created and required by Docker or
+// Podman itself.
+func isManaged(name string) bool {
+	return models.IsPredefinedNetwork(name) || swarmNetworks[name]
+}
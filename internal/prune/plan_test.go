@@ -0,0 +1,142 @@
+package prune
+
+import (
+	"strings"
+	"testing"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// planFixture returns networks and containers covering every reason.
+func planFixture() ([]*models.NetworkInfo, map[string]*models.ContainerInfo, map[string][]models.ContainerInfo) {
+	networks := []*models.NetworkInfo{
+		{ID: "n1", Name: "bridge", Driver: "bridge"},
+		{ID: "n2", Name: "active", Driver: "bridge"},
+		{ID: "n3", Name: "empty", Driver: "bridge"},
+		{ID: "n4", Name: "old_default", Driver: "bridge", Project: "old"},
+		{ID: "n5", Name: "stopped", Driver: "bridge"},
+		{ID: "n6", Name: "shop_idle", Driver: "bridge", Project: "shop"},
+		{ID: "n7", Name: "mixed", Driver: "bridge"},
+		{ID: "n8", Name: "podman", Driver: "bridge"},
+		{ID: "n9", Name: "legacy_shared", Driver: "bridge", Project: "legacy"},
+		{ID: "n10", Name: "retired_default", Driver: "bridge", Project: "retired"},
+	}
+
+	containers := map[string]*models.ContainerInfo{}
+	add := func(name, state, project string, nets ...string) {
+		c := models.NewContainerInfo(name)
+		c.State = state
+		c.Project = project
+		for _, n := range nets {
+			c.AddNetwork(n)
+		}
+		containers[name] = c
+	}
+	add("web", "running", "", "active", "mixed", "bridge")
+	add("job-b", "exited", "", "stopped", "mixed")
+	add("job-a", "created", "", "stopped")
+	add("shop-api-1", "exited", "shop", "shop_other")
+	// Networks of torn-down projects used as external networks by others.
+	add("blog-web-1", "running", "blog", "legacy_shared")
+	add("blog-cron-1", "exited", "blog", "retired_default")
+
+	netMap := map[string][]models.ContainerInfo{}
+	for _, c := range containers {
+		for _, n := range c.Networks {
+			netMap[n] = append(netMap[n], *c)
+		}
+	}
+
+	return networks, containers, netMap
+}
+
+func TestPlan(t *testing.T) {
+	candidates := Plan(planFixture())
+
+	want := []struct {
+		network string
+		reason  Reason
+	}{
+		{"empty", ReasonNoContainers},
+		{"old_default", ReasonOrphanedProject},
+		{"retired_default", ReasonOrphanedProject},
+		{"shop_idle", ReasonNoContainers},
+		{"stopped", ReasonExitedOnly},
+	}
+
+	if len(candidates) != len(want) {
+		t.Fatalf("expected %d candidates, got %+v", len(want), candidates)
+	}
+	for i, w := range want {
+		if candidates[i].Network != w.network || candidates[i].Reason != w.reason {
+			t.Errorf("candidate %d = %s (%s), want %s (%s)",
+				i, candidates[i].Network, candidates[i].Reason, w.network, w.reason)
+		}
+	}
+
+	if orphaned := candidates[1]; len(orphaned.Containers) != 0 {
+		t.Errorf("expected no containers on %s, got %+v", orphaned.Network, orphaned.Containers)
+	}
+
+	if retired := candidates[2]; strings.Join(retired.Containers, ",") != "blog-cron-1" {
+		t.Errorf("expected the stopped container of another project on %s, got %+v", retired.Network, retired.Containers)
+	}
+
+	stopped := candidates[4]
+	if stopped.ID != "n5" || strings.Join(stopped.Containers, ",") != "job-a,job-b" {
+		t.Errorf("unexpected candidate: %+v", stopped)
+	}
+}
+
+// TestPlan_OrphanedProjectInUse verifies that an orphaned project's network
+// used by a running container of another project is not proposed.
+func TestPlan_OrphanedProjectInUse(t *testing.T) {
+	for _, c := range Plan(planFixture()) {
+		if c.Network == "legacy_shared" {
+			t.Errorf("network used by running blog-web-1 should not be proposed: %+v", c)
+		}
+	}
+}
+
+func TestPlan_Empty(t *testing.T) {
+	if candidates := Plan(nil, nil, nil); len(candidates) != 0 {
+		t.Errorf("expected no candidates, got %+v", candidates)
+	}
+}
+
+func TestCandidate_String(t *testing.T) {
+	tests := []struct {
+		candidate Candidate
+		want      string
+	}{
+		{
+			Candidate{Network: "old_default", Reason: ReasonOrphanedProject, Project: "old"},
+			"network old_default belongs to Compose project old, which has no containers",
+		},
+		{
+			Candidate{Network: "old_default", Reason: ReasonOrphanedProject, Project: "old", Containers: []string{"blog-cron-1"}},
+			"network old_default belongs to Compose project old, which has no containers, and is only used by stopped containers: blog-cron-1",
+		},
+		{
+			Candidate{Network: "empty", Reason: ReasonNoContainers},
+			"network empty has no containers",
+		},
+		{
+			Candidate{Network: "stopped", Reason: ReasonExitedOnly, Containers: []string{"job-a", "job-b"}},
+			"network stopped is only used by stopped containers: job-a, job-b",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := tt.candidate.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestCandidate_Command(t *testing.T) {
+	c := Candidate{Network: "empty", ID: "n3"}
+	if got := c.Command(); got != "docker network rm empty" {
+		t.Errorf("Command() = %q", got)
+	}
+}
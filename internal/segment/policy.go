@@ -0,0 +1,114 @@
+// Package segment grades and proposes network segmentation.
+// This file contains the communication policy file.
+package segment
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// Policy lists the communication containers are allowed, as an alternative
+// to deriving it from their environment.
+//
+// Example:
+//
+//	allow:
+//	  - from: web
+//	    to: api
+//	  - from: api
+//	    to: [db, cache]
+type Policy struct {
+	// Allow lists the allowed connections.
+	Allow []PolicyRule `yaml:"allow"`
+}
+
+// PolicyRule allows connections from one container or service to others.
+type PolicyRule struct {
+	// From is the container or Compose service that opens connections.
+	From string `yaml:"from"`
+
+	// To are the containers or Compose services it connects to.
+	To PolicyTargets `yaml:"to"`
+}
+
+// PolicyTargets lists container or service names. A single name or a list
+// is accepted when decoding.
+type PolicyTargets []string
+
+// UnmarshalYAML decodes either a single name or a list of names.
+func (t *PolicyTargets) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		*t = PolicyTargets{node.Value}
+		return nil
+	case yaml.SequenceNode:
+		var names []string
+		if err := node.Decode(&names); err != nil {
+			return err
+		}
+		*t = names
+		return nil
+	default:
+		return fmt.Errorf("line %d: to must be a name or a list of names", node.Line)
+	}
+}
+
+// LoadPolicy reads a policy file.
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path is supplied by the user
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file %s: %w", path, err)
+	}
+
+	var p Policy
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse policy file %s: %w", path, err)
+	}
+
+	return &p, nil
+}
+
+// Needs resolves the policy's names to containers and returns a need for
+// every allowed connection. A name matches a container by its name or its
+// Compose service, so a service stands for all of its replicas. Returns an
+// error naming the first name that matches no container.
+func (p *Policy) Needs(containers map[string]*models.ContainerInfo) ([]Need, error) {
+	var needs []Need
+	for _, rule := range p.Allow {
+		from, err := resolve(rule.From, containers)
+		if err != nil {
+			return nil, err
+		}
+		for _, target := range rule.To {
+			to, err := resolve(target, containers)
+			if err != nil {
+				return nil, err
+			}
+			for _, f := range from {
+				for _, t := range to {
+					needs = append(needs, Need{From: f, To: t})
+				}
+			}
+		}
+	}
+	return needs, nil
+}
+
+// resolve returns the containers a policy name stands for, sorted by name.
+func resolve(name string, containers map[string]*models.ContainerInfo) ([]string, error) {
+	var matches []string
+	for _, c := range models.SortedContainers(containers) {
+		if strings.EqualFold(c.Name, name) || (c.Service != "" && strings.EqualFold(c.Service, name)) {
+			matches = append(matches, c.Name)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("policy names %q, which matches no container or service", name)
+	}
+	return matches, nil
+}
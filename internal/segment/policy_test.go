@@ -0,0 +1,97 @@
+package segment
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// writePolicy writes a policy file and returns its path.
+func writePolicy(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "policy.yml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadPolicy(t *testing.T) {
+	path := writePolicy(t, `
+allow:
+  - from: web
+    to: api
+  - from: api
+    to: [db, cache]
+`)
+
+	policy, err := LoadPolicy(path)
+	if err != nil {
+		t.Fatalf("LoadPolicy returned error: %v", err)
+	}
+
+	want := &Policy{Allow: []PolicyRule{
+		{From: "web", To: PolicyTargets{"api"}},
+		{From: "api", To: PolicyTargets{"db", "cache"}},
+	}}
+	if !reflect.DeepEqual(policy, want) {
+		t.Errorf("LoadPolicy = %+v, want %+v", policy, want)
+	}
+}
+
+func TestLoadPolicyErrors(t *testing.T) {
+	if _, err := LoadPolicy(filepath.Join(t.TempDir(), "missing.yml")); err == nil ||
+		!strings.Contains(err.Error(), "failed to read policy file") {
+		t.Errorf("missing file: err = %v", err)
+	}
+
+	path := writePolicy(t, "allow:\n  - from: web\n    to: {api: true}\n")
+	if _, err := LoadPolicy(path); err == nil || !strings.Contains(err.Error(), "failed to parse policy file") {
+		t.Errorf("mapping target: err = %v", err)
+	}
+}
+
+func TestPolicyNeeds(t *testing.T) {
+	containers := map[string]*models.ContainerInfo{
+		"shop-web-1": {Name: "shop-web-1", Service: "web"},
+		"shop-api-1": {Name: "shop-api-1", Service: "api"},
+		"shop-api-2": {Name: "shop-api-2", Service: "api"},
+		"legacy-db":  {Name: "legacy-db"},
+	}
+	policy := &Policy{Allow: []PolicyRule{
+		{From: "web", To: PolicyTargets{"api"}},
+		{From: "api", To: PolicyTargets{"legacy-db"}},
+	}}
+
+	needs, err := policy.Needs(containers)
+	if err != nil {
+		t.Fatalf("Needs returned error: %v", err)
+	}
+
+	want := []Need{
+		{From: "shop-web-1", To: "shop-api-1"},
+		{From: "shop-web-1", To: "shop-api-2"},
+		{From: "shop-api-1", To: "legacy-db"},
+		{From: "shop-api-2", To: "legacy-db"},
+	}
+	if !reflect.DeepEqual(needs, want) {
+		t.Errorf("Needs =\n%+v\nwant\n%+v", needs, want)
+	}
+}
+
+func TestPolicyNeedsUnknownName(t *testing.T) {
+	containers := map[string]*models.ContainerInfo{
+		"web": {Name: "web"},
+	}
+	policy := &Policy{Allow: []PolicyRule{{From: "web", To: PolicyTargets{"ghost"}}}}
+
+	_, err := policy.Needs(containers)
+	if err == nil || !strings.Contains(err.Error(), `"ghost"`) {
+		t.Errorf("Needs err = %v, want error naming ghost", err)
+	}
+}
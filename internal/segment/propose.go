@@ -0,0 +1,203 @@
+// Package segment grades and proposes network segmentation.
+// This file proposes a minimal set of networks for the needed communication.
+package segment
+
+import (
+	"maps"
+	"slices"
+	"sort"
+	"strconv"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// proposedNetworkSuffix is appended to the name of the container a proposed
+// network is centered on.
+const proposedNetworkSuffix = "_net"
+
+// Need is a container's need to talk to another container.
+type Need struct {
+	// From is the name of the container that opens connections.
+	From string
+
+	// To is the name of the container it connects to.
+	To string
+}
+
+// Proposal is a set of networks that allows exactly the needed
+// communication.
+type Proposal struct {
+	// Networks are the proposed networks, in the order they were formed:
+	// the most widely shared first.
+	Networks []ProposedNetwork
+
+	// Unconnected are the containers without any need, which need no
+	// shared network, sorted by name.
+	Unconnected []string
+
+	// CurrentPairs is the number of container pairs sharing a network now.
+	CurrentPairs int
+
+	// ProposedPairs is the number of container pairs sharing a proposed
+	// network, which is the number of pairs with a need.
+	ProposedPairs int
+}
+
+// ProposedNetwork is a network in a proposal.
+type ProposedNetwork struct {
+	// Name is the proposed network name, after the container most of its
+	// members connect to, or its Compose service.
+	Name string
+
+	// Containers are the network's members, sorted by name.
+	Containers []string
+}
+
+// Propose finds a small set of networks in which every pair of members
+// needs to talk, in at least one direction, and every need is served by a
+// network. This is a clique cover of the need graph, built greedily: each
+// network starts from an uncovered need and takes in every container that
+// needs, or is needed by, all members so far. Needs between unknown
+// containers, or from a container to itself, are ignored.
+func Propose(containers map[string]*models.ContainerInfo, needs []Need) *Proposal {
+	adjacent := make(map[string]map[string]bool)
+	inDegree := make(map[string]int)
+	link := func(a, b string) {
+		if adjacent[a] == nil {
+			adjacent[a] = make(map[string]bool)
+		}
+		adjacent[a][b] = true
+	}
+
+	seenNeed := make(map[Need]bool)
+	for _, n := range needs {
+		if n.From == n.To || containers[n.From] == nil || containers[n.To] == nil || seenNeed[n] {
+			continue
+		}
+		seenNeed[n] = true
+		link(n.From, n.To)
+		link(n.To, n.From)
+		inDegree[n.To]++
+	}
+
+	proposal := &Proposal{CurrentPairs: currentPairs(containers)}
+	covered := make(map[[2]string]bool)
+
+	for _, edge := range sortedEdges(adjacent, inDegree) {
+		if covered[edge] {
+			continue
+		}
+
+		members := []string{edge[0], edge[1]}
+		for _, candidate := range slices.Sorted(maps.Keys(adjacent)) {
+			if containsAll(adjacent[candidate], members) {
+				members = append(members, candidate)
+			}
+		}
+		sort.Strings(members)
+
+		for i, a := range members {
+			for _, b := range members[i+1:] {
+				covered[[2]string{a, b}] = true
+			}
+		}
+
+		proposal.Networks = append(proposal.Networks, ProposedNetwork{
+			Name:       proposedName(containers, members, inDegree, proposal.Networks),
+			Containers: members,
+		})
+	}
+
+	proposal.ProposedPairs = len(covered)
+
+	for _, name := range slices.Sorted(maps.Keys(containers)) {
+		if len(adjacent[name]) == 0 {
+			proposal.Unconnected = append(proposal.Unconnected, name)
+		}
+	}
+
+	return proposal
+}
+
+// sortedEdges returns the undirected edges of the need graph as sorted name
+// pairs. Edges to the most needed containers come first, so that networks
+// form around shared services.
+func sortedEdges(adjacent map[string]map[string]bool, inDegree map[string]int) [][2]string {
+	var edges [][2]string
+	for a, peers := range adjacent {
+		for b := range peers {
+			if a < b {
+				edges = append(edges, [2]string{a, b})
+			}
+		}
+	}
+
+	weight := func(e [2]string) int {
+		return max(inDegree[e[0]], inDegree[e[1]])
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if wi, wj := weight(edges[i]), weight(edges[j]); wi != wj {
+			return wi > wj
+		}
+		if edges[i][0] != edges[j][0] {
+			return edges[i][0] < edges[j][0]
+		}
+		return edges[i][1] < edges[j][1]
+	})
+	return edges
+}
+
+// containsAll reports whether peers holds every member.
+func containsAll(peers map[string]bool, members []string) bool {
+	for _, m := range members {
+		if !peers[m] {
+			return false
+		}
+	}
+	return true
+}
+
+// proposedName names a network after its most needed member, by Compose
+// service when it has one, adding a number when an earlier network already
+// has the name.
+func proposedName(containers map[string]*models.ContainerInfo, members []string, inDegree map[string]int, existing []ProposedNetwork) string {
+	center := members[0]
+	for _, m := range members[1:] {
+		if inDegree[m] > inDegree[center] {
+			center = m
+		}
+	}
+	if service := containers[center].Service; service != "" {
+		center = service
+	}
+
+	name := center + proposedNetworkSuffix
+	for n := 2; nameTaken(name, existing); n++ {
+		name = center + proposedNetworkSuffix + "_" + strconv.Itoa(n)
+	}
+	return name
+}
+
+// nameTaken reports whether a network in existing has the name.
+func nameTaken(name string, existing []ProposedNetwork) bool {
+	for _, n := range existing {
+		if n.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// currentPairs counts the container pairs that share a network other than
+// host or none.
+func currentPairs(containers map[string]*models.ContainerInfo) int {
+	pairs := make(map[[2]string]bool)
+	for _, members := range networkMembers(models.SortedContainers(containers)) {
+		for i, a := range members {
+			for _, b := range members[i+1:] {
+				pairs[[2]string{a.Name, b.Name}] = true
+			}
+		}
+	}
+	return len(pairs)
+}
@@ -0,0 +1,121 @@
+package segment
+
+import (
+	"reflect"
+	"testing"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+func TestPropose(t *testing.T) {
+	needs := []Need{
+		{From: "web", To: "api"},
+		{From: "api", To: "db"},
+		{From: "worker", To: "db"},
+		{From: "worker", To: "api"},
+		{From: "metrics", To: "api"},
+	}
+
+	proposal := Propose(legacyContainers(), needs)
+
+	want := []ProposedNetwork{
+		{Name: "api_net", Containers: []string{"api", "db", "worker"}},
+		{Name: "api_net_2", Containers: []string{"api", "metrics"}},
+		{Name: "api_net_3", Containers: []string{"api", "web"}},
+	}
+	if !reflect.DeepEqual(proposal.Networks, want) {
+		t.Errorf("Networks =\n%+v\nwant\n%+v", proposal.Networks, want)
+	}
+	if want := []string{"cron", "legacy"}; !reflect.DeepEqual(proposal.Unconnected, want) {
+		t.Errorf("Unconnected = %v, want %v", proposal.Unconnected, want)
+	}
+	if proposal.CurrentPairs != 7 {
+		t.Errorf("CurrentPairs = %d, want 7", proposal.CurrentPairs)
+	}
+	if proposal.ProposedPairs != 5 {
+		t.Errorf("ProposedPairs = %d, want 5", proposal.ProposedPairs)
+	}
+}
+
+func TestProposeCoversEveryNeed(t *testing.T) {
+	needs := []Need{
+		{From: "web", To: "api"},
+		{From: "api", To: "db"},
+		{From: "worker", To: "db"},
+	}
+
+	proposal := Propose(legacyContainers(), needs)
+
+	for _, n := range needs {
+		found := false
+		for _, net := range proposal.Networks {
+			if containsAll(setOf(net.Containers), []string{n.From, n.To}) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("no proposed network serves %s -> %s", n.From, n.To)
+		}
+	}
+}
+
+func TestProposeIgnoresInvalidNeeds(t *testing.T) {
+	needs := []Need{
+		{From: "api", To: "api"},
+		{From: "api", To: "ghost"},
+		{From: "web", To: "api"},
+		{From: "web", To: "api"},
+	}
+
+	proposal := Propose(legacyContainers(), needs)
+
+	want := []ProposedNetwork{{Name: "api_net", Containers: []string{"api", "web"}}}
+	if !reflect.DeepEqual(proposal.Networks, want) {
+		t.Errorf("Networks = %+v, want %+v", proposal.Networks, want)
+	}
+	if proposal.ProposedPairs != 1 {
+		t.Errorf("ProposedPairs = %d, want 1", proposal.ProposedPairs)
+	}
+}
+
+func TestProposeNoNeeds(t *testing.T) {
+	proposal := Propose(legacyContainers(), nil)
+
+	if len(proposal.Networks) != 0 {
+		t.Errorf("Networks = %+v, want none", proposal.Networks)
+	}
+	if len(proposal.Unconnected) != 7 {
+		t.Errorf("Unconnected = %v, want all 7 containers", proposal.Unconnected)
+	}
+}
+
+// setOf returns a set of names.
+func setOf(names []string) map[string]bool {
+	set := make(map[string]bool, len(names))
+	for _, n := range names {
+		set[n] = true
+	}
+	return set
+}
+
+func TestProposeNamesByService(t *testing.T) {
+	containers := map[string]*models.ContainerInfo{
+		"shop-api-1": {Name: "shop-api-1", Service: "api"},
+		"shop-db-1":  {Name: "shop-db-1", Service: "db"},
+		"shop-web-1": {Name: "shop-web-1", Service: "web"},
+	}
+	needs := []Need{
+		{From: "shop-api-1", To: "shop-db-1"},
+		{From: "shop-web-1", To: "shop-db-1"},
+	}
+
+	proposal := Propose(containers, needs)
+
+	want := []ProposedNetwork{
+		{Name: "db_net", Containers: []string{"shop-api-1", "shop-db-1"}},
+		{Name: "db_net_2", Containers: []string{"shop-db-1", "shop-web-1"}},
+	}
+	if !reflect.DeepEqual(proposal.Networks, want) {
+		t.Errorf("Networks =\n%+v\nwant\n%+v", proposal.Networks, want)
+	}
+}
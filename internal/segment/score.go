@@ -0,0 +1,222 @@
+// Package segment grades how well a host's containers are segmented into
+// networks and proposes a minimal set of networks that allows only the
+// communication the containers need.
+package segment
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// MaxScore is the score of a host with no segmentation issues.
+const MaxScore = 100
+
+// DefaultBroadNetworkSize is the number of containers from which a shared
+// network counts as overly broad.
+const DefaultBroadNetworkSize = 10
+
+// Scoring categories.
+const (
+	// CategoryDefaultBridge counts containers on the default bridge network.
+	CategoryDefaultBridge = "default-bridge"
+
+	// CategoryBroadNetwork counts networks shared by many containers.
+	CategoryBroadNetwork = "broad-network"
+
+	// CategoryDatabaseExposure counts databases sharing a network with a
+	// container that publishes ports on all host interfaces.
+	CategoryDatabaseExposure = "database-exposure"
+
+	// CategoryMultiHomed counts containers attached to several networks.
+	CategoryMultiHomed = "multi-homed"
+)
+
+// category describes how a category is scored: the penalty per finding and
+// the most the category can take off the score.
+type category struct {
+	name        string
+	description string
+	penalty     int
+	maxPenalty  int
+}
+
+// categories lists the scoring categories in report order. The caps add up
+// to MaxScore.
+var categories = []category{
+	{CategoryDefaultBridge, "containers on the default bridge network", 5, 25},
+	{CategoryBroadNetwork, "overly broad shared networks", 10, 25},
+	{CategoryDatabaseExposure, "databases sharing a network with public-facing services", 15, 30},
+	{CategoryMultiHomed, "multi-homed containers", 2, 20},
+}
+
+// Options tunes the scoring.
+type Options struct {
+	// BroadNetworkSize is the number of containers from which a shared
+	// network counts as overly broad. Zero uses DefaultBroadNetworkSize.
+	BroadNetworkSize int
+}
+
+// Report is the segmentation score of a host.
+type Report struct {
+	// Score is MaxScore less the penalties of every category, from 0 to
+	// MaxScore.
+	Score int
+
+	// Categories holds the result of every scoring category, in a fixed
+	// order.
+	Categories []CategoryResult
+}
+
+// CategoryResult is the outcome of one scoring category.
+type CategoryResult struct {
+	// Name identifies the category, such as CategoryDefaultBridge.
+	Name string
+
+	// Description explains what the category counts.
+	Description string
+
+	// Penalty is the number of points the category takes off the score.
+	Penalty int
+
+	// Findings describe each counted item, sorted.
+	Findings []string
+}
+
+// Score grades the segmentation of the given containers. Each finding costs
+// a fixed penalty up to a cap per category, so that no single category can
+// drive the score to zero on its own.
+//
+// A public-facing service is a container publishing a port on all host
+// interfaces; a database is recognized by its well-known ports or its image.
+// The host and none networks are not counted as shared networks.
+func Score(containers map[string]*models.ContainerInfo, opts Options) *Report {
+	broad := opts.BroadNetworkSize
+	if broad <= 0 {
+		broad = DefaultBroadNetworkSize
+	}
+
+	sorted := models.SortedContainers(containers)
+	members := networkMembers(sorted)
+
+	findings := map[string][]string{
+		CategoryDefaultBridge:    defaultBridgeFindings(sorted),
+		CategoryBroadNetwork:     broadNetworkFindings(members, broad),
+		CategoryDatabaseExposure: databaseExposureFindings(sorted, members),
+		CategoryMultiHomed:       multiHomedFindings(sorted),
+	}
+
+	report := &Report{Score: MaxScore}
+	for _, cat := range categories {
+		result := CategoryResult{
+			Name:        cat.name,
+			Description: cat.description,
+			Penalty:     min(len(findings[cat.name])*cat.penalty, cat.maxPenalty),
+			Findings:    findings[cat.name],
+		}
+		report.Score -= result.Penalty
+		report.Categories = append(report.Categories, result)
+	}
+	report.Score = max(report.Score, 0)
+
+	return report
+}
+
+// defaultBridgeFindings lists the containers on the default bridge network.
+func defaultBridgeFindings(containers []*models.ContainerInfo) []string {
+	var findings []string
+	for _, c := range containers {
+		for _, net := range []string{models.BridgeNetwork, models.PodmanDefaultNetwork} {
+			if c.HasNetwork(net) {
+				findings = append(findings, fmt.Sprintf("%s is on %s", c.Name, net))
+				break
+			}
+		}
+	}
+	return findings
+}
+
+// broadNetworkFindings lists the networks with at least size containers.
+func broadNetworkFindings(members map[string][]*models.ContainerInfo, size int) []string {
+	var findings []string
+	for _, net := range slices.Sorted(maps.Keys(members)) {
+		if n := len(members[net]); n >= size {
+			findings = append(findings, fmt.Sprintf("%s connects %d containers", net, n))
+		}
+	}
+	return findings
+}
+
+// databaseExposureFindings lists, for every database and network, the
+// public-facing services the database shares the network with.
+func databaseExposureFindings(containers []*models.ContainerInfo, members map[string][]*models.ContainerInfo) []string {
+	var findings []string
+	for _, db := range containers {
+		product, ok := db.DatabaseProduct()
+		if !ok {
+			continue
+		}
+		for _, net := range db.SortedNetworks() {
+			var public []string
+			for _, c := range members[net] {
+				if c.Name != db.Name && isPublicFacing(c) {
+					public = append(public, c.Name)
+				}
+			}
+			if len(public) > 0 {
+				findings = append(findings, fmt.Sprintf("%s (%s) shares %s with %s",
+					db.Name, product, net, strings.Join(public, ", ")))
+			}
+		}
+	}
+	return findings
+}
+
+// multiHomedFindings lists the containers attached to several networks.
+func multiHomedFindings(containers []*models.ContainerInfo) []string {
+	var findings []string
+	for _, c := range containers {
+		if nets := sharedNetworks(c); len(nets) > 1 {
+			findings = append(findings, fmt.Sprintf("%s joins %s", c.Name, strings.Join(nets, ", ")))
+		}
+	}
+	return findings
+}
+
+// isPublicFacing reports whether a container publishes a port on all host
+// interfaces.
+func isPublicFacing(c *models.ContainerInfo) bool {
+	for _, p := range c.Ports {
+		if p.IsPublic() {
+			return true
+		}
+	}
+	return false
+}
+
+// sharedNetworks returns a container's networks that connect it to other
+// containers, sorted by name: all but host and none.
+func sharedNetworks(c *models.ContainerInfo) []string {
+	var nets []string
+	for _, net := range c.SortedNetworks() {
+		if net != models.HostNetwork && net != models.NoneNetwork {
+			nets = append(nets, net)
+		}
+	}
+	return nets
+}
+
+// networkMembers maps each shared network to its containers, in the order
+// given.
+func networkMembers(containers []*models.ContainerInfo) map[string][]*models.ContainerInfo {
+	members := make(map[string][]*models.ContainerInfo)
+	for _, c := range containers {
+		for _, net := range sharedNetworks(c) {
+			members[net] = append(members[net], c)
+		}
+	}
+	return members
+}
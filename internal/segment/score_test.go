@@ -0,0 +1,131 @@
+package segment
+
+import (
+	"reflect"
+	"testing"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// legacyContainers returns an "everything on one network" topology: a public
+// web server, an api, a database and a worker share app, the api also joins
+// monitoring, and a legacy container runs on the default bridge.
+func legacyContainers() map[string]*models.ContainerInfo {
+	return map[string]*models.ContainerInfo{
+		"web": {Name: "web", Networks: []string{"app"}, Ports: []models.PortInfo{
+			{IP: "0.0.0.0", PrivatePort: 80, PublicPort: 80, Type: "tcp"},
+		}},
+		"api":     {Name: "api", Networks: []string{"app", "monitoring"}},
+		"db":      {Name: "db", Image: "postgres:16", Networks: []string{"app"}},
+		"worker":  {Name: "worker", Networks: []string{"app"}},
+		"metrics": {Name: "metrics", Networks: []string{"monitoring"}},
+		"legacy":  {Name: "legacy", Networks: []string{"bridge"}},
+		"cron":    {Name: "cron", Networks: []string{"none"}},
+	}
+}
+
+func TestScore(t *testing.T) {
+	report := Score(legacyContainers(), Options{BroadNetworkSize: 4})
+
+	want := []CategoryResult{
+		{
+			Name: CategoryDefaultBridge, Description: "containers on the default bridge network",
+			Penalty: 5, Findings: []string{"legacy is on bridge"},
+		},
+		{
+			Name: CategoryBroadNetwork, Description: "overly broad shared networks",
+			Penalty: 10, Findings: []string{"app connects 4 containers"},
+		},
+		{
+			Name: CategoryDatabaseExposure, Description: "databases sharing a network with public-facing services",
+			Penalty: 15, Findings: []string{"db (PostgreSQL) shares app with web"},
+		},
+		{
+			Name: CategoryMultiHomed, Description: "multi-homed containers",
+			Penalty: 2, Findings: []string{"api joins app, monitoring"},
+		},
+	}
+	if !reflect.DeepEqual(report.Categories, want) {
+		t.Errorf("Categories =\n%+v\nwant\n%+v", report.Categories, want)
+	}
+	if report.Score != 68 {
+		t.Errorf("Score = %d, want 68", report.Score)
+	}
+}
+
+func TestScoreDefaultBroadNetworkSize(t *testing.T) {
+	report := Score(legacyContainers(), Options{})
+
+	if findings := report.Categories[1].Findings; len(findings) != 0 {
+		t.Errorf("broad-network findings = %v, want none below %d containers", findings, DefaultBroadNetworkSize)
+	}
+}
+
+func TestScorePenaltyCap(t *testing.T) {
+	containers := make(map[string]*models.ContainerInfo)
+	for _, name := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+		containers[name] = &models.ContainerInfo{Name: name, Networks: []string{"bridge"}}
+	}
+
+	report := Score(containers, Options{BroadNetworkSize: 100})
+
+	if got := report.Categories[0]; got.Penalty != 25 || len(got.Findings) != 7 {
+		t.Errorf("default-bridge = penalty %d with %d findings, want 25 with 7", got.Penalty, len(got.Findings))
+	}
+	if report.Score != 75 {
+		t.Errorf("Score = %d, want 75", report.Score)
+	}
+}
+
+func TestScorePodmanNetwork(t *testing.T) {
+	containers := map[string]*models.ContainerInfo{
+		"app": {Name: "app", Networks: []string{"podman"}},
+	}
+
+	report := Score(containers, Options{})
+
+	if got := report.Categories[0].Findings; !reflect.DeepEqual(got, []string{"app is on podman"}) {
+		t.Errorf("default-bridge findings = %v", got)
+	}
+}
+
+func TestScoreLocalPortIsNotPublic(t *testing.T) {
+	containers := map[string]*models.ContainerInfo{
+		"admin": {Name: "admin", Networks: []string{"back"}, Ports: []models.PortInfo{
+			{IP: "127.0.0.1", PrivatePort: 8080, PublicPort: 8080, Type: "tcp"},
+		}},
+		"db": {Name: "db", Networks: []string{"back"}, Ports: []models.PortInfo{
+			{PrivatePort: 5432, Type: "tcp"},
+		}},
+	}
+
+	report := Score(containers, Options{})
+
+	if got := report.Categories[2].Findings; len(got) != 0 {
+		t.Errorf("database-exposure findings = %v, want none for a port on 127.0.0.1", got)
+	}
+	if report.Score != MaxScore {
+		t.Errorf("Score = %d, want %d", report.Score, MaxScore)
+	}
+}
+
+func TestScoreEmpty(t *testing.T) {
+	report := Score(nil, Options{})
+
+	if report.Score != MaxScore {
+		t.Errorf("Score = %d, want %d", report.Score, MaxScore)
+	}
+	if len(report.Categories) != len(categories) {
+		t.Errorf("got %d categories, want %d", len(report.Categories), len(categories))
+	}
+}
+
+func TestCategoryCapsAddUpToMaxScore(t *testing.T) {
+	total := 0
+	for _, cat := range categories {
+		total += cat.maxPenalty
+	}
+	if total != MaxScore {
+		t.Errorf("category caps add up to %d, want %d", total, MaxScore)
+	}
+}
@@ -0,0 +1,181 @@
+// Package simulate applies hypothetical changes to an in-memory copy of the
+// network topology, so their effect can be reviewed without touching the
+// Docker daemon.
+package simulate
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// Action is the kind of a hypothetical change.
+type Action string
+
+// Supported actions.
+const (
+	// ActionConnect attaches a container to a network.
+	ActionConnect Action = "connect"
+
+	// ActionDisconnect detaches a container from a network.
+	ActionDisconnect Action = "disconnect"
+
+	// ActionAddNetwork creates a network.
+	ActionAddNetwork Action = "add-network"
+
+	// ActionRemoveNetwork removes a network, detaching its containers.
+	ActionRemoveNetwork Action = "remove-network"
+
+	// ActionAddContainer creates a container attached to the given networks.
+	ActionAddContainer Action = "add-container"
+
+	// ActionRemoveContainer removes a container.
+	ActionRemoveContainer Action = "remove-container"
+)
+
+// Change is a single hypothetical change to the topology.
+type Change struct {
+	// Action is the kind of change.
+	Action Action `yaml:"action"`
+
+	// Container is the container concerned, for container actions and connect/disconnect.
+	Container string `yaml:"container,omitempty"`
+
+	// Network is the network concerned, for network actions and connect/disconnect.
+	Network string `yaml:"network,omitempty"`
+
+	// Networks are the networks of a container created with add-container.
+	Networks []string `yaml:"networks,omitempty"`
+
+	// Aliases are the container's aliases on the network, for connect and add-container.
+	Aliases []string `yaml:"aliases,omitempty"`
+
+	// Driver is the driver of a network created with add-network; defaults to "bridge".
+	Driver string `yaml:"driver,omitempty"`
+
+	// Internal marks a network created with add-network as internal.
+	Internal bool `yaml:"internal,omitempty"`
+}
+
+// String describes the change, for example "connect api to backend".
+func (c Change) String() string {
+	switch c.Action {
+	case ActionConnect:
+		return fmt.Sprintf("connect %s to %s", c.Container, c.Network)
+	case ActionDisconnect:
+		return fmt.Sprintf("disconnect %s from %s", c.Container, c.Network)
+	case ActionAddNetwork:
+		return fmt.Sprintf("add network %s", c.Network)
+	case ActionRemoveNetwork:
+		return fmt.Sprintf("remove network %s", c.Network)
+	case ActionAddContainer:
+		if len(c.Networks) == 0 {
+			return fmt.Sprintf("add container %s", c.Container)
+		}
+		return fmt.Sprintf("add container %s on %s", c.Container, strings.Join(c.Networks, ", "))
+	case ActionRemoveContainer:
+		return fmt.Sprintf("remove container %s", c.Container)
+	default:
+		return string(c.Action)
+	}
+}
+
+// Validate checks that the change names the container and network its action needs.
+func (c Change) Validate() error {
+	needContainer, needNetwork := false, false
+	switch c.Action {
+	case ActionConnect, ActionDisconnect:
+		needContainer, needNetwork = true, true
+	case ActionAddNetwork, ActionRemoveNetwork:
+		needNetwork = true
+	case ActionAddContainer, ActionRemoveContainer:
+		needContainer = true
+	default:
+		return fmt.Errorf("unknown action %q", c.Action)
+	}
+
+	if needContainer && c.Container == "" {
+		return fmt.Errorf("%s: container is required", c.Action)
+	}
+	if needNetwork && c.Network == "" {
+		return fmt.Errorf("%s: network is required", c.Action)
+	}
+
+	return nil
+}
+
+// changeFile is the document read by LoadFile.
+type changeFile struct {
+	Changes []Change `yaml:"changes"`
+}
+
+// LoadFile reads changes from a YAML file of the form:
+//
+//	changes:
+//	  - action: connect
+//	    container: api
+//	    network: backend
+//	  - action: remove-network
+//	    network: legacy
+//
+// Unknown keys are rejected so typos do not silently drop a change.
+func LoadFile(path string) ([]Change, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open change file: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	var doc changeFile
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(&doc); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse change file %s: %w", path, err)
+	}
+
+	for i, c := range doc.Changes {
+		if err := c.Validate(); err != nil {
+			return nil, fmt.Errorf("change %d in %s: %w", i+1, path, err)
+		}
+	}
+
+	return doc.Changes, nil
+}
+
+// ParseChange parses the command-line form of a change: "CONTAINER:NETWORK"
+// for connect and disconnect, "NETWORK" for network actions, and
+// "CONTAINER[:NETWORK,...]" for container actions.
+func ParseChange(action Action, spec string) (Change, error) {
+	c := Change{Action: action}
+
+	switch action {
+	case ActionConnect, ActionDisconnect:
+		container, network, ok := strings.Cut(spec, ":")
+		if !ok {
+			return Change{}, fmt.Errorf("invalid %s %q: expected CONTAINER:NETWORK", action, spec)
+		}
+		c.Container, c.Network = container, network
+	case ActionAddNetwork, ActionRemoveNetwork:
+		c.Network = spec
+	case ActionAddContainer, ActionRemoveContainer:
+		container, networks, _ := strings.Cut(spec, ":")
+		c.Container = container
+		for _, n := range strings.Split(networks, ",") {
+			if n = strings.TrimSpace(n); n != "" {
+				c.Networks = append(c.Networks, n)
+			}
+		}
+	}
+
+	if err := c.Validate(); err != nil {
+		return Change{}, err
+	}
+
+	return c, nil
+}
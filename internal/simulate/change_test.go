@@ -0,0 +1,127 @@
+package simulate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "changes.yml")
+	content := `
+changes:
+  - action: connect
+    container: api
+    network: backend
+    aliases: [api.internal]
+  - action: add-network
+    network: data
+    internal: true
+  - action: add-container
+    container: worker
+    networks: [data]
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	changes, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(changes) != 3 {
+		t.Fatalf("expected 3 changes, got %+v", changes)
+	}
+	if changes[0].Action != ActionConnect || changes[0].Aliases[0] != "api.internal" {
+		t.Errorf("unexpected change: %+v", changes[0])
+	}
+	if !changes[1].Internal || changes[2].Networks[0] != "data" {
+		t.Errorf("unexpected changes: %+v", changes[1:])
+	}
+}
+
+func TestLoadFileErrors(t *testing.T) {
+	tests := map[string]string{
+		"unknown key":     "changes:\n  - action: connect\n    containr: api\n    network: backend\n",
+		"unknown action":  "changes:\n  - action: rename\n    container: api\n",
+		"missing network": "changes:\n  - action: connect\n    container: api\n",
+	}
+
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "changes.yml")
+			if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := LoadFile(path); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+
+	if _, err := LoadFile(filepath.Join(t.TempDir(), "missing.yml")); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
+
+func TestLoadFileEmpty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "changes.yml")
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	changes, err := LoadFile(path)
+	if err != nil || len(changes) != 0 {
+		t.Errorf("expected no changes, got %+v, %v", changes, err)
+	}
+}
+
+func TestParseChange(t *testing.T) {
+	tests := []struct {
+		action Action
+		spec   string
+		want   string
+	}{
+		{ActionConnect, "api:backend", "connect api to backend"},
+		{ActionDisconnect, "web:frontend", "disconnect web from frontend"},
+		{ActionAddNetwork, "data", "add network data"},
+		{ActionRemoveNetwork, "legacy", "remove network legacy"},
+		{ActionAddContainer, "worker:data, backend", "add container worker on data, backend"},
+		{ActionAddContainer, "worker", "add container worker"},
+		{ActionRemoveContainer, "old", "remove container old"},
+	}
+
+	for _, tt := range tests {
+		c, err := ParseChange(tt.action, tt.spec)
+		if err != nil {
+			t.Errorf("ParseChange(%s, %q) error: %v", tt.action, tt.spec, err)
+			continue
+		}
+		if got := c.String(); got != tt.want {
+			t.Errorf("ParseChange(%s, %q) = %q, want %q", tt.action, tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestParseChangeErrors(t *testing.T) {
+	for _, tt := range []struct {
+		action Action
+		spec   string
+	}{
+		{ActionConnect, "api"},
+		{ActionConnect, "api:"},
+		{ActionRemoveNetwork, ""},
+		{"rename", "api"},
+	} {
+		if _, err := ParseChange(tt.action, tt.spec); err == nil {
+			t.Errorf("ParseChange(%s, %q) should fail", tt.action, tt.spec)
+		}
+	}
+
+	_, err := ParseChange(ActionConnect, "api")
+	if err == nil || !strings.Contains(err.Error(), "CONTAINER:NETWORK") {
+		t.Errorf("expected usage hint in error, got %v", err)
+	}
+}
@@ -0,0 +1,179 @@
+// Package simulate applies hypothetical changes to an in-memory copy of the
+// network topology.
+package simulate
+
+import (
+	"fmt"
+	"sort"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// defaultDriver is the driver of networks added without one.
+const defaultDriver = "bridge"
+
+// State is an in-memory copy of the topology that changes are applied to.
+type State struct {
+	// Networks holds the networks sorted by name.
+	Networks []*models.NetworkInfo
+
+	// Containers maps container names to their network information.
+	Containers map[string]*models.ContainerInfo
+}
+
+// NewState returns a deep copy of the given networks and containers, so
+// applying changes never modifies the originals.
+func NewState(networks []*models.NetworkInfo, containers map[string]*models.ContainerInfo) *State {
+	s := &State{
+		Networks:   make([]*models.NetworkInfo, 0, len(networks)),
+		Containers: make(map[string]*models.ContainerInfo, len(containers)),
+	}
+	for _, net := range networks {
+		clone := *net
+		s.Networks = append(s.Networks, &clone)
+	}
+	for name, c := range containers {
+		s.Containers[name] = c.Clone()
+	}
+	return s
+}
+
+// Apply applies the changes in order. It stops at the first change that
+// cannot be applied, such as connecting an unknown container, and returns an
+// error naming it; the changes before it remain applied.
+func (s *State) Apply(changes []Change) error {
+	for i, c := range changes {
+		if err := s.apply(c); err != nil {
+			return fmt.Errorf("change %d (%s): %w", i+1, c, err)
+		}
+	}
+	return nil
+}
+
+// apply applies a single change.
+func (s *State) apply(c Change) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+
+	switch c.Action {
+	case ActionConnect:
+		container, err := s.container(c.Container)
+		if err != nil {
+			return err
+		}
+		if _, err := s.network(c.Network); err != nil {
+			return err
+		}
+		if !container.AddNetwork(c.Network) {
+			return fmt.Errorf("container %s is already connected to network %s", c.Container, c.Network)
+		}
+		for _, alias := range c.Aliases {
+			container.AddNetworkAlias(c.Network, alias)
+		}
+
+	case ActionDisconnect:
+		container, err := s.container(c.Container)
+		if err != nil {
+			return err
+		}
+		if !container.RemoveNetwork(c.Network) {
+			return fmt.Errorf("container %s is not connected to network %s", c.Container, c.Network)
+		}
+
+	case ActionAddNetwork:
+		if _, err := s.network(c.Network); err == nil {
+			return fmt.Errorf("network %s already exists", c.Network)
+		}
+		driver := c.Driver
+		if driver == "" {
+			driver = defaultDriver
+		}
+		net := models.NewNetworkInfo(c.Network, driver)
+		net.Internal = c.Internal
+		s.Networks = append(s.Networks, net)
+		sort.Slice(s.Networks, func(i, j int) bool {
+			return s.Networks[i].Name < s.Networks[j].Name
+		})
+
+	case ActionRemoveNetwork:
+		index := -1
+		for i, net := range s.Networks {
+			if net.Name == c.Network {
+				index = i
+			}
+		}
+		if index < 0 {
+			return fmt.Errorf("network %s not found", c.Network)
+		}
+		s.Networks = append(s.Networks[:index:index], s.Networks[index+1:]...)
+		for _, container := range s.Containers {
+			container.RemoveNetwork(c.Network)
+		}
+
+	case ActionAddContainer:
+		if _, ok := s.Containers[c.Container]; ok {
+			return fmt.Errorf("container %s already exists", c.Container)
+		}
+		container := models.NewContainerInfo(c.Container)
+		container.State = "running"
+		for _, netName := range c.Networks {
+			if _, err := s.network(netName); err != nil {
+				return err
+			}
+			container.AddNetwork(netName)
+			for _, alias := range c.Aliases {
+				container.AddNetworkAlias(netName, alias)
+			}
+		}
+		s.Containers[c.Container] = container
+
+	case ActionRemoveContainer:
+		if _, err := s.container(c.Container); err != nil {
+			return err
+		}
+		delete(s.Containers, c.Container)
+	}
+
+	return nil
+}
+
+// NetworkToContainers builds the network-to-containers map of the state,
+// in the form returned by docker.Client.BuildNetworkToContainersMap.
+func (s *State) NetworkToContainers() map[string][]models.ContainerInfo {
+	netMap := make(map[string][]models.ContainerInfo)
+
+	names := make([]string, 0, len(s.Containers))
+	for name := range s.Containers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		c := s.Containers[name]
+		for _, netName := range c.Networks {
+			netMap[netName] = append(netMap[netName], *c)
+		}
+	}
+
+	return netMap
+}
+
+// container returns the named container.
+func (s *State) container(name string) (*models.ContainerInfo, error) {
+	c, ok := s.Containers[name]
+	if !ok {
+		return nil, fmt.Errorf("container %s not found", name)
+	}
+	return c, nil
+}
+
+// network returns the named network.
+func (s *State) network(name string) (*models.NetworkInfo, error) {
+	for _, net := range s.Networks {
+		if net.Name == name {
+			return net, nil
+		}
+	}
+	return nil, fmt.Errorf("network %s not found", name)
+}
@@ -0,0 +1,113 @@
+package simulate
+
+import (
+	"testing"
+
+	"git.o.ocom.com.au/go/docker-network-viz/internal/models"
+)
+
+// testState returns a state with api and nginx on frontend, api and postgres
+// on backend, and an unused legacy network.
+func testState() ([]*models.NetworkInfo, map[string]*models.ContainerInfo) {
+	networks := []*models.NetworkInfo{
+		models.NewNetworkInfo("backend", "bridge"),
+		models.NewNetworkInfo("frontend", "bridge"),
+		models.NewNetworkInfo("legacy", "bridge"),
+	}
+
+	containers := map[string]*models.ContainerInfo{}
+	for name, nets := range map[string][]string{
+		"api":      {"frontend", "backend"},
+		"nginx":    {"frontend"},
+		"postgres": {"backend"},
+	} {
+		c := models.NewContainerInfo(name)
+		for _, n := range nets {
+			c.AddNetwork(n)
+		}
+		containers[name] = c
+	}
+
+	return networks, containers
+}
+
+func TestStateApply(t *testing.T) {
+	networks, containers := testState()
+	s := NewState(networks, containers)
+
+	err := s.Apply([]Change{
+		{Action: ActionAddNetwork, Network: "data", Internal: true},
+		{Action: ActionAddContainer, Container: "worker", Networks: []string{"data"}, Aliases: []string{"jobs"}},
+		{Action: ActionConnect, Container: "postgres", Network: "data", Aliases: []string{"db"}},
+		{Action: ActionDisconnect, Container: "api", Network: "frontend"},
+		{Action: ActionRemoveNetwork, Network: "legacy"},
+		{Action: ActionRemoveContainer, Container: "nginx"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var names []string
+	for _, net := range s.Networks {
+		names = append(names, net.Name)
+	}
+	if len(names) != 3 || names[0] != "backend" || names[1] != "data" || names[2] != "frontend" {
+		t.Errorf("unexpected networks: %v", names)
+	}
+	if !s.Networks[1].Internal {
+		t.Error("added network should be internal")
+	}
+
+	netMap := s.NetworkToContainers()
+	if got := netMap["data"]; len(got) != 2 || got[0].Name != "postgres" || got[1].Name != "worker" {
+		t.Errorf("unexpected data containers: %+v", got)
+	}
+	if len(netMap["frontend"]) != 0 {
+		t.Errorf("frontend should be empty, got %+v", netMap["frontend"])
+	}
+	if got := s.Containers["postgres"].AliasesOn("data"); len(got) != 1 || got[0] != "db" {
+		t.Errorf("unexpected postgres aliases: %v", got)
+	}
+
+	// The originals are untouched.
+	if len(networks) != 3 || !containers["api"].HasNetwork("frontend") || containers["postgres"].HasNetwork("data") {
+		t.Error("Apply should not modify the original topology")
+	}
+}
+
+func TestStateApplyRemoveNetworkDetachesContainers(t *testing.T) {
+	networks, containers := testState()
+	s := NewState(networks, containers)
+
+	if err := s.Apply([]Change{{Action: ActionRemoveNetwork, Network: "frontend"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if s.Containers["api"].HasNetwork("frontend") || s.Containers["nginx"].NetworkCount() != 0 {
+		t.Error("containers should be detached from the removed network")
+	}
+}
+
+func TestStateApplyErrors(t *testing.T) {
+	tests := map[string]Change{
+		"unknown container":  {Action: ActionConnect, Container: "missing", Network: "backend"},
+		"unknown network":    {Action: ActionConnect, Container: "api", Network: "missing"},
+		"already connected":  {Action: ActionConnect, Container: "api", Network: "backend"},
+		"not connected":      {Action: ActionDisconnect, Container: "nginx", Network: "backend"},
+		"duplicate network":  {Action: ActionAddNetwork, Network: "backend"},
+		"missing network":    {Action: ActionRemoveNetwork, Network: "missing"},
+		"duplicate":          {Action: ActionAddContainer, Container: "api"},
+		"add on unknown net": {Action: ActionAddContainer, Container: "worker", Networks: []string{"missing"}},
+		"remove unknown":     {Action: ActionRemoveContainer, Container: "missing"},
+		"invalid":            {Action: ActionConnect, Container: "api"},
+	}
+
+	for name, change := range tests {
+		t.Run(name, func(t *testing.T) {
+			networks, containers := testState()
+			if err := NewState(networks, containers).Apply([]Change{change}); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
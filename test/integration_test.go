@@ -272,7 +272,7 @@ func TestIntegration_ContainerTreeOutput(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	output.PrintContainerTree(&buf, containerInfo, networkToContainers)
+	output.PrintContainerTree(&buf, containerInfo, networkToContainers, nil)
 
 	result := buf.String()
 
@@ -369,7 +369,7 @@ func TestIntegration_ContainerWithNoReachability(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	output.PrintContainerTree(&buf, containerInfo, networkToContainers)
+	output.PrintContainerTree(&buf, containerInfo, networkToContainers, nil)
 
 	result := buf.String()
 
@@ -86,7 +86,7 @@ func TestOutputFormat_ContainerTreeStructure(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	output.PrintContainerTree(&buf, containerInfo, networkToContainers)
+	output.PrintContainerTree(&buf, containerInfo, networkToContainers, nil)
 
 	result := buf.String()
 	lines := strings.Split(strings.TrimSpace(result), "\n")
@@ -195,7 +195,7 @@ func TestOutputFormat_ConnectsToDisplay(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	output.PrintContainerTree(&buf, containerInfo, networkToContainers)
+	output.PrintContainerTree(&buf, containerInfo, networkToContainers, nil)
 
 	result := buf.String()
 
@@ -250,7 +250,7 @@ func TestOutputFormat_NoReachableContainersMessage(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	output.PrintContainerTree(&buf, containerInfo, networkToContainers)
+	output.PrintContainerTree(&buf, containerInfo, networkToContainers, nil)
 
 	result := buf.String()
 
@@ -283,7 +283,7 @@ func TestOutputFormat_MultipleNetworksPerContainer(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	output.PrintContainerTree(&buf, containerInfo, networkToContainers)
+	output.PrintContainerTree(&buf, containerInfo, networkToContainers, nil)
 
 	result := buf.String()
 
@@ -349,7 +349,7 @@ func TestOutputFormat_SortedNetworksInContainerTree(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	output.PrintContainerTree(&buf, containerInfo, networkToContainers)
+	output.PrintContainerTree(&buf, containerInfo, networkToContainers, nil)
 
 	result := buf.String()
 